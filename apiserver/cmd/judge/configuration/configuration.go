@@ -0,0 +1,138 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultTestsDir    = "/var/lib/cplatform/tests"
+	defaultWorkDir     = "/var/lib/cplatform/judge"
+	defaultCgroupRoot  = "/sys/fs/cgroup/judge"
+	defaultSandboxRoot = "/var/lib/cplatform/sandbox"
+	defaultLanguages   = "/etc/cplatform/languages.json"
+	// nobody
+	defaultSandboxId = 65534
+	defaultWorkers   = 1
+)
+
+type Configuration struct {
+	RedisUrl   string
+	PgsqlUrl   string
+	SlogLevel  slog.Level
+	TestsDir   string
+	WorkDir    string
+	CgroupRoot string
+	// SandboxRoot is empty directory per-run sandbox roots are mounted over
+	SandboxRoot string
+	// LanguagesFile must list the same languages as api server one, otherwise submissions fail with CE
+	LanguagesFile string
+	SandboxUid    int
+	SandboxGid    int
+	Workers       int
+}
+
+var (
+	ErrRedisUrlNotFound    = errors.New("redis URL not found")
+	ErrPgsqlUrlNotFound    = errors.New("pgsql connection string not found")
+	ErrLoggingLevelInvalid = errors.New("logging level invalid")
+	ErrNumberInvalid       = errors.New("number invalid")
+)
+
+var strToSlog = map[string]slog.Level{
+	"":      slog.LevelInfo,
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+func ReadConfigurationFromEnv() (*Configuration, error) {
+	errs := make([]error, 0)
+
+	redisUrl := os.Getenv("JUDGE_REDIS_URL")
+	if redisUrl == "" {
+		errs = append(errs, ErrRedisUrlNotFound)
+	}
+
+	pgsqlUrl := os.Getenv("JUDGE_PGSQL_URL")
+	if pgsqlUrl == "" {
+		errs = append(errs, ErrPgsqlUrlNotFound)
+	}
+
+	logLevelStr := os.Getenv("JUDGE_LOG_LEVEL")
+	logLevelStr = strings.ToLower(logLevelStr)
+
+	logLevel, ok := strToSlog[logLevelStr]
+	if !ok {
+		errs = append(errs, fmt.Errorf("%w: %q level is not recognized; valid are: debug, info, warn, error; default is info", ErrLoggingLevelInvalid, logLevelStr))
+	}
+
+	testsDir := stringOrDefault("JUDGE_TESTS_DIR", defaultTestsDir)
+	workDir := stringOrDefault("JUDGE_WORK_DIR", defaultWorkDir)
+	cgroupRoot := stringOrDefault("JUDGE_CGROUP_ROOT", defaultCgroupRoot)
+	sandboxRoot := stringOrDefault("JUDGE_SANDBOX_ROOT", defaultSandboxRoot)
+	languagesFile := stringOrDefault("JUDGE_LANGUAGES_FILE", defaultLanguages)
+
+	sandboxUid, err := positiveIntOrDefault("JUDGE_SANDBOX_UID", defaultSandboxId)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	sandboxGid, err := positiveIntOrDefault("JUDGE_SANDBOX_GID", defaultSandboxId)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	workers, err := positiveIntOrDefault("JUDGE_WORKERS", defaultWorkers)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	configuration := &Configuration{
+		RedisUrl:      redisUrl,
+		PgsqlUrl:      pgsqlUrl,
+		SlogLevel:     logLevel,
+		TestsDir:      testsDir,
+		WorkDir:       workDir,
+		CgroupRoot:    cgroupRoot,
+		SandboxRoot:   sandboxRoot,
+		LanguagesFile: languagesFile,
+		SandboxUid:    sandboxUid,
+		SandboxGid:    sandboxGid,
+		Workers:       workers,
+	}
+
+	return configuration, nil
+}
+
+func stringOrDefault(name string, defaultValue string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+func positiveIntOrDefault(name string, defaultValue int) (int, error) {
+	str := os.Getenv(name)
+	if str == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(str)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("%w: %s must be positive integer, got %q", ErrNumberInvalid, name, str)
+	}
+
+	return value, nil
+}
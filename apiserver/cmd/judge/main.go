@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"cplatform/cmd/judge/configuration"
+	credis "cplatform/internal/infrastructure/cache/redis"
+	"cplatform/internal/infrastructure/languages/jsonfile"
+	"cplatform/internal/infrastructure/persistence/postgres"
+	qredis "cplatform/internal/infrastructure/queue/redis"
+	"cplatform/internal/infrastructure/storage/filesystem"
+	"cplatform/internal/judge"
+	"cplatform/internal/judge/sandbox"
+	"cplatform/pkg/slogext"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func main() {
+	// sandbox re-executes this binary to set up isolated process before running untrusted code
+	if sandbox.IsInitProcess() {
+		sandbox.RunInit()
+		return
+	}
+
+	config, err := configuration.ReadConfigurationFromEnv()
+	if err != nil {
+		slog.Error("main: error reading configuration from env", slogext.Cause(err))
+		os.Exit(2)
+	}
+
+	// LOGGER
+	handlerOptions := &slog.HandlerOptions{
+		Level: config.SlogLevel,
+	}
+	handler := slog.NewJSONHandler(os.Stdout, handlerOptions)
+	logger := slog.New(handler)
+
+	// REDIS
+	redisOptions, err := redis.ParseURL(config.RedisUrl)
+	if err != nil {
+		logger.Error("main: fail parse redis url", slogext.Cause(err))
+		return
+	}
+
+	redisClient := redis.NewClient(redisOptions)
+
+	// POSTGRES
+	pgConfig, err := pgxpool.ParseConfig(config.PgsqlUrl)
+	if err != nil {
+		logger.Error("main: fail parse pgsql url", slogext.Cause(err))
+		return
+	}
+
+	pgPool, err := pgxpool.NewWithConfig(context.Background(), pgConfig)
+	if err != nil {
+		logger.Error("main: fail create pgxpool", slogext.Cause(err))
+		return
+	}
+
+	// INFRASTRUCTURE
+	uowFactory := postgres.NewUnitOfWorkFactory(pgPool, logger)
+
+	testStorage, err := filesystem.NewTestStorage(config.TestsDir, logger)
+	if err != nil {
+		logger.Error("main: fail create test storage", slogext.Cause(err))
+		return
+	}
+
+	jobQueue := qredis.NewJobQueue(redisClient, logger)
+
+	redisCache := credis.NewRedisCache(redisClient, logger)
+
+	languageRegistry, err := jsonfile.LoadRegistry(config.LanguagesFile)
+	if err != nil {
+		logger.Error("main: fail load languages", slogext.Cause(err))
+		return
+	}
+
+	// SANDBOX
+	err = os.MkdirAll(config.WorkDir, 0o711)
+	if err != nil {
+		logger.Error("main: fail create work dir", slogext.Cause(err))
+		return
+	}
+
+	box, err := sandbox.New(sandbox.Config{
+		CgroupRoot: config.CgroupRoot,
+		RootDir:    config.SandboxRoot,
+		Uid:        config.SandboxUid,
+		Gid:        config.SandboxGid,
+	}, logger)
+	if err != nil {
+		logger.Error("main: fail create sandbox", slogext.Cause(err))
+		return
+	}
+
+	// WORKERS
+	workerConfig := judge.WorkerConfig{
+		WorkDir: config.WorkDir,
+		Uid:     config.SandboxUid,
+		Gid:     config.SandboxGid,
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < config.Workers; i++ {
+		worker := judge.NewWorker(workerConfig, jobQueue, uowFactory, testStorage, languageRegistry, redisCache, box, logger.With(slog.Int("worker", i)))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker.Run(ctx)
+		}()
+	}
+
+	requeuer := judge.NewRequeuer(jobQueue, uowFactory, logger.With(slog.String("component", "requeuer")))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		requeuer.Run(ctx)
+	}()
+
+	logger.Info("main: judge started", slog.Int("workers", config.Workers))
+
+	<-ctx.Done()
+	logger.Info("main: start shutdown")
+
+	wg.Wait()
+	logger.Info("main: judge shutdown gracefully")
+}
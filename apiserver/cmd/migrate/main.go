@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"cplatform/cmd/migrate/configuration"
+	"cplatform/internal/infrastructure/persistence/postgres/migrations"
+	"cplatform/pkg/slogext"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const usage = `usage: migrate <command> [argument]
+
+commands:
+  up [version]        apply pending migrations up to version, all by default
+  down [steps]        revert last applied migrations, one by default
+  status              list migrations and when they were applied
+  baseline <version>  record migrations up to version as applied without running them,
+                      for databases created by pgsql/scripts/cplatformdb.sql
+
+environment:
+  MIGRATE_PGSQL_URL   connection string of database to migrate
+  MIGRATE_LOG_LEVEL   debug, info, warn or error; default is info
+`
+
+func main() {
+	if len(os.Args) < 2 || len(os.Args) > 3 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command := os.Args[1]
+	argument := ""
+	if len(os.Args) == 3 {
+		argument = os.Args[2]
+	}
+
+	config, err := configuration.ReadConfigurationFromEnv()
+	if err != nil {
+		slog.Error("main: error reading configuration from env", slogext.Cause(err))
+		os.Exit(2)
+	}
+
+	// LOGGER
+	handlerOptions := &slog.HandlerOptions{
+		Level: config.SlogLevel,
+	}
+	handler := slog.NewJSONHandler(os.Stderr, handlerOptions)
+	logger := slog.New(handler)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	// POSTGRES
+	pgPool, err := pgxpool.New(ctx, config.PgsqlUrl)
+	if err != nil {
+		logger.Error("main: fail create pgxpool", slogext.Cause(err))
+		os.Exit(1)
+	}
+	defer pgPool.Close()
+
+	migrator, err := migrations.NewMigrator(pgPool, logger)
+	if err != nil {
+		logger.Error("main: fail load migrations", slogext.Cause(err))
+		os.Exit(1)
+	}
+
+	switch command {
+	case "up":
+		var target int64
+		if argument != "" {
+			target, err = strconv.ParseInt(argument, 10, 64)
+			if err != nil || target <= 0 {
+				fmt.Fprintf(os.Stderr, "version %q must be positive integer\n", argument)
+				os.Exit(2)
+			}
+		}
+
+		applied, err := migrator.Up(ctx, target)
+		printMigrations("applied", applied)
+		exitOnError(logger, "fail apply migrations", err)
+	case "down":
+		steps := 1
+		if argument != "" {
+			steps, err = strconv.Atoi(argument)
+			if err != nil || steps <= 0 {
+				fmt.Fprintf(os.Stderr, "steps %q must be positive integer\n", argument)
+				os.Exit(2)
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		printMigrations("reverted", reverted)
+		exitOnError(logger, "fail revert migrations", err)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		exitOnError(logger, "fail read migrations status", err)
+		printStatuses(statuses)
+	case "baseline":
+		version, err := strconv.ParseInt(argument, 10, 64)
+		if err != nil || version <= 0 {
+			fmt.Fprintf(os.Stderr, "version %q must be positive integer\n", argument)
+			os.Exit(2)
+		}
+
+		err = migrator.Baseline(ctx, version)
+		exitOnError(logger, "fail baseline migrations", err)
+		fmt.Printf("baselined at %d\n", version)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func exitOnError(logger *slog.Logger, msg string, err error) {
+	if err != nil {
+		logger.Error("main: "+msg, slogext.Cause(err))
+		os.Exit(1)
+	}
+}
+
+func printMigrations(action string, list []*migrations.Migration) {
+	for _, migration := range list {
+		fmt.Printf("%s %d_%s\n", action, migration.Version, migration.Name)
+	}
+
+	if len(list) == 0 {
+		fmt.Printf("nothing %s\n", action)
+	}
+}
+
+func printStatuses(statuses []migrations.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+
+		if status.Unknown {
+			appliedAt += " (unknown to this build)"
+		}
+
+		if status.Modified {
+			appliedAt += " (modified since applied)"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+
+	_ = w.Flush()
+}
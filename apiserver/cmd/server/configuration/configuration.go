@@ -1,23 +1,152 @@
 package configuration
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
+const defaultTestsDir = "/var/lib/cplatform/tests"
+const defaultLanguagesFile = "/etc/cplatform/languages.json"
+const defaultSessionTtl = 72 * time.Hour
+const defaultLoginAccountAttempts = 5
+const defaultLoginIpAttempts = 50
+const defaultLoginWindow = time.Hour
+const defaultLoginLockout = time.Minute
+const defaultLoginMaxLockout = time.Hour
+const defaultOidcScopes = "openid email profile"
+const defaultHttpAddr = ":80"
+const defaultHttpsAddr = ":443"
+const defaultReadHeaderTimeout = 5 * time.Second
+const defaultReadTimeout = time.Minute
+const defaultWriteTimeout = time.Minute
+const defaultIdleTimeout = 2 * time.Minute
+const defaultMaxHeaderBytes = 1 << 20
+const defaultShutdownTimeout = 10 * time.Second
+const defaultStartupTimeout = time.Minute
+
+// redacted replaces secrets in printed configuration
+const redacted = "REDACTED"
+
+// Configuration is read from defaults, then optional yaml file, then APISERVER_* environment variables, each layer overriding previous one
 type Configuration struct {
-	RedisUrl  string
-	PgsqlUrl  string
-	SlogLevel slog.Level
+	// LogLevel is one of debug, info, warn, error
+	LogLevel  string         `yaml:"log_level"`
+	SlogLevel slog.Level     `yaml:"-"`
+	Http      HttpConfig     `yaml:"http"`
+	Postgres  PostgresConfig `yaml:"postgres"`
+	Redis     RedisConfig    `yaml:"redis"`
+	Auth      AuthConfig     `yaml:"auth"`
+	Limits    LimitsConfig   `yaml:"limits"`
+	Judge     JudgeConfig    `yaml:"judge"`
+}
+
+type HttpConfig struct {
+	// Addr defaults to :443 when TLS is configured and to :80 otherwise
+	Addr string `yaml:"addr"`
+	// TlsCertFile and TlsKeyFile are set together; files are reread when they change
+	TlsCertFile string `yaml:"tls_cert_file"`
+	TlsKeyFile  string `yaml:"tls_key_file"`
+	// timeouts follow http.Server, zero disables them; ReadTimeout and WriteTimeout bound whole body transfer
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes"`
+	// ShutdownTimeout is grace period for in-flight requests on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ShutdownDelay is time between failing readiness and closing listener, so load balancer stops routing requests first
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+	// H2c serves HTTP/2 without TLS for proxies talking prior knowledge HTTP/2
+	H2c bool `yaml:"h2c"`
+	// DebugAddr serves /debug/vars with pool statistics when set; keep it unreachable from outside
+	DebugAddr string `yaml:"debug_addr"`
+}
+
+type PostgresConfig struct {
+	Url string `yaml:"url"`
+	// MigrateOnStart applies pending schema migrations before serving; otherwise they are applied by cmd/migrate
+	MigrateOnStart bool `yaml:"migrate_on_start"`
+	// pool settings left zero keep value from url, like pool_max_conns, or pgxpool default
+	MaxConns          int           `yaml:"max_conns"`
+	MinConns          int           `yaml:"min_conns"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period"`
+	// StatementTimeout aborts statements running longer on server side; zero disables it
+	StatementTimeout time.Duration `yaml:"statement_timeout"`
+	// StartupTimeout bounds retries of first connection, so server waits for database started along with it
+	StartupTimeout time.Duration `yaml:"startup_timeout"`
+}
+
+type RedisConfig struct {
+	Url string `yaml:"url"`
+	// pool settings left zero keep value from url, like pool_size, or go-redis default
+	PoolSize        int           `yaml:"pool_size"`
+	MinIdleConns    int           `yaml:"min_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+	DialTimeout     time.Duration `yaml:"dial_timeout"`
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	// StartupTimeout bounds retries of first connection
+	StartupTimeout time.Duration `yaml:"startup_timeout"`
+}
+
+type AuthConfig struct {
+	// SessionTtl is lifetime of bearer session tokens
+	SessionTtl time.Duration `yaml:"session_ttl"`
+	// OidcProviders are OpenID Connect providers users may sign in with
+	OidcProviders []OidcProvider `yaml:"oidc_providers"`
+}
+
+type OidcProvider struct {
+	Name         string   `yaml:"name"`
+	Issuer       string   `yaml:"issuer"`
+	ClientId     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectUrl  string   `yaml:"redirect_url"`
+	Scopes       []string `yaml:"scopes"`
+}
+
+type LimitsConfig struct {
+	// LoginAccountAttempts and LoginIpAttempts are failed logins allowed per account and per client address within LoginWindow
+	LoginAccountAttempts int           `yaml:"login_account_attempts"`
+	LoginIpAttempts      int           `yaml:"login_ip_attempts"`
+	LoginWindow          time.Duration `yaml:"login_window"`
+	// LoginLockout is first lockout after limit is reached; it doubles on every further failure up to LoginMaxLockout
+	LoginLockout    time.Duration `yaml:"login_lockout"`
+	LoginMaxLockout time.Duration `yaml:"login_max_lockout"`
+}
+
+type JudgeConfig struct {
+	// TestsDir is shared with judges, which read tests uploaded through api
+	TestsDir string `yaml:"tests_dir"`
+	// LanguagesFile is json array of languages accepted for submissions
+	LanguagesFile string `yaml:"languages_file"`
 }
 
 var (
+	ErrConfigFileInvalid   = errors.New("configuration file invalid")
 	ErrRedisUrlNotFound    = errors.New("redis URL not found")
 	ErrPgsqlUrlNotFound    = errors.New("pgsql connection string not found")
 	ErrLoggingLevelInvalid = errors.New("logging level invalid")
+	ErrSessionTtlInvalid   = errors.New("session ttl invalid")
+	ErrLoginLimitInvalid   = errors.New("login limit invalid")
+	ErrOidcProviderInvalid = errors.New("oidc provider invalid")
+	ErrMigrateFlagInvalid  = errors.New("migrate on start flag invalid")
+	ErrHttpConfigInvalid   = errors.New("http configuration invalid")
+	ErrPgsqlPoolInvalid    = errors.New("pgsql pool configuration invalid")
+	ErrRedisPoolInvalid    = errors.New("redis pool configuration invalid")
 )
 
 var strToSlog = map[string]slog.Level{
@@ -28,36 +157,482 @@ var strToSlog = map[string]slog.Level{
 	"error": slog.LevelError,
 }
 
-func ReadConfigurationFromEnv() (*Configuration, error) {
+// ReadConfiguration layers file at path, when it is not empty, and environment over defaults; all found problems are reported at once
+func ReadConfiguration(path string) (*Configuration, error) {
 	errs := make([]error, 0)
 
-	redisUrl := os.Getenv("APISERVER_REDIS_URL")
-	if redisUrl == "" {
-		errs = append(errs, ErrRedisUrlNotFound)
+	config := defaultConfiguration()
+
+	if path != "" {
+		if err := readFile(path, config); err != nil {
+			return nil, err
+		}
 	}
 
-	pgsqlUrl := os.Getenv("APISERVER_PGSQL_URL")
-	if pgsqlUrl == "" {
-		errs = append(errs, ErrPgsqlUrlNotFound)
+	readEnv(config, &errs)
+	validate(config, &errs)
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return config, nil
+}
+
+// Redacted returns copy safe to print: passwords in connection strings and client secrets are replaced
+func (c *Configuration) Redacted() *Configuration {
+	copied := *c
+
+	copied.Postgres.Url = redactUrl(c.Postgres.Url)
+	copied.Redis.Url = redactUrl(c.Redis.Url)
+
+	copied.Auth.OidcProviders = make([]OidcProvider, 0, len(c.Auth.OidcProviders))
+	for _, provider := range c.Auth.OidcProviders {
+		if provider.ClientSecret != "" {
+			provider.ClientSecret = redacted
+		}
+
+		copied.Auth.OidcProviders = append(copied.Auth.OidcProviders, provider)
 	}
 
-	logLevelStr := os.Getenv("APISERVER_LOG_LEVEL")
-	logLevelStr = strings.ToLower(logLevelStr)
+	return &copied
+}
+
+// Yaml formats configuration the way file is read
+func (c *Configuration) Yaml() ([]byte, error) {
+	var buf bytes.Buffer
 
-	logLevel, ok := strToSlog[logLevelStr]
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(c); err != nil {
+		return nil, fmt.Errorf("fail encode configuration: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("fail encode configuration: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func defaultConfiguration() *Configuration {
+	return &Configuration{
+		LogLevel: "info",
+		Http: HttpConfig{
+			ReadHeaderTimeout: defaultReadHeaderTimeout,
+			ReadTimeout:       defaultReadTimeout,
+			WriteTimeout:      defaultWriteTimeout,
+			IdleTimeout:       defaultIdleTimeout,
+			MaxHeaderBytes:    defaultMaxHeaderBytes,
+			ShutdownTimeout:   defaultShutdownTimeout,
+		},
+		Postgres: PostgresConfig{
+			StartupTimeout: defaultStartupTimeout,
+		},
+		Redis: RedisConfig{
+			StartupTimeout: defaultStartupTimeout,
+		},
+		Auth: AuthConfig{
+			SessionTtl:    defaultSessionTtl,
+			OidcProviders: make([]OidcProvider, 0),
+		},
+		Limits: LimitsConfig{
+			LoginAccountAttempts: defaultLoginAccountAttempts,
+			LoginIpAttempts:      defaultLoginIpAttempts,
+			LoginWindow:          defaultLoginWindow,
+			LoginLockout:         defaultLoginLockout,
+			LoginMaxLockout:      defaultLoginMaxLockout,
+		},
+		Judge: JudgeConfig{
+			TestsDir:      defaultTestsDir,
+			LanguagesFile: defaultLanguagesFile,
+		},
+	}
+}
+
+func readFile(path string, config *Configuration) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrConfigFileInvalid, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	// misspelled keys would silently fall back to defaults otherwise
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %s: %s", ErrConfigFileInvalid, path, err)
+	}
+
+	return nil
+}
+
+func readEnv(config *Configuration, errs *[]error) {
+	readString("APISERVER_LOG_LEVEL", &config.LogLevel)
+
+	readString("APISERVER_HTTP_ADDR", &config.Http.Addr)
+	readString("APISERVER_TLS_CERT_FILE", &config.Http.TlsCertFile)
+	readString("APISERVER_TLS_KEY_FILE", &config.Http.TlsKeyFile)
+	readDuration("APISERVER_HTTP_READ_HEADER_TIMEOUT", &config.Http.ReadHeaderTimeout, ErrHttpConfigInvalid, errs)
+	readDuration("APISERVER_HTTP_READ_TIMEOUT", &config.Http.ReadTimeout, ErrHttpConfigInvalid, errs)
+	readDuration("APISERVER_HTTP_WRITE_TIMEOUT", &config.Http.WriteTimeout, ErrHttpConfigInvalid, errs)
+	readDuration("APISERVER_HTTP_IDLE_TIMEOUT", &config.Http.IdleTimeout, ErrHttpConfigInvalid, errs)
+	readInt("APISERVER_HTTP_MAX_HEADER_BYTES", &config.Http.MaxHeaderBytes, ErrHttpConfigInvalid, errs)
+	readDuration("APISERVER_SHUTDOWN_TIMEOUT", &config.Http.ShutdownTimeout, ErrHttpConfigInvalid, errs)
+	readDuration("APISERVER_SHUTDOWN_DELAY", &config.Http.ShutdownDelay, ErrHttpConfigInvalid, errs)
+	readBool("APISERVER_HTTP_H2C", &config.Http.H2c, ErrHttpConfigInvalid, errs)
+	readString("APISERVER_HTTP_DEBUG_ADDR", &config.Http.DebugAddr)
+
+	readString("APISERVER_PGSQL_URL", &config.Postgres.Url)
+	readBool("APISERVER_MIGRATE_ON_START", &config.Postgres.MigrateOnStart, ErrMigrateFlagInvalid, errs)
+	readInt("APISERVER_PGSQL_MAX_CONNS", &config.Postgres.MaxConns, ErrPgsqlPoolInvalid, errs)
+	readInt("APISERVER_PGSQL_MIN_CONNS", &config.Postgres.MinConns, ErrPgsqlPoolInvalid, errs)
+	readDuration("APISERVER_PGSQL_MAX_CONN_LIFETIME", &config.Postgres.MaxConnLifetime, ErrPgsqlPoolInvalid, errs)
+	readDuration("APISERVER_PGSQL_MAX_CONN_IDLE_TIME", &config.Postgres.MaxConnIdleTime, ErrPgsqlPoolInvalid, errs)
+	readDuration("APISERVER_PGSQL_HEALTH_CHECK_PERIOD", &config.Postgres.HealthCheckPeriod, ErrPgsqlPoolInvalid, errs)
+	readDuration("APISERVER_PGSQL_STATEMENT_TIMEOUT", &config.Postgres.StatementTimeout, ErrPgsqlPoolInvalid, errs)
+	readDuration("APISERVER_PGSQL_STARTUP_TIMEOUT", &config.Postgres.StartupTimeout, ErrPgsqlPoolInvalid, errs)
+
+	readString("APISERVER_REDIS_URL", &config.Redis.Url)
+	readInt("APISERVER_REDIS_POOL_SIZE", &config.Redis.PoolSize, ErrRedisPoolInvalid, errs)
+	readInt("APISERVER_REDIS_MIN_IDLE_CONNS", &config.Redis.MinIdleConns, ErrRedisPoolInvalid, errs)
+	readDuration("APISERVER_REDIS_CONN_MAX_LIFETIME", &config.Redis.ConnMaxLifetime, ErrRedisPoolInvalid, errs)
+	readDuration("APISERVER_REDIS_CONN_MAX_IDLE_TIME", &config.Redis.ConnMaxIdleTime, ErrRedisPoolInvalid, errs)
+	readDuration("APISERVER_REDIS_DIAL_TIMEOUT", &config.Redis.DialTimeout, ErrRedisPoolInvalid, errs)
+	readDuration("APISERVER_REDIS_READ_TIMEOUT", &config.Redis.ReadTimeout, ErrRedisPoolInvalid, errs)
+	readDuration("APISERVER_REDIS_WRITE_TIMEOUT", &config.Redis.WriteTimeout, ErrRedisPoolInvalid, errs)
+	readDuration("APISERVER_REDIS_STARTUP_TIMEOUT", &config.Redis.StartupTimeout, ErrRedisPoolInvalid, errs)
+
+	readDuration("APISERVER_SESSION_TTL", &config.Auth.SessionTtl, ErrSessionTtlInvalid, errs)
+	readOidcProviders(config)
+
+	readInt("APISERVER_LOGIN_ACCOUNT_ATTEMPTS", &config.Limits.LoginAccountAttempts, ErrLoginLimitInvalid, errs)
+	readInt("APISERVER_LOGIN_IP_ATTEMPTS", &config.Limits.LoginIpAttempts, ErrLoginLimitInvalid, errs)
+	readDuration("APISERVER_LOGIN_WINDOW", &config.Limits.LoginWindow, ErrLoginLimitInvalid, errs)
+	readDuration("APISERVER_LOGIN_LOCKOUT", &config.Limits.LoginLockout, ErrLoginLimitInvalid, errs)
+	readDuration("APISERVER_LOGIN_MAX_LOCKOUT", &config.Limits.LoginMaxLockout, ErrLoginLimitInvalid, errs)
+
+	readString("APISERVER_TESTS_DIR", &config.Judge.TestsDir)
+	readString("APISERVER_LANGUAGES_FILE", &config.Judge.LanguagesFile)
+}
+
+// readOidcProviders replaces providers from file with ones named in APISERVER_OIDC_PROVIDERS, keeping file settings of same names;
+// settings of each provider are then overridden by APISERVER_OIDC_<NAME>_* variables, so secrets can stay out of file
+func readOidcProviders(config *Configuration) {
+	if names, ok := os.LookupEnv("APISERVER_OIDC_PROVIDERS"); ok {
+		fromFile := config.Auth.OidcProviders
+		config.Auth.OidcProviders = make([]OidcProvider, 0)
+
+		for _, name := range strings.Split(names, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+
+			provider := OidcProvider{Name: name}
+			for _, candidate := range fromFile {
+				if candidate.Name == name {
+					provider = candidate
+				}
+			}
+
+			config.Auth.OidcProviders = append(config.Auth.OidcProviders, provider)
+		}
+	}
+
+	for i := range config.Auth.OidcProviders {
+		provider := &config.Auth.OidcProviders[i]
+		prefix := oidcEnvPrefix(provider.Name)
+
+		readString(prefix+"ISSUER", &provider.Issuer)
+		readString(prefix+"CLIENT_ID", &provider.ClientId)
+		readString(prefix+"CLIENT_SECRET", &provider.ClientSecret)
+		readString(prefix+"REDIRECT_URL", &provider.RedirectUrl)
+
+		if scopes := os.Getenv(prefix + "SCOPES"); scopes != "" {
+			provider.Scopes = strings.Fields(scopes)
+		}
+	}
+}
+
+func validate(config *Configuration, errs *[]error) {
+	logLevel, ok := strToSlog[strings.ToLower(config.LogLevel)]
 	if !ok {
-		errs = append(errs, fmt.Errorf("%w: %q level is not recognized; valid are: debug, info, warn, error; default is info", ErrLoggingLevelInvalid, logLevelStr))
+		*errs = append(*errs, fmt.Errorf("%w: %q level is not recognized; valid are: debug, info, warn, error; default is info", ErrLoggingLevelInvalid, config.LogLevel))
 	}
 
-	if len(errs) > 0 {
-		return nil, errors.Join(errs...)
+	config.SlogLevel = logLevel
+
+	validateHttp(&config.Http, errs)
+
+	if config.Postgres.Url == "" {
+		*errs = append(*errs, ErrPgsqlUrlNotFound)
+	}
+
+	validatePostgres(&config.Postgres, errs)
+
+	if config.Redis.Url == "" {
+		*errs = append(*errs, ErrRedisUrlNotFound)
+	}
+
+	validateRedis(&config.Redis, errs)
+
+	if config.Auth.SessionTtl <= 0 {
+		*errs = append(*errs, fmt.Errorf("%w: %s must be positive duration like 72h", ErrSessionTtlInvalid, config.Auth.SessionTtl))
+	}
+
+	for i := range config.Auth.OidcProviders {
+		provider := &config.Auth.OidcProviders[i]
+
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = strings.Fields(defaultOidcScopes)
+		}
+
+		if provider.Name == "" || provider.Issuer == "" || provider.ClientId == "" || provider.RedirectUrl == "" {
+			prefix := oidcEnvPrefix(provider.Name)
+			*errs = append(*errs, fmt.Errorf("%w: %q needs name, issuer, client id and redirect url, set in file or by %sISSUER, %sCLIENT_ID and %sREDIRECT_URL", ErrOidcProviderInvalid, provider.Name, prefix, prefix, prefix))
+		}
+	}
+
+	limits := &config.Limits
+	requirePositive("login account attempts", limits.LoginAccountAttempts, ErrLoginLimitInvalid, errs)
+	requirePositive("login ip attempts", limits.LoginIpAttempts, ErrLoginLimitInvalid, errs)
+	requirePositive("login window", limits.LoginWindow, ErrLoginLimitInvalid, errs)
+	requirePositive("login lockout", limits.LoginLockout, ErrLoginLimitInvalid, errs)
+	requirePositive("login max lockout", limits.LoginMaxLockout, ErrLoginLimitInvalid, errs)
+
+	if limits.LoginMaxLockout < limits.LoginLockout {
+		*errs = append(*errs, fmt.Errorf("%w: max lockout %s is less than lockout %s", ErrLoginLimitInvalid, limits.LoginMaxLockout, limits.LoginLockout))
+	}
+}
+
+func validateHttp(config *HttpConfig, errs *[]error) {
+	if (config.TlsCertFile == "") != (config.TlsKeyFile == "") {
+		*errs = append(*errs, fmt.Errorf("%w: tls cert file and key file must be set together", ErrHttpConfigInvalid))
+	}
+
+	if config.Addr == "" {
+		config.Addr = defaultHttpAddr
+		if config.TlsCertFile != "" {
+			config.Addr = defaultHttpsAddr
+		}
+	}
+
+	requireNonNegative("read header timeout", config.ReadHeaderTimeout, ErrHttpConfigInvalid, errs)
+	requireNonNegative("read timeout", config.ReadTimeout, ErrHttpConfigInvalid, errs)
+	requireNonNegative("write timeout", config.WriteTimeout, ErrHttpConfigInvalid, errs)
+	requireNonNegative("idle timeout", config.IdleTimeout, ErrHttpConfigInvalid, errs)
+	requirePositive("max header bytes", config.MaxHeaderBytes, ErrHttpConfigInvalid, errs)
+	requirePositive("shutdown timeout", config.ShutdownTimeout, ErrHttpConfigInvalid, errs)
+	requireNonNegative("shutdown delay", config.ShutdownDelay, ErrHttpConfigInvalid, errs)
+}
+
+func validatePostgres(config *PostgresConfig, errs *[]error) {
+	requireNonNegative("max conns", config.MaxConns, ErrPgsqlPoolInvalid, errs)
+	requireNonNegative("min conns", config.MinConns, ErrPgsqlPoolInvalid, errs)
+	requireNonNegative("max conn lifetime", config.MaxConnLifetime, ErrPgsqlPoolInvalid, errs)
+	requireNonNegative("max conn idle time", config.MaxConnIdleTime, ErrPgsqlPoolInvalid, errs)
+	requireNonNegative("health check period", config.HealthCheckPeriod, ErrPgsqlPoolInvalid, errs)
+	requireNonNegative("statement timeout", config.StatementTimeout, ErrPgsqlPoolInvalid, errs)
+	requirePositive("startup timeout", config.StartupTimeout, ErrPgsqlPoolInvalid, errs)
+
+	if config.MaxConns > 0 && config.MinConns > config.MaxConns {
+		*errs = append(*errs, fmt.Errorf("%w: min conns %d is greater than max conns %d", ErrPgsqlPoolInvalid, config.MinConns, config.MaxConns))
+	}
+}
+
+func validateRedis(config *RedisConfig, errs *[]error) {
+	requireNonNegative("pool size", config.PoolSize, ErrRedisPoolInvalid, errs)
+	requireNonNegative("min idle conns", config.MinIdleConns, ErrRedisPoolInvalid, errs)
+	requireNonNegative("conn max lifetime", config.ConnMaxLifetime, ErrRedisPoolInvalid, errs)
+	requireNonNegative("conn max idle time", config.ConnMaxIdleTime, ErrRedisPoolInvalid, errs)
+	requireNonNegative("dial timeout", config.DialTimeout, ErrRedisPoolInvalid, errs)
+	requireNonNegative("read timeout", config.ReadTimeout, ErrRedisPoolInvalid, errs)
+	requireNonNegative("write timeout", config.WriteTimeout, ErrRedisPoolInvalid, errs)
+	requirePositive("startup timeout", config.StartupTimeout, ErrRedisPoolInvalid, errs)
+
+	if config.PoolSize > 0 && config.MinIdleConns > config.PoolSize {
+		*errs = append(*errs, fmt.Errorf("%w: min idle conns %d is greater than pool size %d", ErrRedisPoolInvalid, config.MinIdleConns, config.PoolSize))
+	}
+}
+
+func requirePositive[T int | time.Duration](name string, value T, kind error, errs *[]error) {
+	if value <= 0 {
+		*errs = append(*errs, fmt.Errorf("%w: %s %v must be positive", kind, name, value))
+	}
+}
+
+// requireNonNegative is for timeouts, where zero disables timeout, and for settings where zero keeps default
+func requireNonNegative[T int | time.Duration](name string, value T, kind error, errs *[]error) {
+	if value < 0 {
+		*errs = append(*errs, fmt.Errorf("%w: %s %v must not be negative", kind, name, value))
+	}
+}
+
+// oidcEnvPrefix keeps names usable in env variable names, where dashes are not allowed
+func oidcEnvPrefix(name string) string {
+	return "APISERVER_OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
+
+// redactUrl masks password in url userinfo and password query parameter, or password keyword of key=value connection string
+func redactUrl(raw string) string {
+	if raw == "" {
+		return raw
+	}
+
+	if !strings.Contains(raw, "://") {
+		return redactKeywordValue(raw)
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		// unparsable url may still carry password
+		return redacted
+	}
+
+	if _, hasPassword := parsed.User.Password(); hasPassword {
+		parsed.User = url.UserPassword(parsed.User.Username(), redacted)
+	}
+
+	query, err := url.ParseQuery(parsed.RawQuery)
+	if err != nil {
+		return redacted
+	}
+
+	if query.Has("password") {
+		query.Set("password", redacted)
+		parsed.RawQuery = query.Encode()
+	}
+
+	return parsed.String()
+}
+
+// redactKeywordValue masks password in libpq style "host=db password='se cret'" string, keeping rest as written
+func redactKeywordValue(raw string) string {
+	var b strings.Builder
+
+	i := 0
+	for {
+		start := i
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+
+		if i == len(raw) {
+			b.WriteString(raw[start:])
+			return b.String()
+		}
+
+		keyStart := i
+		for i < len(raw) && raw[i] != '=' && !isSpace(raw[i]) {
+			i++
+		}
+
+		key := raw[keyStart:i]
+
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+
+		if key == "" || i == len(raw) || raw[i] != '=' {
+			return redacted
+		}
+
+		i++
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+
+		valueStart := i
+		if i < len(raw) && raw[i] == '\'' {
+			i++
+			for i < len(raw) && raw[i] != '\'' {
+				if raw[i] == '\\' {
+					i++
+				}
+				i++
+			}
+
+			if i >= len(raw) {
+				// unterminated quote, value boundary is unknown
+				return redacted
+			}
+
+			i++
+		} else {
+			for i < len(raw) && !isSpace(raw[i]) {
+				if raw[i] == '\\' {
+					i++
+				}
+				i++
+			}
+
+			if i > len(raw) {
+				return redacted
+			}
+		}
+
+		b.WriteString(raw[start:valueStart])
+		if key == "password" {
+			b.WriteString(redacted)
+		} else {
+			b.WriteString(raw[valueStart:i])
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func readString(name string, target *string) {
+	if raw := os.Getenv(name); raw != "" {
+		*target = raw
+	}
+}
+
+func readInt(name string, target *int, kind error, errs *[]error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%w: %s=%q must be integer", kind, name, raw))
+		return
+	}
+
+	*target = value
+}
+
+func readDuration(name string, target *time.Duration, kind error, errs *[]error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return
+	}
+
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%w: %s=%q must be duration like 15m", kind, name, raw))
+		return
+	}
+
+	*target = value
+}
+
+func readBool(name string, target *bool, kind error, errs *[]error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return
 	}
 
-	configuration := &Configuration{
-		RedisUrl:  redisUrl,
-		PgsqlUrl:  pgsqlUrl,
-		SlogLevel: logLevel,
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%w: %s=%q must be true or false", kind, name, raw))
+		return
 	}
 
-	return configuration, nil
+	*target = value
 }
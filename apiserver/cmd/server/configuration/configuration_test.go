@@ -0,0 +1,249 @@
+package configuration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testPgsqlUrl = "postgres://cplatform:secret@db:5432/cplatform"
+	testRedisUrl = "redis://:secret@redis:6379/0"
+)
+
+func TestReadConfiguration(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		env      map[string]string
+		check    func(t *testing.T, config *Configuration)
+		wantErrs []error
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{"APISERVER_PGSQL_URL": testPgsqlUrl, "APISERVER_REDIS_URL": testRedisUrl},
+			check: func(t *testing.T, config *Configuration) {
+				if config.Http.Addr != defaultHttpAddr || config.Http.ReadTimeout != defaultReadTimeout || config.Auth.SessionTtl != defaultSessionTtl {
+					t.Errorf("defaults not applied: %+v", config)
+				}
+
+				if config.Postgres.Url != testPgsqlUrl || config.Redis.Url != testRedisUrl {
+					t.Errorf("urls = %q, %q", config.Postgres.Url, config.Redis.Url)
+				}
+			},
+		},
+		{
+			name: "file",
+			file: `
+log_level: debug
+http:
+  read_timeout: 30s
+postgres:
+  url: ` + testPgsqlUrl + `
+  statement_timeout: 15s
+redis:
+  url: ` + testRedisUrl + `
+limits:
+  login_ip_attempts: 10
+`,
+			check: func(t *testing.T, config *Configuration) {
+				if config.LogLevel != "debug" || config.Http.ReadTimeout != 30*time.Second ||
+					config.Postgres.StatementTimeout != 15*time.Second || config.Limits.LoginIpAttempts != 10 {
+					t.Errorf("file values not applied: %+v", config)
+				}
+
+				// keys absent in file keep defaults
+				if config.Http.WriteTimeout != defaultWriteTimeout || config.Limits.LoginAccountAttempts != defaultLoginAccountAttempts {
+					t.Errorf("defaults lost: %+v", config)
+				}
+			},
+		},
+		{
+			name: "env overrides file",
+			file: `
+http:
+  read_timeout: 30s
+postgres:
+  url: postgres://file/db
+redis:
+  url: ` + testRedisUrl + `
+`,
+			env: map[string]string{
+				"APISERVER_HTTP_READ_TIMEOUT":     "45s",
+				"APISERVER_PGSQL_URL":             testPgsqlUrl,
+				"APISERVER_MIGRATE_ON_START":      "true",
+				"APISERVER_HTTP_MAX_HEADER_BYTES": "4096",
+			},
+			check: func(t *testing.T, config *Configuration) {
+				if config.Http.ReadTimeout != 45*time.Second || config.Postgres.Url != testPgsqlUrl ||
+					!config.Postgres.MigrateOnStart || config.Http.MaxHeaderBytes != 4096 {
+					t.Errorf("env values not applied: %+v", config)
+				}
+			},
+		},
+		{
+			name: "tls changes default address",
+			env: map[string]string{
+				"APISERVER_PGSQL_URL":     testPgsqlUrl,
+				"APISERVER_REDIS_URL":     testRedisUrl,
+				"APISERVER_TLS_CERT_FILE": "/etc/tls/cert.pem",
+				"APISERVER_TLS_KEY_FILE":  "/etc/tls/key.pem",
+			},
+			check: func(t *testing.T, config *Configuration) {
+				if config.Http.Addr != defaultHttpsAddr {
+					t.Errorf("addr = %q, want %q", config.Http.Addr, defaultHttpsAddr)
+				}
+			},
+		},
+		{
+			name: "oidc provider from file completed by env",
+			file: `
+postgres:
+  url: ` + testPgsqlUrl + `
+redis:
+  url: ` + testRedisUrl + `
+auth:
+  oidc_providers:
+    - name: corp-sso
+      issuer: https://sso.example.com
+      redirect_url: https://cplatform.example.com/callback
+`,
+			env: map[string]string{
+				"APISERVER_OIDC_CORP_SSO_CLIENT_ID":     "cplatform",
+				"APISERVER_OIDC_CORP_SSO_CLIENT_SECRET": "secret",
+			},
+			check: func(t *testing.T, config *Configuration) {
+				if len(config.Auth.OidcProviders) != 1 {
+					t.Fatalf("providers = %+v", config.Auth.OidcProviders)
+				}
+
+				provider := config.Auth.OidcProviders[0]
+				if provider.ClientId != "cplatform" || provider.ClientSecret != "secret" || len(provider.Scopes) != 3 {
+					t.Errorf("provider = %+v", provider)
+				}
+			},
+		},
+		{
+			name: "missing urls",
+			// empty variables count as unset, so values from developer environment do not leak in
+			env:      map[string]string{"APISERVER_PGSQL_URL": "", "APISERVER_REDIS_URL": ""},
+			wantErrs: []error{ErrPgsqlUrlNotFound, ErrRedisUrlNotFound},
+		},
+		{
+			name:     "unknown file key",
+			file:     "postgres:\n  uri: " + testPgsqlUrl + "\n",
+			wantErrs: []error{ErrConfigFileInvalid},
+		},
+		{
+			name: "every invalid env is reported",
+			env: map[string]string{
+				"APISERVER_PGSQL_URL":         testPgsqlUrl,
+				"APISERVER_REDIS_URL":         testRedisUrl,
+				"APISERVER_HTTP_READ_TIMEOUT": "soon",
+				"APISERVER_PGSQL_MAX_CONNS":   "many",
+				"APISERVER_LOG_LEVEL":         "verbose",
+			},
+			wantErrs: []error{ErrHttpConfigInvalid, ErrPgsqlPoolInvalid, ErrLoggingLevelInvalid},
+		},
+		{
+			name: "max lockout below lockout",
+			env: map[string]string{
+				"APISERVER_PGSQL_URL":         testPgsqlUrl,
+				"APISERVER_REDIS_URL":         testRedisUrl,
+				"APISERVER_LOGIN_LOCKOUT":     "10m",
+				"APISERVER_LOGIN_MAX_LOCKOUT": "5m",
+			},
+			wantErrs: []error{ErrLoginLimitInvalid},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			path := ""
+			if tt.file != "" {
+				path = filepath.Join(t.TempDir(), "apiserver.yaml")
+				if err := os.WriteFile(path, []byte(tt.file), 0o600); err != nil {
+					t.Fatalf("write file: %v", err)
+				}
+			}
+
+			config, err := ReadConfiguration(path)
+
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("error = %v, want %v", err, want)
+				}
+			}
+
+			if len(tt.wantErrs) > 0 {
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ReadConfiguration: %v", err)
+			}
+
+			tt.check(t, config)
+		})
+	}
+}
+
+func TestRedactUrl(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{name: "empty", raw: "", want: ""},
+		{name: "url without password", raw: "postgres://cplatform@db/cplatform", want: "postgres://cplatform@db/cplatform"},
+		{name: "url password", raw: "postgres://cplatform:secret@db/cplatform", want: "postgres://cplatform:REDACTED@db/cplatform"},
+		{name: "redis password only", raw: "redis://:secret@redis:6379/0", want: "redis://:REDACTED@redis:6379/0"},
+		{name: "query password", raw: "postgres://db/cplatform?password=secret&sslmode=disable", want: "postgres://db/cplatform?password=REDACTED&sslmode=disable"},
+		{name: "unparsable url", raw: "postgres://cplatform:secret@db:port/cplatform", want: redacted},
+		{name: "keyword password", raw: "host=db user=cplatform password=secret dbname=cplatform", want: "host=db user=cplatform password=REDACTED dbname=cplatform"},
+		{name: "keyword password with spaces", raw: "host=db password = secret", want: "host=db password = REDACTED"},
+		{name: "quoted keyword password", raw: `host=db password='se cret \' x' dbname=cplatform`, want: "host=db password=REDACTED dbname=cplatform"},
+		{name: "keyword without password", raw: "host=db passwordfile=/run/pg", want: "host=db passwordfile=/run/pg"},
+		{name: "unterminated quote", raw: "host=db password='secret dbname=cplatform", want: redacted},
+		{name: "keyword without value", raw: "host=db password", want: redacted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactUrl(tt.raw); got != tt.want {
+				t.Errorf("redactUrl(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	config := defaultConfiguration()
+	config.Postgres.Url = testPgsqlUrl
+	config.Redis.Url = testRedisUrl
+	config.Auth.OidcProviders = []OidcProvider{
+		{Name: "corp", ClientSecret: "secret"},
+		{Name: "public"},
+	}
+
+	redactedConfig := config.Redacted()
+
+	if redactedConfig.Postgres.Url != "postgres://cplatform:REDACTED@db:5432/cplatform" || redactedConfig.Redis.Url != "redis://:REDACTED@redis:6379/0" {
+		t.Errorf("urls = %q, %q", redactedConfig.Postgres.Url, redactedConfig.Redis.Url)
+	}
+
+	if redactedConfig.Auth.OidcProviders[0].ClientSecret != redacted || redactedConfig.Auth.OidcProviders[1].ClientSecret != "" {
+		t.Errorf("providers = %+v", redactedConfig.Auth.OidcProviders)
+	}
+
+	// original is still used to connect
+	if config.Postgres.Url != testPgsqlUrl || config.Auth.OidcProviders[0].ClientSecret != "secret" {
+		t.Errorf("Redacted modified original configuration")
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"cplatform/cmd/server/configuration"
+	"cplatform/pkg/backoff"
+	"cplatform/pkg/slogext"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// pingTimeout bounds single connection attempt, so hanging dial does not eat whole startup timeout
+const pingTimeout = 5 * time.Second
+
+var startupBackoff = backoff.Policy{
+	Initial: 500 * time.Millisecond,
+	Max:     10 * time.Second,
+}
+
+func applyPostgresConfig(pgConfig *pgxpool.Config, config *configuration.PostgresConfig) {
+	if config.MaxConns > 0 {
+		pgConfig.MaxConns = int32(config.MaxConns)
+	}
+
+	if config.MinConns > 0 {
+		pgConfig.MinConns = int32(config.MinConns)
+	}
+
+	if config.MaxConnLifetime > 0 {
+		pgConfig.MaxConnLifetime = config.MaxConnLifetime
+	}
+
+	if config.MaxConnIdleTime > 0 {
+		pgConfig.MaxConnIdleTime = config.MaxConnIdleTime
+	}
+
+	if config.HealthCheckPeriod > 0 {
+		pgConfig.HealthCheckPeriod = config.HealthCheckPeriod
+	}
+
+	if config.StatementTimeout > 0 {
+		// sent as startup parameter, so it holds for every connection of pool; migrator lifts it on its own session
+		pgConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(config.StatementTimeout.Milliseconds(), 10)
+	}
+}
+
+func applyRedisConfig(options *redis.Options, config *configuration.RedisConfig) {
+	if config.PoolSize > 0 {
+		options.PoolSize = config.PoolSize
+	}
+
+	if config.MinIdleConns > 0 {
+		options.MinIdleConns = config.MinIdleConns
+	}
+
+	if config.ConnMaxLifetime > 0 {
+		options.ConnMaxLifetime = config.ConnMaxLifetime
+	}
+
+	if config.ConnMaxIdleTime > 0 {
+		options.ConnMaxIdleTime = config.ConnMaxIdleTime
+	}
+
+	if config.DialTimeout > 0 {
+		options.DialTimeout = config.DialTimeout
+	}
+
+	if config.ReadTimeout > 0 {
+		options.ReadTimeout = config.ReadTimeout
+	}
+
+	if config.WriteTimeout > 0 {
+		options.WriteTimeout = config.WriteTimeout
+	}
+}
+
+// waitReachable retries ping with backoff until it succeeds or timeout passes; dependencies started along with server may take a while to accept connections
+func waitReachable(timeout time.Duration, name string, ping func(ctx context.Context) error, logger *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := backoff.Retry(ctx, startupBackoff, func(ctx context.Context) error {
+		attemptCtx, attemptCancel := context.WithTimeout(ctx, pingTimeout)
+		defer attemptCancel()
+
+		return ping(attemptCtx)
+	}, func(attempt int, delay time.Duration, err error) {
+		logger.Warn("main: dependency is not reachable yet", "dependency", name, "attempt", attempt, "retry_in", delay.String(), slogext.Cause(err))
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("main: dependency is reachable", "dependency", name)
+
+	return nil
+}
@@ -3,14 +3,32 @@ package main
 import (
 	"context"
 	"cplatform/cmd/server/configuration"
+	"cplatform/internal/application/authentication/accesstoken"
 	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/application/authentication/bearer"
+	"cplatform/internal/application/authorization/rbac"
+	"cplatform/internal/application/contracts/infrastructure"
 	"cplatform/internal/di/middleware"
 	"cplatform/internal/di/scope"
 	credis "cplatform/internal/infrastructure/cache/redis"
+	"cplatform/internal/infrastructure/identity/oidc"
+	"cplatform/internal/infrastructure/languages/jsonfile"
+	lredis "cplatform/internal/infrastructure/limiter/redis"
+	"cplatform/internal/infrastructure/mail/logmail"
 	"cplatform/internal/infrastructure/persistence/postgres"
+	"cplatform/internal/infrastructure/persistence/postgres/migrations"
+	qredis "cplatform/internal/infrastructure/queue/redis"
+	sredis "cplatform/internal/infrastructure/sessions/redis"
+	"cplatform/internal/infrastructure/storage/filesystem"
+	tredis "cplatform/internal/infrastructure/tokens/redis"
 	controller_http "cplatform/internal/presentation/http/controller"
 	"cplatform/internal/presentation/http/pipeline"
+	"cplatform/pkg/certreload"
+	"cplatform/pkg/poolstats"
 	"cplatform/pkg/slogext"
+	"crypto/tls"
+	"expvar"
+	"flag"
 	"log/slog"
 	"net/http"
 	"os"
@@ -24,12 +42,27 @@ import (
 )
 
 func main() {
-	config, err := configuration.ReadConfigurationFromEnv()
+	configFile := flag.String("config", os.Getenv("APISERVER_CONFIG_FILE"), "yaml configuration file, APISERVER_* environment variables override its values")
+	printConfig := flag.Bool("print-config", false, "print effective configuration with secrets redacted and exit")
+	flag.Parse()
+
+	config, err := configuration.ReadConfiguration(*configFile)
 	if err != nil {
-		slog.Error("main: error reading configuration from env", slogext.Cause(err))
+		slog.Error("main: error reading configuration", slogext.Cause(err))
 		os.Exit(2)
 	}
 
+	if *printConfig {
+		printed, err := config.Redacted().Yaml()
+		if err != nil {
+			slog.Error("main: fail print configuration", slogext.Cause(err))
+			os.Exit(2)
+		}
+
+		os.Stdout.Write(printed)
+		return
+	}
+
 	// LOGGER
 	handlerOptions := &slog.HandlerOptions{
 		Level: config.SlogLevel,
@@ -38,33 +71,118 @@ func main() {
 	logger := slog.New(handler)
 
 	// REDIS
-	redisOptions, err := redis.ParseURL(config.RedisUrl)
+	redisOptions, err := redis.ParseURL(config.Redis.Url)
 	if err != nil {
 		logger.Error("main: fail parse redis url", slogext.Cause(err))
 		return
 	}
 
+	applyRedisConfig(redisOptions, &config.Redis)
 	redisClient := redis.NewClient(redisOptions)
 
+	err = waitReachable(config.Redis.StartupTimeout, "redis", func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	}, logger)
+	if err != nil {
+		logger.Error("main: redis is unreachable", slogext.Cause(err))
+		return
+	}
+
 	// POSTGRES
-	pgConfig, err := pgxpool.ParseConfig(config.PgsqlUrl)
+	pgConfig, err := pgxpool.ParseConfig(config.Postgres.Url)
 	if err != nil {
 		logger.Error("main: fail parse pgsql url", slogext.Cause(err))
 		return
 	}
 
+	applyPostgresConfig(pgConfig, &config.Postgres)
+
 	pgPool, err := pgxpool.NewWithConfig(context.Background(), pgConfig)
 	if err != nil {
 		logger.Error("main: fail create pgxpool", slogext.Cause(err))
 		return
 	}
 
+	err = waitReachable(config.Postgres.StartupTimeout, "pgsql", pgPool.Ping, logger)
+	if err != nil {
+		logger.Error("main: pgsql is unreachable", slogext.Cause(err))
+		return
+	}
+
+	poolstats.PublishPgxPool("pgsql_pool", pgPool)
+	poolstats.PublishRedisPool("redis_pool", redisClient)
+
+	// MIGRATIONS
+	migrator, err := migrations.NewMigrator(pgPool, logger)
+	if err != nil {
+		logger.Error("main: fail load migrations", slogext.Cause(err))
+		return
+	}
+
+	if config.Postgres.MigrateOnStart {
+		if _, err := migrator.Up(context.Background(), 0); err != nil {
+			logger.Error("main: fail migrate database", slogext.Cause(err))
+			return
+		}
+	} else if err := migrator.CheckCurrent(context.Background()); err != nil {
+		logger.Warn("main: database schema is not current, run migrate up", slogext.Cause(err))
+	}
+
 	// INFRASTRUCTURE
 	uowFactory := postgres.NewUnitOfWorkFactory(pgPool, logger)
 	redisCache := credis.NewRedisCache(redisClient, logger)
 
+	testStorage, err := filesystem.NewTestStorage(config.Judge.TestsDir, logger)
+	if err != nil {
+		logger.Error("main: fail create test storage", slogext.Cause(err))
+		return
+	}
+
+	jobQueue := qredis.NewJobQueue(redisClient, logger)
+
+	languageRegistry, err := jsonfile.LoadRegistry(config.Judge.LanguagesFile)
+	if err != nil {
+		logger.Error("main: fail load languages", slogext.Cause(err))
+		return
+	}
+
+	sessionStore := sredis.NewSessionStore(redisClient, logger)
+	tokenStore := tredis.NewTokenStore(redisClient, logger)
+	mailer := logmail.NewMailer(logger)
+
+	loginPolicy := infrastructure.AttemptPolicy{
+		MaxAttempts: config.Limits.LoginAccountAttempts,
+		Window:      config.Limits.LoginWindow,
+		BaseLockout: config.Limits.LoginLockout,
+		MaxLockout:  config.Limits.LoginMaxLockout,
+	}
+	accountLimiter := lredis.NewAttemptLimiter(redisClient, "login_account", loginPolicy, logger)
+
+	loginPolicy.MaxAttempts = config.Limits.LoginIpAttempts
+	ipLimiter := lredis.NewAttemptLimiter(redisClient, "login_ip", loginPolicy, logger)
+
+	oidcConfigs := make([]oidc.Config, 0, len(config.Auth.OidcProviders))
+	for _, provider := range config.Auth.OidcProviders {
+		oidcConfigs = append(oidcConfigs, oidc.Config{
+			Name:         provider.Name,
+			Issuer:       provider.Issuer,
+			ClientId:     provider.ClientId,
+			ClientSecret: provider.ClientSecret,
+			RedirectUrl:  provider.RedirectUrl,
+			Scopes:       provider.Scopes,
+		})
+	}
+
+	identityProviders, err := oidc.NewRegistry(oidcConfigs, &http.Client{Timeout: 10 * time.Second}, logger)
+	if err != nil {
+		logger.Error("main: fail create identity providers", slogext.Cause(err))
+		return
+	}
+
+	externalLogins := tredis.NewExternalLoginStore(redisClient, logger)
+
 	// SCOPES
-	scopeFactory := scope.NewFactory(uowFactory, redisCache, logger)
+	scopeFactory := scope.NewFactory(uowFactory, redisCache, testStorage, jobQueue, languageRegistry, sessionStore, config.Auth.SessionTtl, tokenStore, mailer, accountLimiter, ipLimiter, identityProviders, externalLogins, logger)
 
 	// API
 	corsMiddleware := cors.New(cors.Options{
@@ -77,18 +195,51 @@ func main() {
 
 	isoLevelMiddleware := middleware.NewIsoLevelMiddleware(logger)
 	scopeMiddleware := middleware.NewScopeMiddleware(logger, scopeFactory)
-	basicAuthMiddleware := basic.NewBasicAuthMiddleware(logger)
-
 	controller := controller_http.NewController(logger)
-	router := controller_http.NewRouter(controller, isoLevelMiddleware, scopeMiddleware, basicAuthMiddleware, logger)
-	p := pipeline.NewPipeline(router, recoverMiddleware, corsMiddleware, logger)
+
+	basicAuthMiddleware := basic.NewBasicAuthMiddleware(controller.WriteServiceError, logger)
+	bearerAuthMiddleware := bearer.NewBearerAuthMiddleware(controller.WriteServiceError, logger)
+	tokenAuthMiddleware := accesstoken.NewAccessTokenAuthMiddleware(controller.WriteServiceError, logger)
+	permissionMiddleware := rbac.NewPermissionMiddleware(controller.WriteServiceError, logger)
+
+	router := controller_http.NewRouter(controller, isoLevelMiddleware, scopeMiddleware, basicAuthMiddleware, bearerAuthMiddleware, tokenAuthMiddleware, permissionMiddleware, logger)
+	probes := pipeline.NewProbes([]pipeline.Check{
+		{Name: "pgsql", Ping: pgPool.Ping},
+		{Name: "redis", Ping: func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		}},
+	}, logger)
+
+	p := pipeline.NewPipeline(router, recoverMiddleware, corsMiddleware, probes, logger)
 
 	// HTTP
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(config.Http.H2c)
+
 	server := http.Server{
-		Addr:         ":80",
-		Handler:      p.CreateHandler(),
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 5 * time.Second,
+		Addr:              config.Http.Addr,
+		Handler:           p.CreateHandler(),
+		ReadHeaderTimeout: config.Http.ReadHeaderTimeout,
+		ReadTimeout:       config.Http.ReadTimeout,
+		WriteTimeout:      config.Http.WriteTimeout,
+		IdleTimeout:       config.Http.IdleTimeout,
+		MaxHeaderBytes:    config.Http.MaxHeaderBytes,
+		Protocols:         protocols,
+	}
+
+	if config.Http.TlsCertFile != "" {
+		certs, err := certreload.New(config.Http.TlsCertFile, config.Http.TlsKeyFile, logger)
+		if err != nil {
+			logger.Error("main: fail load tls certificate", slogext.Cause(err))
+			return
+		}
+
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+		}
 	}
 
 	sigChan := make(chan os.Signal, 1)
@@ -96,16 +247,52 @@ func main() {
 
 	serverChan := make(chan error)
 	go func() {
-		serverChan <- server.ListenAndServe()
+		logger.Info("main: start serving", "addr", server.Addr, "tls", server.TLSConfig != nil, "h2c", config.Http.H2c)
+
+		if server.TLSConfig != nil {
+			// certificate comes from TLSConfig.GetCertificate
+			serverChan <- server.ListenAndServeTLS("", "")
+		} else {
+			serverChan <- server.ListenAndServe()
+		}
 	}()
 
+	// debug server runs on separate address, so pool statistics are not exposed along with api
+	var debugServer *http.Server
+	if config.Http.DebugAddr != "" {
+		debugMux := http.NewServeMux()
+		debugMux.Handle("GET /debug/vars", expvar.Handler())
+
+		debugServer = &http.Server{
+			Addr:              config.Http.DebugAddr,
+			Handler:           debugMux,
+			ReadHeaderTimeout: config.Http.ReadHeaderTimeout,
+		}
+
+		go func() {
+			logger.Info("main: start serving debug", "addr", debugServer.Addr)
+			serverChan <- debugServer.ListenAndServe()
+		}()
+	}
+
 	select {
 	case sig := <-sigChan:
 		logger.Info("main: start shutdown", slogext.Signal(sig))
 
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		probes.SetShuttingDown()
+		if config.Http.ShutdownDelay > 0 {
+			logger.Info("main: wait for traffic to drain", "delay", config.Http.ShutdownDelay.String())
+			time.Sleep(config.Http.ShutdownDelay)
+		}
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), config.Http.ShutdownTimeout)
 		defer shutdownCancel()
 
+		if debugServer != nil {
+			// debug server has no long requests worth waiting for
+			_ = debugServer.Close()
+		}
+
 		err := server.Shutdown(shutdownCtx)
 		if err != nil {
 			logger.Error("main: cannot shutdown server gracefully", slogext.Signal(sig), slogext.Cause(err))
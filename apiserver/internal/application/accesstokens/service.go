@@ -0,0 +1,106 @@
+package accesstokens
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"cplatform/pkg/securetoken"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const tokenBytes = 32
+
+type AccessTokenService struct {
+	uow    infrastructure.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewAccessTokenService(uow infrastructure.UnitOfWork, logger *slog.Logger) *AccessTokenService {
+	return &AccessTokenService{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+func (s *AccessTokenService) CreateToken(ctx context.Context, token *domain.AccessToken) (string, error) {
+	random, err := securetoken.New(tokenBytes)
+	if err != nil {
+		return "", fmt.Errorf("fail generate access token: %w", err)
+	}
+
+	secret := domain.AccessTokenPrefix + random
+
+	err = s.uow.AccessTokenRepository(ctx).AddAccessToken(ctx, token, securetoken.Hash(secret))
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrDuplicateAccessToken) {
+			err = fmt.Errorf("%w: %s", application.ErrDuplicateAccessToken, err.Error())
+		}
+
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return "", fmt.Errorf("fail create access token: %w", err)
+	}
+
+	return secret, nil
+}
+
+func (s *AccessTokenService) ListTokens(ctx context.Context, userId domain.UserId) ([]*domain.AccessToken, error) {
+	tokens, err := s.uow.AccessTokenRepository(ctx).ListAccessTokens(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("fail list access tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (s *AccessTokenService) RevokeToken(ctx context.Context, userId domain.UserId, id domain.AccessTokenId) error {
+	err := s.uow.AccessTokenRepository(ctx).DeleteAccessToken(ctx, userId, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrAccessTokenNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrAccessTokenNotFound, err.Error())
+		}
+
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AccessTokenService) Authenticate(ctx context.Context, secret string) (*domain.User, *domain.AccessToken, error) {
+	if !strings.HasPrefix(secret, domain.AccessTokenPrefix) {
+		return nil, nil, fmt.Errorf("%w: not an access token", application.ErrInvalidAccessToken)
+	}
+
+	token, err := s.uow.AccessTokenRepository(ctx).GetAccessTokenByHash(ctx, securetoken.Hash(secret))
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrAccessTokenNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrInvalidAccessToken, err.Error())
+		}
+
+		return nil, nil, fmt.Errorf("fail get access token: %w", err)
+	}
+
+	if token.IsExpired(time.Now()) {
+		return nil, nil, fmt.Errorf("%w: token %d expired at %s", application.ErrInvalidAccessToken, token.Id, token.ExpiresAt)
+	}
+
+	user, err := s.uow.UserRepository(ctx).GetUserById(ctx, token.UserId)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrUserNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrInvalidAccessToken, err.Error())
+		}
+
+		return nil, nil, fmt.Errorf("fail get access token owner: %w", err)
+	}
+
+	return user, token, nil
+}
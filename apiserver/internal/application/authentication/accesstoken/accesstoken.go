@@ -0,0 +1,92 @@
+package accesstoken
+
+import (
+	"context"
+	"cplatform/internal/application/authentication"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/di/middleware"
+	"cplatform/internal/domain"
+	"cplatform/pkg/slogext"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type contextKey string
+
+const scopeKey contextKey = "access_token_scope"
+
+// AccessTokenAuthMiddleware accepts personal access tokens sent with bearer scheme; route must allow token scope by WithScope
+type AccessTokenAuthMiddleware struct {
+	writeError authentication.ErrorWriter
+	logger     *slog.Logger
+}
+
+func NewAccessTokenAuthMiddleware(writeError authentication.ErrorWriter, logger *slog.Logger) *AccessTokenAuthMiddleware {
+	return &AccessTokenAuthMiddleware{
+		writeError: writeError,
+		logger:     logger,
+	}
+}
+
+func (m *AccessTokenAuthMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret, ok := extractToken(r)
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		scope := middleware.GetScope(r.Context())
+		if scope == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		user, token, err := scope.AccessTokenService(r.Context()).Authenticate(r.Context(), secret)
+		if err != nil {
+			if errors.Is(err, application.ErrInvalidAccessToken) {
+				w.WriteHeader(http.StatusUnauthorized)
+			} else {
+				m.logger.Error("fail to check access token", slogext.Cause(err))
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		// routes without scope are closed to tokens, so leaked token cannot manage account
+		required, ok := r.Context().Value(scopeKey).(domain.TokenScope)
+		if !ok || !token.Allows(required) {
+			m.logger.Info("access token scope denied", "token_id", token.Id, "user_id", user.Id, "path", r.URL.Path)
+			m.writeError(w, fmt.Errorf("%w: token does not allow this action", application.ErrAccessDenied), "fail authorize access token")
+
+			return
+		}
+
+		ctx := authentication.WithUser(r.Context(), user)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithScope marks request as allowed for access tokens holding scope
+func WithScope(reqCtx context.Context, scope domain.TokenScope) context.Context {
+	return context.WithValue(reqCtx, scopeKey, scope)
+}
+
+// HasToken reports whether request carries personal access token rather than session token
+func HasToken(r *http.Request) bool {
+	_, ok := extractToken(r)
+	return ok
+}
+
+func extractToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || !strings.HasPrefix(token, domain.AccessTokenPrefix) {
+		return "", false
+	}
+
+	return token, true
+}
@@ -2,6 +2,7 @@ package basic
 
 import (
 	"context"
+	"cplatform/internal/application/authentication"
 	"cplatform/internal/application/contracts/application"
 	"cplatform/internal/di/middleware"
 	"cplatform/internal/domain"
@@ -13,15 +14,15 @@ import (
 	"strings"
 )
 
-const userKey = "basic_user"
-
 type BasicAuthMiddleware struct {
-	logger *slog.Logger
+	writeError authentication.ErrorWriter
+	logger     *slog.Logger
 }
 
-func NewBasicAuthMiddleware(logger *slog.Logger) *BasicAuthMiddleware {
+func NewBasicAuthMiddleware(writeError authentication.ErrorWriter, logger *slog.Logger) *BasicAuthMiddleware {
 	return &BasicAuthMiddleware{
-		logger: logger,
+		writeError: writeError,
+		logger:     logger,
 	}
 }
 
@@ -62,8 +63,13 @@ func (m *BasicAuthMiddleware) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
-		user, err := scope.UserService(r.Context()).GetUserWithCheckCredentials(r.Context(), email, password)
+		user, err := scope.UserService(r.Context()).GetUserWithCheckCredentials(r.Context(), email, password, authentication.ClientIp(r))
 		if err != nil {
+			if errors.Is(err, application.ErrTooManyAttempts) {
+				m.writeError(w, err, "fail to check user during basic auth")
+				return
+			}
+
 			m.logger.Error("fail to check user during basic auth", slogext.Cause(err))
 
 			if errors.Is(err, application.ErrUserNotFound) ||
@@ -76,20 +82,12 @@ func (m *BasicAuthMiddleware) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
-		ctx := withUser(r.Context(), user)
+		ctx := authentication.WithUser(r.Context(), user)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// GetUser is kept for handlers written before bearer authentication; it sees users authenticated by any scheme
 func GetUser(reqCtx context.Context) *domain.User {
-	user := reqCtx.Value(userKey)
-	if user == nil {
-		return nil
-	}
-
-	return user.(*domain.User)
-}
-
-func withUser(reqCtx context.Context, user *domain.User) context.Context {
-	return context.WithValue(reqCtx, userKey, user)
+	return authentication.GetUser(reqCtx)
 }
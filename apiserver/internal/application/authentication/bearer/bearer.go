@@ -0,0 +1,76 @@
+package bearer
+
+import (
+	"context"
+	"cplatform/internal/application/authentication"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/di/middleware"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type contextKey string
+
+const tokenKey contextKey = "bearer_token"
+
+type BearerAuthMiddleware struct {
+	writeError authentication.ErrorWriter
+	logger     *slog.Logger
+}
+
+func NewBearerAuthMiddleware(writeError authentication.ErrorWriter, logger *slog.Logger) *BearerAuthMiddleware {
+	return &BearerAuthMiddleware{
+		writeError: writeError,
+		logger:     logger,
+	}
+}
+
+func (m *BearerAuthMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := extractToken(r)
+		if !ok {
+			m.writeError(w, fmt.Errorf("%w: missing bearer token", application.ErrInvalidSession), "fail bearer auth")
+			return
+		}
+
+		scope := middleware.GetScope(r.Context())
+		if scope == nil {
+			m.writeError(w, errors.New("request scope is missing"), "fail bearer auth")
+			return
+		}
+
+		user, err := scope.SessionService(r.Context()).Authenticate(r.Context(), token)
+		if err != nil {
+			m.writeError(w, err, "fail to check session during bearer auth")
+			return
+		}
+
+		ctx := authentication.WithUser(r.Context(), user)
+		ctx = context.WithValue(ctx, tokenKey, token)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// HasToken reports whether request uses bearer scheme, letting router fall back to other schemes
+func HasToken(r *http.Request) bool {
+	_, ok := extractToken(r)
+	return ok
+}
+
+// GetToken returns session token of request authenticated by BearerAuthMiddleware
+func GetToken(reqCtx context.Context) string {
+	token, _ := reqCtx.Value(tokenKey).(string)
+	return token
+}
+
+func extractToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", false
+	}
+
+	return token, true
+}
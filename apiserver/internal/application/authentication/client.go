@@ -0,0 +1,16 @@
+package authentication
+
+import (
+	"net"
+	"net/http"
+)
+
+// ClientIp returns address of direct peer; forwarded headers are ignored since they are set by client
+func ClientIp(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
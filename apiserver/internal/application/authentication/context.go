@@ -0,0 +1,24 @@
+package authentication
+
+import (
+	"context"
+	"cplatform/internal/domain"
+)
+
+type contextKey string
+
+const userKey contextKey = "authenticated_user"
+
+// GetUser returns user put into request context by any authentication middleware
+func GetUser(reqCtx context.Context) *domain.User {
+	user := reqCtx.Value(userKey)
+	if user == nil {
+		return nil
+	}
+
+	return user.(*domain.User)
+}
+
+func WithUser(reqCtx context.Context, user *domain.User) context.Context {
+	return context.WithValue(reqCtx, userKey, user)
+}
@@ -0,0 +1,6 @@
+package authentication
+
+import "net/http"
+
+// ErrorWriter reports application error to client; presentation layer provides it, so middlewares stay unaware of api error format
+type ErrorWriter func(w http.ResponseWriter, err error, msg string)
@@ -0,0 +1,131 @@
+package authorization
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+)
+
+// Authorizer resolves roles of user on resources; services use it for per resource checks
+// since route level middleware knows nothing about particular contest or problem
+type Authorizer struct {
+	uow infrastructure.UnitOfWork
+}
+
+func NewAuthorizer(uow infrastructure.UnitOfWork) *Authorizer {
+	return &Authorizer{
+		uow: uow,
+	}
+}
+
+func (a *Authorizer) HasPermission(ctx context.Context, userId domain.UserId, permission domain.Permission) (bool, error) {
+	roles, err := a.uow.RoleRepository(ctx).ListGlobalRoles(ctx, userId)
+	if err != nil {
+		return false, fmt.Errorf("fail list global roles: %w", err)
+	}
+
+	return AnyGrants(roles, permission), nil
+}
+
+// ContestRoles returns contest roles of user; global admin role is included as it applies to every contest
+func (a *Authorizer) ContestRoles(ctx context.Context, contest *domain.Contest, userId domain.UserId) ([]domain.Role, error) {
+	global, err := a.uow.RoleRepository(ctx).ListGlobalRoles(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("fail list global roles: %w", err)
+	}
+
+	roles, err := a.uow.RoleRepository(ctx).ListContestRoles(ctx, contest.Id, userId)
+	if err != nil {
+		return nil, fmt.Errorf("fail list contest roles: %w", err)
+	}
+
+	for _, role := range global {
+		if role == domain.RoleAdmin {
+			roles = append(roles, role)
+		}
+	}
+
+	if contest.OwnerId == userId {
+		roles = append(roles, domain.RoleContestOwner)
+	}
+
+	registered, err := a.uow.ContestRepository(ctx).IsParticipant(ctx, contest.Id, userId)
+	if err != nil {
+		return nil, fmt.Errorf("fail check participant: %w", err)
+	}
+
+	if registered {
+		roles = append(roles, domain.RoleParticipant)
+	}
+
+	return roles, nil
+}
+
+func (a *Authorizer) HasContestPermission(ctx context.Context, contest *domain.Contest, userId domain.UserId, permission domain.Permission) (bool, error) {
+	roles, err := a.ContestRoles(ctx, contest, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return AnyGrants(roles, permission), nil
+}
+
+// RequireContestPermission fails with ErrAccessDenied when user lacks permission in contest
+func (a *Authorizer) RequireContestPermission(ctx context.Context, contest *domain.Contest, userId domain.UserId, permission domain.Permission) error {
+	allowed, err := a.HasContestPermission(ctx, contest, userId, permission)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return fmt.Errorf("%w: user %d lacks %s in contest %d", application.ErrAccessDenied, userId, permission, contest.Id)
+	}
+
+	return nil
+}
+
+// CanEditProblem allows problem author and holders of PermissionEditAnyProblem
+func (a *Authorizer) CanEditProblem(ctx context.Context, problem *domain.Problem, userId domain.UserId) (bool, error) {
+	if problem.AuthorId == userId {
+		return true, nil
+	}
+
+	return a.HasPermission(ctx, userId, domain.PermissionEditAnyProblem)
+}
+
+// RequireVerifiedEmail fails with ErrEmailNotVerified when contest demands verified email and user has none
+func (a *Authorizer) RequireVerifiedEmail(ctx context.Context, contest *domain.Contest, userId domain.UserId) error {
+	if !contest.RequireVerifiedEmail {
+		return nil
+	}
+
+	// cached users lack verification state, so it is always read from database
+	user, err := a.uow.UserRepository(ctx).GetUserById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrUserNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrUserNotFound, err.Error())
+		}
+
+		return fmt.Errorf("fail get user: %w", err)
+	}
+
+	if !user.EmailVerified {
+		return fmt.Errorf("%w: contest %d requires verified email of user %d", application.ErrEmailNotVerified, contest.Id, userId)
+	}
+
+	return nil
+}
+
+// AnyGrants reports whether any of roles grants permission
+func AnyGrants(roles []domain.Role, permission domain.Permission) bool {
+	for _, role := range roles {
+		if role.Grants(permission) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,56 @@
+package rbac
+
+import (
+	"cplatform/internal/application/authentication"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/di/middleware"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// PermissionMiddleware guards routes by global permissions; it must run after authentication middleware.
+// Permissions on particular contests and problems are checked by services
+type PermissionMiddleware struct {
+	writeError authentication.ErrorWriter
+	logger     *slog.Logger
+}
+
+func NewPermissionMiddleware(writeError authentication.ErrorWriter, logger *slog.Logger) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		writeError: writeError,
+		logger:     logger,
+	}
+}
+
+func (m *PermissionMiddleware) Middleware(permission domain.Permission, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := authentication.GetUser(r.Context())
+		if user == nil {
+			m.writeError(w, fmt.Errorf("%w: request is not authenticated", application.ErrInvalidSession), "fail check permission")
+			return
+		}
+
+		scope := middleware.GetScope(r.Context())
+		if scope == nil {
+			m.writeError(w, errors.New("request scope is missing"), "fail check permission")
+			return
+		}
+
+		allowed, err := scope.RoleService(r.Context()).HasPermission(r.Context(), user.Id, permission)
+		if err != nil {
+			m.writeError(w, err, fmt.Sprintf("fail to check permission %s", permission))
+			return
+		}
+
+		if !allowed {
+			m.logger.Info("permission denied", "permission", permission, "user_id", user.Id)
+			m.writeError(w, fmt.Errorf("%w: user lacks %s", application.ErrAccessDenied, permission), "fail check permission")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
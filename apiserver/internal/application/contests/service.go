@@ -0,0 +1,440 @@
+package contests
+
+import (
+	"context"
+	"cplatform/internal/application/authorization"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+type ContestService struct {
+	uow    infrastructure.UnitOfWork
+	access *authorization.Authorizer
+	logger *slog.Logger
+}
+
+func NewContestService(uow infrastructure.UnitOfWork, logger *slog.Logger) *ContestService {
+	return &ContestService{
+		uow:    uow,
+		access: authorization.NewAuthorizer(uow),
+		logger: logger,
+	}
+}
+
+func (s *ContestService) CreateContest(ctx context.Context, contest *domain.Contest) error {
+	err := s.uow.ContestRepository(ctx).AddContest(ctx, contest)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail create contest: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ContestService) GetContest(ctx context.Context, id domain.ContestId, viewerId domain.UserId) (*domain.Contest, error) {
+	contest, err := s.getContest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := s.canView(ctx, contest, viewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !visible {
+		// private contests are indistinguishable from missing ones for outsiders
+		return nil, fmt.Errorf("%w: contest %d is private", application.ErrContestNotFound, id)
+	}
+
+	return contest, nil
+}
+
+func (s *ContestService) UpdateContest(ctx context.Context, contest *domain.Contest, editorId domain.UserId) error {
+	existing, err := s.getOwnedContest(ctx, contest.Id, editorId)
+	if err != nil {
+		return err
+	}
+
+	contest.OwnerId = existing.OwnerId
+
+	err = s.uow.ContestRepository(ctx).UpdateContest(ctx, contest)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail update contest: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ContestService) DeleteContest(ctx context.Context, id domain.ContestId, editorId domain.UserId) error {
+	if _, err := s.getOwnedContest(ctx, id, editorId); err != nil {
+		return err
+	}
+
+	err := s.uow.ContestRepository(ctx).DeleteContest(ctx, id)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail delete contest: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ContestService) ListContests(ctx context.Context, viewerId domain.UserId, offset int, limit int) ([]*domain.Contest, error) {
+	contests, err := s.uow.ContestRepository(ctx).ListContestsVisibleTo(ctx, viewerId, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fail list contests: %w", err)
+	}
+
+	return contests, nil
+}
+
+func (s *ContestService) SetContestProblem(ctx context.Context, problem *domain.ContestProblem, editorId domain.UserId) error {
+	if _, err := s.getOwnedContest(ctx, problem.ContestId, editorId); err != nil {
+		return err
+	}
+
+	revision, err := s.getPinnableRevision(ctx, problem, editorId)
+	if err != nil {
+		return err
+	}
+
+	problem.Title = revision.Title
+
+	err = s.uow.ContestRepository(ctx).SetContestProblem(ctx, problem)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail pin contest problem: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ContestService) RemoveContestProblem(ctx context.Context, contestId domain.ContestId, label string, editorId domain.UserId) error {
+	if _, err := s.getOwnedContest(ctx, contestId, editorId); err != nil {
+		return err
+	}
+
+	err := s.uow.ContestRepository(ctx).RemoveContestProblem(ctx, contestId, label)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrContestProblemNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrContestProblemNotFound, err.Error())
+		}
+
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail unpin contest problem: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ContestService) ListContestProblems(ctx context.Context, contestId domain.ContestId, viewerId domain.UserId) ([]*domain.ContestProblem, error) {
+	contest, err := s.GetContest(ctx, contestId, viewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := s.canSeeProblems(ctx, contest, viewerId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if !visible {
+		return []*domain.ContestProblem{}, nil
+	}
+
+	problems, err := s.uow.ContestRepository(ctx).ListContestProblems(ctx, contestId)
+	if err != nil {
+		return nil, fmt.Errorf("fail list contest problems: %w", err)
+	}
+
+	return problems, nil
+}
+
+func (s *ContestService) GetContestProblemStatement(ctx context.Context, contestId domain.ContestId, label string, viewerId domain.UserId) (*domain.ContestProblem, *domain.ProblemRevision, error) {
+	contest, err := s.GetContest(ctx, contestId, viewerId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	visible, err := s.canSeeProblems(ctx, contest, viewerId, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !visible {
+		return nil, nil, fmt.Errorf("%w: contest %d is not started", application.ErrAccessDenied, contestId)
+	}
+
+	problem, err := s.uow.ContestRepository(ctx).GetContestProblem(ctx, contestId, label)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrContestProblemNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrContestProblemNotFound, err.Error())
+		}
+
+		return nil, nil, fmt.Errorf("fail get contest problem: %w", err)
+	}
+
+	revision, err := s.uow.ProblemRepository(ctx).GetProblemRevision(ctx, problem.ProblemId, problem.Revision)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fail get pinned problem revision: %w", err)
+	}
+
+	return problem, revision, nil
+}
+
+func (s *ContestService) Register(ctx context.Context, contestId domain.ContestId, userId domain.UserId, teamId *domain.TeamId, inviteCode string) (*domain.ContestParticipant, error) {
+	contest, err := s.uow.ContestRepository(ctx).GetContestByIdForUpdate(ctx, contestId)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrContestNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrContestNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get contest: %w", err)
+	}
+
+	codeMatches := contest.InviteCode != "" && subtle.ConstantTimeCompare([]byte(contest.InviteCode), []byte(inviteCode)) == 1
+	if contest.Visibility != domain.ContestVisibilityPublic && !codeMatches {
+		return nil, fmt.Errorf("%w: contest %d is private", application.ErrContestNotFound, contestId)
+	}
+
+	if contest.InviteCode != "" && !codeMatches {
+		return nil, fmt.Errorf("%w: contest %d requires invite code", application.ErrInvalidInviteCode, contestId)
+	}
+
+	roster := []domain.UserId{userId}
+	var team *domain.Team
+	if teamId != nil {
+		team, err = s.uow.TeamRepository(ctx).GetTeamById(ctx, *teamId)
+		if err != nil {
+			if errors.Is(err, infrastructure.ErrTeamNotFound) {
+				err = fmt.Errorf("%w: %s", application.ErrTeamNotFound, err.Error())
+			}
+
+			return nil, fmt.Errorf("fail get team: %w", err)
+		}
+
+		if team.CaptainId != userId {
+			return nil, fmt.Errorf("%w: only captain can register team", application.ErrAccessDenied)
+		}
+
+		roster = roster[:0]
+		for _, member := range team.Members {
+			roster = append(roster, member.UserId)
+		}
+	}
+
+	for _, memberId := range roster {
+		roles, err := s.access.ContestRoles(ctx, contest, memberId)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(roles, domain.RoleContestOwner) || slices.Contains(roles, domain.RoleJury) {
+			return nil, fmt.Errorf("%w: contest staff cannot register for contest", application.ErrAccessDenied)
+		}
+
+		if err := s.access.RequireVerifiedEmail(ctx, contest, memberId); err != nil {
+			return nil, err
+		}
+	}
+
+	if !contest.IsRegistrationOpen(time.Now()) {
+		return nil, fmt.Errorf("%w: contest %d", application.ErrRegistrationClosed, contestId)
+	}
+
+	if contest.Capacity > 0 {
+		// contest row lock taken above keeps count stable until commit
+		count, err := s.uow.ContestRepository(ctx).CountContestants(ctx, contestId)
+		if err != nil {
+			return nil, fmt.Errorf("fail count contestants: %w", err)
+		}
+
+		if count >= contest.Capacity {
+			return nil, fmt.Errorf("%w: contest %d has %d contestants", application.ErrContestFull, contestId, count)
+		}
+	}
+
+	var registered *domain.ContestParticipant
+	for _, memberId := range roster {
+		participant := &domain.ContestParticipant{
+			ContestId: contestId,
+			UserId:    memberId,
+		}
+
+		if team != nil {
+			participant.TeamId = &team.Id
+			participant.TeamName = team.Name
+		}
+
+		err = s.uow.ContestRepository(ctx).AddParticipant(ctx, participant)
+		if err != nil {
+			break
+		}
+
+		if memberId == userId {
+			registered = participant
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrDuplicateParticipant) {
+			err = fmt.Errorf("%w: %s", application.ErrAlreadyRegistered, err.Error())
+		}
+
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return nil, fmt.Errorf("fail register participant: %w", err)
+	}
+
+	return registered, nil
+}
+
+func (s *ContestService) Unregister(ctx context.Context, contestId domain.ContestId, userId domain.UserId) error {
+	contest, err := s.GetContest(ctx, contestId, userId)
+	if err != nil {
+		return err
+	}
+
+	if !time.Now().Before(contest.StartTime) {
+		return fmt.Errorf("%w: contest %d has already started", application.ErrRegistrationClosed, contestId)
+	}
+
+	participant, err := s.uow.ContestRepository(ctx).GetParticipant(ctx, contestId, userId)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrParticipantNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrNotRegistered, err.Error())
+		}
+
+		return fmt.Errorf("fail get participant: %w", err)
+	}
+
+	if participant.TeamId == nil {
+		err = s.uow.ContestRepository(ctx).RemoveParticipant(ctx, contestId, userId)
+	} else {
+		var team *domain.Team
+		team, err = s.uow.TeamRepository(ctx).GetTeamById(ctx, *participant.TeamId)
+		if err != nil {
+			return fmt.Errorf("fail get team: %w", err)
+		}
+
+		if team.CaptainId != userId {
+			return fmt.Errorf("%w: only captain can unregister team", application.ErrAccessDenied)
+		}
+
+		err = s.uow.ContestRepository(ctx).RemoveTeamParticipants(ctx, contestId, team.Id)
+	}
+
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail unregister participant: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ContestService) ListParticipants(ctx context.Context, contestId domain.ContestId, viewerId domain.UserId, offset int, limit int) ([]*domain.ContestParticipant, error) {
+	if _, err := s.GetContest(ctx, contestId, viewerId); err != nil {
+		return nil, err
+	}
+
+	participants, err := s.uow.ContestRepository(ctx).ListParticipants(ctx, contestId, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fail list participants: %w", err)
+	}
+
+	return participants, nil
+}
+
+func (s *ContestService) getOwnedContest(ctx context.Context, id domain.ContestId, editorId domain.UserId) (*domain.Contest, error) {
+	contest, err := s.GetContest(ctx, id, editorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.access.RequireContestPermission(ctx, contest, editorId, domain.PermissionManageContest); err != nil {
+		return nil, err
+	}
+
+	return contest, nil
+}
+
+// getPinnableRevision loads revision of problem which editor is allowed to put into contest
+func (s *ContestService) getPinnableRevision(ctx context.Context, problem *domain.ContestProblem, editorId domain.UserId) (*domain.ProblemRevision, error) {
+	pinned, err := s.uow.ProblemRepository(ctx).GetProblemById(ctx, problem.ProblemId)
+
+	var revision *domain.ProblemRevision
+	if err == nil {
+		revision, err = s.uow.ProblemRepository(ctx).GetProblemRevision(ctx, problem.ProblemId, problem.Revision)
+	}
+
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrProblemNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrProblemNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get pinned problem revision: %w", err)
+	}
+
+	editable, err := s.access.CanEditProblem(ctx, pinned, editorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !editable {
+		return nil, fmt.Errorf("%w: problem %d belongs to another author", application.ErrAccessDenied, problem.ProblemId)
+	}
+
+	return revision, nil
+}
+
+func (s *ContestService) getContest(ctx context.Context, id domain.ContestId) (*domain.Contest, error) {
+	contest, err := s.uow.ContestRepository(ctx).GetContestById(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrContestNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrContestNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get contest: %w", err)
+	}
+
+	return contest, nil
+}
+
+func (s *ContestService) canView(ctx context.Context, contest *domain.Contest, viewerId domain.UserId) (bool, error) {
+	if contest.Visibility == domain.ContestVisibilityPublic {
+		return true, nil
+	}
+
+	return s.access.HasContestPermission(ctx, contest, viewerId, domain.PermissionViewContest)
+}
+
+// canSeeProblems lets contest staff prepare problems before start
+func (s *ContestService) canSeeProblems(ctx context.Context, contest *domain.Contest, viewerId domain.UserId, at time.Time) (bool, error) {
+	if !at.Before(contest.StartTime) {
+		return true, nil
+	}
+
+	return s.access.HasContestPermission(ctx, contest, viewerId, domain.PermissionJudgeContest)
+}
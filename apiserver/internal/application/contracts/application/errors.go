@@ -3,7 +3,37 @@ package application
 import "errors"
 
 var (
-	ErrDuplicateEmail   = errors.New("email already exists")
-	ErrUserNotFound     = errors.New("user not found")
-	ErrWrongCredentials = errors.New("wrong credentials")
+	ErrDuplicateEmail           = errors.New("email already exists")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrWrongCredentials         = errors.New("wrong credentials")
+	ErrInvalidSession           = errors.New("invalid session")
+	ErrInvalidToken             = errors.New("invalid or expired token")
+	ErrEmailNotVerified         = errors.New("email is not verified")
+	ErrTooManyAttempts          = errors.New("too many attempts")
+	ErrIdentityProviderNotFound = errors.New("identity provider not found")
+	ErrExternalLoginFailed      = errors.New("external login failed")
+	ErrInvalidAccessToken       = errors.New("invalid access token")
+	ErrAccessTokenNotFound      = errors.New("access token not found")
+	ErrDuplicateAccessToken     = errors.New("access token name already exists")
+	ErrContestNotFound          = errors.New("contest not found")
+	ErrProblemNotFound          = errors.New("problem not found")
+	ErrInvalidProblem           = errors.New("invalid problem")
+	ErrContestProblemNotFound   = errors.New("contest problem not found")
+	ErrInvalidTests             = errors.New("invalid tests")
+	ErrTestsNotFound            = errors.New("tests not found")
+	ErrContestNotRunning        = errors.New("contest is not running")
+	ErrRegistrationClosed       = errors.New("registration is closed")
+	ErrContestFull              = errors.New("contest is full")
+	ErrInvalidInviteCode        = errors.New("invalid invite code")
+	ErrAlreadyRegistered        = errors.New("already registered")
+	ErrNotRegistered            = errors.New("not registered")
+	ErrTeamNotFound             = errors.New("team not found")
+	ErrDuplicateTeamName        = errors.New("team name already exists")
+	ErrTeamFull                 = errors.New("team is full")
+	ErrAlreadyTeamMember        = errors.New("already a team member")
+	ErrSubmissionNotFound       = errors.New("submission not found")
+	ErrLanguageNotFound         = errors.New("language not found")
+	ErrAccessDenied             = errors.New("access denied")
+	ErrInvalidRole              = errors.New("invalid role")
+	ErrRoleGrantNotFound        = errors.New("role grant not found")
 )
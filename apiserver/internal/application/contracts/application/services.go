@@ -3,10 +3,173 @@ package application
 import (
 	"context"
 	"cplatform/internal/domain"
+	"io"
 )
 
+// TestFile is an uploaded file named like 01.in or 01.ans
+type TestFile struct {
+	Name string
+	Size int64
+	Open func() (io.ReadCloser, error)
+}
+
+// TestManifest describes samples and subtasks; tests are referenced by 1-based ordinals
+type TestManifest struct {
+	Samples  []int                 `json:"samples"`
+	Subtasks []TestManifestSubtask `json:"subtasks"`
+}
+
+type TestManifestSubtask struct {
+	Score int   `json:"score"`
+	Tests []int `json:"tests"`
+}
+
+type TestUpload struct {
+	ProblemId domain.ProblemId
+	EditorId  domain.UserId
+	Files     []TestFile
+	Manifest  *TestManifest
+	// CheckerSource is optional custom checker, required when problem uses custom checker
+	CheckerSource string
+}
+
+// ProfileUpdate carries changed profile fields; nil fields are left as is
+type ProfileUpdate struct {
+	UserId       domain.UserId
+	Name         *string
+	Email        *string
+	Country      *string
+	Organization *string
+	Bio          *string
+}
+
 type UserService interface {
-	RegisterUser(ctx context.Context, name string, email string, password string) error
-	GetUserWithCheckCredentials(ctx context.Context, email, password string) (*domain.User, error)
-	DeleteUser(ctx context.Context, id domain.UserId) error
+	// RegisterUser stores new user; call ResendVerification after changes are saved, so no mail is sent for rolled back user
+	RegisterUser(ctx context.Context, name string, email string, password string) (*domain.User, error)
+	// GetUserWithCheckCredentials fails with ErrTooManyAttempts while account or client address is locked out
+	GetUserWithCheckCredentials(ctx context.Context, email, password string, clientIp string) (*domain.User, error)
+	// DeleteUser anonymizes account after password confirmation, which fails with ErrTooManyAttempts like login;
+	// call RevokeUserAccess after changes are saved
+	DeleteUser(ctx context.Context, id domain.UserId, password string, clientIp string) error
+	// RevokeUserAccess evicts cached credentials and ends every session of user
+	RevokeUserAccess(ctx context.Context, user *domain.User) error
+	GetUser(ctx context.Context, id domain.UserId) (*domain.User, error)
+	// UpdateProfile applies update at once except email, which is only checked to be free;
+	// call RequestEmailChange after changes are saved, so no token is mailed for rolled back update
+	UpdateProfile(ctx context.Context, update *ProfileUpdate) (*domain.User, error)
+	// RequestEmailChange mails token to new email, which is set by ConfirmEmailChange
+	RequestEmailChange(ctx context.Context, id domain.UserId, email string) error
+	// ConfirmEmailChange returns user as it was before change, so caller can evict old email from cache
+	ConfirmEmailChange(ctx context.Context, token string) (*domain.User, error)
+	VerifyEmail(ctx context.Context, token string) (*domain.User, error)
+	// ResendVerification mails new verification token unless email is already verified
+	ResendVerification(ctx context.Context, id domain.UserId) error
+	// InvalidateUserCache drops cached credentials; call it after profile changes are saved
+	InvalidateUserCache(ctx context.Context, email string) error
+	// ChangePassword and ResetPassword return changed user; call RevokeUserAccess after changes are saved.
+	// ChangePassword checks old password under login lockout
+	ChangePassword(ctx context.Context, id domain.UserId, oldPassword string, newPassword string, clientIp string) (*domain.User, error)
+	// RequestPasswordReset mails reset token; unknown email is not reported so accounts cannot be probed
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, newPassword string) (*domain.User, error)
+}
+
+type SessionService interface {
+	// CreateSession returns opaque token, only its hash is stored so token cannot be recovered later
+	CreateSession(ctx context.Context, email string, password string, clientIp string) (string, *domain.Session, error)
+	Authenticate(ctx context.Context, token string) (*domain.User, error)
+	RevokeSession(ctx context.Context, token string) error
+	RevokeUserSessions(ctx context.Context, userId domain.UserId) error
+	// StartSession creates session for user authenticated by other means than password
+	StartSession(ctx context.Context, user *domain.User) (string, *domain.Session, error)
+}
+
+type IdentityService interface {
+	// StartLogin returns provider url to send user to
+	StartLogin(ctx context.Context, provider string) (string, error)
+	// CompleteLogin finds, links or creates user of identity asserted by provider; changes must be saved by caller
+	CompleteLogin(ctx context.Context, provider string, state string, code string) (*domain.User, error)
+}
+
+type AccessTokenService interface {
+	// CreateToken stores token and returns its secret; like session tokens, secret is shown once and cannot be recovered
+	CreateToken(ctx context.Context, token *domain.AccessToken) (string, error)
+	ListTokens(ctx context.Context, userId domain.UserId) ([]*domain.AccessToken, error)
+	RevokeToken(ctx context.Context, userId domain.UserId, id domain.AccessTokenId) error
+	// Authenticate returns owner of unexpired token together with token, so caller can check its scopes
+	Authenticate(ctx context.Context, secret string) (*domain.User, *domain.AccessToken, error)
+}
+
+type ContestService interface {
+	CreateContest(ctx context.Context, contest *domain.Contest) error
+	GetContest(ctx context.Context, id domain.ContestId, viewerId domain.UserId) (*domain.Contest, error)
+	UpdateContest(ctx context.Context, contest *domain.Contest, editorId domain.UserId) error
+	DeleteContest(ctx context.Context, id domain.ContestId, editorId domain.UserId) error
+	ListContests(ctx context.Context, viewerId domain.UserId, offset int, limit int) ([]*domain.Contest, error)
+	SetContestProblem(ctx context.Context, problem *domain.ContestProblem, editorId domain.UserId) error
+	RemoveContestProblem(ctx context.Context, contestId domain.ContestId, label string, editorId domain.UserId) error
+	ListContestProblems(ctx context.Context, contestId domain.ContestId, viewerId domain.UserId) ([]*domain.ContestProblem, error)
+	// GetContestProblemStatement returns pinned revision; participants see it only after contest start
+	GetContestProblemStatement(ctx context.Context, contestId domain.ContestId, label string, viewerId domain.UserId) (*domain.ContestProblem, *domain.ProblemRevision, error)
+	// Register checks invite code even for private contests, which stay hidden from user until registration succeeds;
+	// non nil teamId registers whole team roster and is allowed only to team captain
+	Register(ctx context.Context, contestId domain.ContestId, userId domain.UserId, teamId *domain.TeamId, inviteCode string) (*domain.ContestParticipant, error)
+	// Unregister is allowed only before contest start; team registration can be cancelled only by captain
+	Unregister(ctx context.Context, contestId domain.ContestId, userId domain.UserId) error
+	ListParticipants(ctx context.Context, contestId domain.ContestId, viewerId domain.UserId, offset int, limit int) ([]*domain.ContestParticipant, error)
+}
+
+type ProblemService interface {
+	CreateProblem(ctx context.Context, revision *domain.ProblemRevision) (*domain.Problem, error)
+	GetProblem(ctx context.Context, id domain.ProblemId, viewerId domain.UserId) (*domain.Problem, *domain.ProblemRevision, error)
+	ListProblems(ctx context.Context, authorId domain.UserId, offset int, limit int) ([]*domain.ProblemRevision, error)
+	// UpdateProblem never modifies existing revisions, it appends new one
+	UpdateProblem(ctx context.Context, revision *domain.ProblemRevision) error
+	GetProblemRevision(ctx context.Context, id domain.ProblemId, revision int, viewerId domain.UserId) (*domain.ProblemRevision, error)
+	ListProblemRevisions(ctx context.Context, id domain.ProblemId, viewerId domain.UserId) ([]*domain.ProblemRevision, error)
+	// UploadTests stores new test set and creates revision referencing it
+	UploadTests(ctx context.Context, upload *TestUpload) (*domain.ProblemRevision, *domain.TestSet, error)
+	GetTests(ctx context.Context, id domain.ProblemId, revision int, viewerId domain.UserId) (*domain.TestSet, error)
+}
+
+type SubmissionService interface {
+	// Submit stores submission in queued state; call EnqueueSubmission after changes are saved
+	Submit(ctx context.Context, submission *domain.Submission) error
+	EnqueueSubmission(ctx context.Context, id domain.SubmissionId) error
+	// GetSubmission and ListOwnSubmissions treat submissions of teammates as own
+	GetSubmission(ctx context.Context, id domain.SubmissionId, viewerId domain.UserId) (*domain.Submission, error)
+	ListOwnSubmissions(ctx context.Context, contestId domain.ContestId, userId domain.UserId, offset int, limit int) ([]*domain.Submission, error)
+}
+
+type TeamService interface {
+	// CreateTeam makes team.CaptainId the first member
+	CreateTeam(ctx context.Context, team *domain.Team) error
+	// GetTeam is allowed only to team members
+	GetTeam(ctx context.Context, id domain.TeamId, viewerId domain.UserId) (*domain.Team, error)
+	ListTeams(ctx context.Context, userId domain.UserId) ([]*domain.Team, error)
+	JoinTeam(ctx context.Context, inviteToken string, userId domain.UserId) (*domain.Team, error)
+	// LeaveTeam passes captaincy to the longest standing member; empty teams are kept to preserve contest history
+	LeaveTeam(ctx context.Context, id domain.TeamId, userId domain.UserId) error
+	RotateInviteToken(ctx context.Context, id domain.TeamId, captainId domain.UserId) (*domain.Team, error)
+}
+
+type RoleService interface {
+	// HasPermission checks permission granted by global roles only; contest permissions are checked by services
+	HasPermission(ctx context.Context, userId domain.UserId, permission domain.Permission) (bool, error)
+	// GrantRole is allowed to admins for global roles and to contest owners for contest roles; grant.GrantedBy is the actor
+	GrantRole(ctx context.Context, grant *domain.RoleGrant) error
+	RevokeRole(ctx context.Context, grant *domain.RoleGrant, revokerId domain.UserId) error
+	// ListUserRoles returns global grants; users see own roles, admins see roles of anyone
+	ListUserRoles(ctx context.Context, userId domain.UserId, viewerId domain.UserId) ([]*domain.RoleGrant, error)
+	ListContestRoles(ctx context.Context, contestId domain.ContestId, viewerId domain.UserId) ([]*domain.RoleGrant, error)
+}
+
+type ScoreboardService interface {
+	// GetStandings hides verdicts after freeze time from everyone except contest owner and jury until contest ends
+	GetStandings(ctx context.Context, contestId domain.ContestId, viewerId domain.UserId) (*domain.Standings, error)
+}
+
+type LanguageService interface {
+	ListLanguages(ctx context.Context) ([]*domain.Language, error)
+	GetLanguage(ctx context.Context, id string) (*domain.Language, error)
 }
@@ -8,4 +8,10 @@ import (
 type Cache interface {
 	SaveUserByEmail(ctx context.Context, user *domain.User) error
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
+	DeleteUserByEmail(ctx context.Context, email string) error
+	// SaveStandings keeps frozen and full standings of contest apart
+	SaveStandings(ctx context.Context, standings *domain.Standings) error
+	// GetStandings returns nil without error when standings are not cached
+	GetStandings(ctx context.Context, contestId domain.ContestId, frozen bool) (*domain.Standings, error)
+	InvalidateStandings(ctx context.Context, contestId domain.ContestId) error
 }
@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"context"
+	"cplatform/internal/domain"
+	"errors"
+)
+
+// ErrExternalLoginRejected is returned when provider refuses code or returns identity which fails verification
+var ErrExternalLoginRejected = errors.New("external login rejected")
+
+// IdentityProvider runs authorization code flow with PKCE against external OpenID Connect provider
+type IdentityProvider interface {
+	// AuthorizationUrl returns address user is sent to; codeChallenge is S256 challenge of verifier kept by caller
+	AuthorizationUrl(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	// Exchange redeems code and returns identity taken from verified id token
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*domain.ExternalIdentity, error)
+}
+
+// IdentityProviderRegistry is configured once at startup, so it is shared between scopes
+type IdentityProviderRegistry interface {
+	GetProvider(name string) (IdentityProvider, bool)
+}
+
+// ExternalLoginStore keeps started logins by state hash until provider redirects user back
+type ExternalLoginStore interface {
+	SaveLogin(ctx context.Context, stateHash string, login *domain.ExternalLogin) error
+	// ConsumeLogin atomically fetches and deletes login; returns nil without error when login is missing or expired
+	ConsumeLogin(ctx context.Context, stateHash string) (*domain.ExternalLogin, error)
+}
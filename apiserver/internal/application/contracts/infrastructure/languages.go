@@ -0,0 +1,10 @@
+package infrastructure
+
+import "cplatform/internal/domain"
+
+// LanguageRegistry is loaded once at startup, so it is shared between scopes and needs no context
+type LanguageRegistry interface {
+	GetLanguage(id string) (*domain.Language, bool)
+	// ListLanguages returns languages in configuration order
+	ListLanguages() []*domain.Language
+}
@@ -0,0 +1,24 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+)
+
+// AttemptPolicy locks key out after MaxAttempts failures within Window;
+// every further failure doubles lockout starting from BaseLockout up to MaxLockout
+type AttemptPolicy struct {
+	MaxAttempts int
+	Window      time.Duration
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+}
+
+// AttemptLimiter counts failed attempts per key, e.g. per account or per client address
+type AttemptLimiter interface {
+	// LockedFor returns remaining lockout of key, zero when key is not locked
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// RegisterFailure returns lockout caused by failure, zero while key is under limit
+	RegisterFailure(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
@@ -4,23 +4,151 @@ import (
 	"context"
 	"cplatform/internal/domain"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
 var (
-	ErrDuplicateEmail = errors.New("email already exists")
-	ErrUserNotFound   = errors.New("user not found")
+	ErrDuplicateEmail         = errors.New("email already exists")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrContestNotFound        = errors.New("contest not found")
+	ErrProblemNotFound        = errors.New("problem not found")
+	ErrContestProblemNotFound = errors.New("contest problem not found")
+	ErrDuplicateParticipant   = errors.New("participant already registered")
+	ErrParticipantNotFound    = errors.New("participant not found")
+	ErrTeamNotFound           = errors.New("team not found")
+	ErrDuplicateTeamName      = errors.New("team name already exists")
+	ErrDuplicateTeamMember    = errors.New("user already in team")
+	ErrTeamMemberNotFound     = errors.New("team member not found")
+	ErrTestSetNotFound        = errors.New("test set not found")
+	ErrSubmissionNotFound     = errors.New("submission not found")
+	ErrRoleGrantNotFound      = errors.New("role grant not found")
+	ErrIdentityNotFound       = errors.New("identity not found")
+	ErrDuplicateIdentity      = errors.New("identity already linked")
+	ErrAccessTokenNotFound    = errors.New("access token not found")
+	ErrDuplicateAccessToken   = errors.New("access token name already exists")
 )
 
 type UserRepository interface {
 	AddUser(ctx context.Context, user *domain.User) error
+	// DeleteUser anonymizes user and drops credentials, roles, linked identities and access tokens; row is kept so submissions and contests keep their references
 	DeleteUser(ctx context.Context, id domain.UserId) error
+	// GetUserByEmail and GetUserById do not return deleted users
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetUserById(ctx context.Context, id domain.UserId) (*domain.User, error)
+	// UpdateUser stores profile fields, email and its verification state; credentials are left as is
+	UpdateUser(ctx context.Context, user *domain.User) error
+	UpdatePassword(ctx context.Context, user *domain.User) error
+}
+
+type IdentityRepository interface {
+	GetIdentity(ctx context.Context, provider string, subject string) (*domain.UserIdentity, error)
+	AddIdentity(ctx context.Context, identity *domain.UserIdentity) error
+}
+
+type AccessTokenRepository interface {
+	// AddAccessToken stores token with hash of its secret; secret itself is never stored
+	AddAccessToken(ctx context.Context, token *domain.AccessToken, tokenHash string) error
+	GetAccessTokenByHash(ctx context.Context, tokenHash string) (*domain.AccessToken, error)
+	ListAccessTokens(ctx context.Context, userId domain.UserId) ([]*domain.AccessToken, error)
+	DeleteAccessToken(ctx context.Context, userId domain.UserId, id domain.AccessTokenId) error
+}
+
+type RoleRepository interface {
+	// AddRoleGrant stores global grant when ContestId is nil and contest grant otherwise; existing grant is kept as is
+	AddRoleGrant(ctx context.Context, grant *domain.RoleGrant) error
+	RemoveRoleGrant(ctx context.Context, grant *domain.RoleGrant) error
+	ListGlobalRoles(ctx context.Context, userId domain.UserId) ([]domain.Role, error)
+	ListContestRoles(ctx context.Context, contestId domain.ContestId, userId domain.UserId) ([]domain.Role, error)
+	ListGlobalRoleGrants(ctx context.Context, userId domain.UserId) ([]*domain.RoleGrant, error)
+	ListContestRoleGrants(ctx context.Context, contestId domain.ContestId) ([]*domain.RoleGrant, error)
+}
+
+type ContestRepository interface {
+	AddContest(ctx context.Context, contest *domain.Contest) error
+	GetContestById(ctx context.Context, id domain.ContestId) (*domain.Contest, error)
+	// GetContestByIdForUpdate locks contest row until transaction ends, serializing registrations
+	GetContestByIdForUpdate(ctx context.Context, id domain.ContestId) (*domain.Contest, error)
+	UpdateContest(ctx context.Context, contest *domain.Contest) error
+	DeleteContest(ctx context.Context, id domain.ContestId) error
+	// ListContestsVisibleTo returns public contests and private contests userId owns, judges or joined ordered by start time descending
+	ListContestsVisibleTo(ctx context.Context, userId domain.UserId, offset int, limit int) ([]*domain.Contest, error)
+	// SetContestProblem pins problem revision under label, replacing previous pin with the same label
+	SetContestProblem(ctx context.Context, problem *domain.ContestProblem) error
+	RemoveContestProblem(ctx context.Context, contestId domain.ContestId, label string) error
+	GetContestProblem(ctx context.Context, contestId domain.ContestId, label string) (*domain.ContestProblem, error)
+	ListContestProblems(ctx context.Context, contestId domain.ContestId) ([]*domain.ContestProblem, error)
+	AddParticipant(ctx context.Context, participant *domain.ContestParticipant) error
+	RemoveParticipant(ctx context.Context, contestId domain.ContestId, userId domain.UserId) error
+	// RemoveTeamParticipants unregisters every member of team registered for contest
+	RemoveTeamParticipants(ctx context.Context, contestId domain.ContestId, teamId domain.TeamId) error
+	GetParticipant(ctx context.Context, contestId domain.ContestId, userId domain.UserId) (*domain.ContestParticipant, error)
+	IsParticipant(ctx context.Context, contestId domain.ContestId, userId domain.UserId) (bool, error)
+	// CountContestants counts registered teams and individual participants
+	CountContestants(ctx context.Context, contestId domain.ContestId) (int, error)
+	// ListParticipants returns participants ordered by registration time
+	ListParticipants(ctx context.Context, contestId domain.ContestId, offset int, limit int) ([]*domain.ContestParticipant, error)
+	ListAllParticipants(ctx context.Context, contestId domain.ContestId) ([]*domain.ContestParticipant, error)
+}
+
+type TeamRepository interface {
+	AddTeam(ctx context.Context, team *domain.Team) error
+	// GetTeamById loads team together with members ordered by join time
+	GetTeamById(ctx context.Context, id domain.TeamId) (*domain.Team, error)
+	GetTeamByInviteToken(ctx context.Context, token string) (*domain.Team, error)
+	// GetTeamByIdForUpdate locks team row until transaction ends, serializing roster changes
+	GetTeamByIdForUpdate(ctx context.Context, id domain.TeamId) (*domain.Team, error)
+	UpdateTeam(ctx context.Context, team *domain.Team) error
+	ListTeamsByMember(ctx context.Context, userId domain.UserId) ([]*domain.Team, error)
+	AddTeamMember(ctx context.Context, teamId domain.TeamId, userId domain.UserId) error
+	RemoveTeamMember(ctx context.Context, teamId domain.TeamId, userId domain.UserId) error
+}
+
+type ProblemRepository interface {
+	// AddProblem stores new problem together with its first revision
+	AddProblem(ctx context.Context, problem *domain.Problem, revision *domain.ProblemRevision) error
+	GetProblemById(ctx context.Context, id domain.ProblemId) (*domain.Problem, error)
+	// ListLatestRevisionsByAuthor returns latest revision of every problem created by authorId
+	ListLatestRevisionsByAuthor(ctx context.Context, authorId domain.UserId, offset int, limit int) ([]*domain.ProblemRevision, error)
+	// AddProblemRevision assigns next revision number to revision and makes it latest
+	AddProblemRevision(ctx context.Context, revision *domain.ProblemRevision) error
+	GetProblemRevision(ctx context.Context, id domain.ProblemId, revision int) (*domain.ProblemRevision, error)
+	ListProblemRevisions(ctx context.Context, id domain.ProblemId) ([]*domain.ProblemRevision, error)
+	// AddTestSet stores test set metadata; files themselves go to TestStorage
+	AddTestSet(ctx context.Context, set *domain.TestSet) error
+	GetTestSet(ctx context.Context, id domain.TestSetId) (*domain.TestSet, error)
+}
+
+type SubmissionRepository interface {
+	AddSubmission(ctx context.Context, submission *domain.Submission) error
+	GetSubmissionById(ctx context.Context, id domain.SubmissionId) (*domain.Submission, error)
+	ListSubmissionsByUser(ctx context.Context, contestId domain.ContestId, userId domain.UserId, offset int, limit int) ([]*domain.Submission, error)
+	ListSubmissionsByTeam(ctx context.Context, contestId domain.ContestId, teamId domain.TeamId, offset int, limit int) ([]*domain.Submission, error)
+	// ListContestSubmissions returns submissions made in [from, to) ordered by submission time; Source is left empty
+	ListContestSubmissions(ctx context.Context, contestId domain.ContestId, from time.Time, to time.Time) ([]*domain.Submission, error)
+	UpdateSubmissionState(ctx context.Context, id domain.SubmissionId, state domain.SubmissionState) error
+	// SaveSubmissionResult stores verdict and moves submission to judged state
+	SaveSubmissionResult(ctx context.Context, id domain.SubmissionId, result *domain.SubmissionResult) error
+	// ClaimSubmission moves queued submission, or judging one with claim older than staleBefore, to judging state;
+	// false means submission is judged, failed or claimed by live judge
+	ClaimSubmission(ctx context.Context, id domain.SubmissionId, staleBefore time.Time) (bool, error)
+	// RenewSubmissionClaim keeps claim of judging submission from becoming stale
+	RenewSubmissionClaim(ctx context.Context, id domain.SubmissionId) error
+	// RequeueStaleSubmissions marks up to limit queued submissions not enqueued since before as enqueued now and returns them;
+	// rows locked by concurrent caller are skipped, so every stale submission is taken once
+	RequeueStaleSubmissions(ctx context.Context, before time.Time, limit int) ([]domain.SubmissionId, error)
 }
 
 type UnitOfWork interface {
 	UserRepository(ctx context.Context) UserRepository
+	ContestRepository(ctx context.Context) ContestRepository
+	ProblemRepository(ctx context.Context) ProblemRepository
+	SubmissionRepository(ctx context.Context) SubmissionRepository
+	TeamRepository(ctx context.Context) TeamRepository
+	RoleRepository(ctx context.Context) RoleRepository
+	IdentityRepository(ctx context.Context) IdentityRepository
+	AccessTokenRepository(ctx context.Context) AccessTokenRepository
 	SaveChanges(ctx context.Context) error
 	RollbackChanges(ctx context.Context) error
 	Close(ctx context.Context) error
@@ -31,4 +159,7 @@ type UnitOfWorkFactory interface {
 
 	// CreateWithIsolationLevel has the note: txIsolationLevel is tradeoff not to write huge isolation level detection logic
 	CreateWithIsolationLevel(ctx context.Context, level pgx.TxIsoLevel) UnitOfWork
+
+	// TryCreate is Create for background code without recover, it reports failed connection acquire instead of panicking
+	TryCreate(ctx context.Context) (UnitOfWork, error)
 }
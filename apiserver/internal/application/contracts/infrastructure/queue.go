@@ -0,0 +1,22 @@
+package infrastructure
+
+import (
+	"context"
+	"cplatform/internal/domain"
+)
+
+type JudgeJob struct {
+	// MessageId is assigned by queue on enqueue, set on dequeued job and used for acknowledgement
+	MessageId    string
+	SubmissionId domain.SubmissionId
+}
+
+// JobQueue delivers every job at least once; job is redelivered until acknowledged
+type JobQueue interface {
+	Enqueue(ctx context.Context, job *JudgeJob) error
+	// Dequeue blocks until job is available or ctx is done
+	Dequeue(ctx context.Context) (*JudgeJob, error)
+	Ack(ctx context.Context, job *JudgeJob) error
+	// Extend restarts redelivery timeout of dequeued job; consumer calls it periodically while job takes long
+	Extend(ctx context.Context, job *JudgeJob) error
+}
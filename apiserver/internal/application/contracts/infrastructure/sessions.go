@@ -0,0 +1,16 @@
+package infrastructure
+
+import (
+	"context"
+	"cplatform/internal/domain"
+)
+
+// SessionStore keys sessions by token hash, so reading the store does not reveal usable tokens
+type SessionStore interface {
+	// SaveSession keeps session until its ExpiresAt
+	SaveSession(ctx context.Context, tokenHash string, session *domain.Session) error
+	// GetSession returns nil without error when session is missing or expired
+	GetSession(ctx context.Context, tokenHash string) (*domain.Session, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteUserSessions(ctx context.Context, userId domain.UserId) error
+}
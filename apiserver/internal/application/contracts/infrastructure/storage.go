@@ -0,0 +1,26 @@
+package infrastructure
+
+import (
+	"context"
+	"cplatform/internal/domain"
+	"errors"
+	"io"
+)
+
+var (
+	ErrTestFileNotFound = errors.New("test file not found")
+)
+
+type TestFileKind string
+
+const (
+	TestFileInput  TestFileKind = "in"
+	TestFileAnswer TestFileKind = "ans"
+)
+
+// TestStorage keeps test data outside of database since single test can take megabytes
+type TestStorage interface {
+	SaveTestFile(ctx context.Context, setId domain.TestSetId, ordinal int, kind TestFileKind, content io.Reader) (int64, error)
+	OpenTestFile(ctx context.Context, setId domain.TestSetId, ordinal int, kind TestFileKind) (io.ReadCloser, error)
+	DeleteTestSet(ctx context.Context, setId domain.TestSetId) error
+}
@@ -0,0 +1,13 @@
+package infrastructure
+
+import (
+	"context"
+	"cplatform/internal/domain"
+)
+
+// TokenStore keeps one-time tokens by hash and purpose until they expire or are consumed
+type TokenStore interface {
+	SaveToken(ctx context.Context, tokenHash string, token *domain.OneTimeToken) error
+	// ConsumeToken atomically fetches and deletes token; returns nil without error when token is missing or expired
+	ConsumeToken(ctx context.Context, purpose domain.TokenPurpose, tokenHash string) (*domain.OneTimeToken, error)
+}
@@ -0,0 +1,218 @@
+package identities
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"cplatform/pkg/securetoken"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const loginSecretBytes = 32
+
+// externalLoginTtl is time user has to sign in at provider
+const externalLoginTtl = 10 * time.Minute
+
+// maxUserNameLength follows name rules of registration
+const maxUserNameLength = 85
+
+type IdentityService struct {
+	uow       infrastructure.UnitOfWork
+	providers infrastructure.IdentityProviderRegistry
+	logins    infrastructure.ExternalLoginStore
+	logger    *slog.Logger
+}
+
+func NewIdentityService(uow infrastructure.UnitOfWork, providers infrastructure.IdentityProviderRegistry, logins infrastructure.ExternalLoginStore, logger *slog.Logger) *IdentityService {
+	return &IdentityService{
+		uow:       uow,
+		providers: providers,
+		logins:    logins,
+		logger:    logger,
+	}
+}
+
+func (s *IdentityService) StartLogin(ctx context.Context, providerName string) (string, error) {
+	provider, err := s.getProvider(providerName)
+	if err != nil {
+		return "", err
+	}
+
+	var secrets [3]string
+	for i := range secrets {
+		secrets[i], err = securetoken.New(loginSecretBytes)
+		if err != nil {
+			return "", fmt.Errorf("fail generate login secret: %w", err)
+		}
+	}
+
+	state, nonce, verifier := secrets[0], secrets[1], secrets[2]
+
+	err = s.logins.SaveLogin(ctx, securetoken.Hash(state), &domain.ExternalLogin{
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(externalLoginTtl),
+	})
+	if err != nil {
+		return "", fmt.Errorf("fail save external login: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authorizationUrl, err := provider.AuthorizationUrl(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return "", fmt.Errorf("fail build authorization url: %w", err)
+	}
+
+	return authorizationUrl, nil
+}
+
+func (s *IdentityService) CompleteLogin(ctx context.Context, providerName string, state string, code string) (*domain.User, error) {
+	provider, err := s.getProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	login, err := s.logins.ConsumeLogin(ctx, securetoken.Hash(state))
+	if err != nil {
+		return nil, fmt.Errorf("fail consume external login: %w", err)
+	}
+
+	if login == nil || login.Provider != providerName {
+		return nil, fmt.Errorf("%w: unknown or expired login state", application.ErrInvalidToken)
+	}
+
+	identity, err := provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrExternalLoginRejected) {
+			err = fmt.Errorf("%w: %s", application.ErrExternalLoginFailed, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail exchange code: %w", err)
+	}
+
+	user, err := s.resolveUser(ctx, identity)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return nil, fmt.Errorf("fail complete external login: %w", err)
+	}
+
+	return user, nil
+}
+
+// resolveUser finds user linked to identity; unknown identity is linked to account with same email or gets new account
+func (s *IdentityService) resolveUser(ctx context.Context, identity *domain.ExternalIdentity) (*domain.User, error) {
+	linked, err := s.uow.IdentityRepository(ctx).GetIdentity(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		return s.uow.UserRepository(ctx).GetUserById(ctx, linked.UserId)
+	}
+
+	if !errors.Is(err, infrastructure.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	if identity.Email == "" {
+		return nil, fmt.Errorf("%w: provider did not share email", application.ErrExternalLoginFailed)
+	}
+
+	user, err := s.uow.UserRepository(ctx).GetUserByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		// both sides must have verified address, otherwise whoever registered it first would get the account
+		if !identity.EmailVerified || !user.EmailVerified {
+			return nil, fmt.Errorf("%w: %s belongs to other account; sign in with password and verify email to link it", application.ErrDuplicateEmail, identity.Email)
+		}
+	case errors.Is(err, infrastructure.ErrUserNotFound):
+		user, err = s.createUser(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	err = s.uow.IdentityRepository(ctx).AddIdentity(ctx, &domain.UserIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		UserId:   user.Id,
+		Email:    identity.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fail link identity: %w", err)
+	}
+
+	s.logger.Info("external identity linked", "provider", identity.Provider, "user_id", user.Id)
+
+	return user, nil
+}
+
+// createUser registers user without password; password can be set later through password reset
+func (s *IdentityService) createUser(ctx context.Context, identity *domain.ExternalIdentity) (*domain.User, error) {
+	user := &domain.User{
+		Name:          externalUserName(identity),
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+		PasswordHash:  []byte{},
+		Salt:          []byte{},
+	}
+
+	err := s.uow.UserRepository(ctx).AddUser(ctx, user)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrDuplicateEmail) {
+			err = fmt.Errorf("%w: %s", application.ErrDuplicateEmail, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail create user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *IdentityService) getProvider(name string) (infrastructure.IdentityProvider, error) {
+	provider, ok := s.providers.GetProvider(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not configured", application.ErrIdentityProviderNotFound, name)
+	}
+
+	return provider, nil
+}
+
+// externalUserName fits name given by provider into registration name rules
+func externalUserName(identity *domain.ExternalIdentity) string {
+	name := keepAlphanumeric(identity.Name)
+	if name == "" {
+		local, _, _ := strings.Cut(identity.Email, "@")
+		name = keepAlphanumeric(local)
+	}
+
+	if name == "" {
+		name = "user"
+	}
+
+	if len(name) > maxUserNameLength {
+		name = name[:maxUserNameLength]
+	}
+
+	return name
+}
+
+func keepAlphanumeric(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+
+		return -1
+	}, s)
+}
@@ -0,0 +1,35 @@
+package languages
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"fmt"
+	"log/slog"
+)
+
+type LanguageService struct {
+	registry infrastructure.LanguageRegistry
+	logger   *slog.Logger
+}
+
+func NewLanguageService(registry infrastructure.LanguageRegistry, logger *slog.Logger) *LanguageService {
+	return &LanguageService{
+		registry: registry,
+		logger:   logger,
+	}
+}
+
+func (s *LanguageService) ListLanguages(context.Context) ([]*domain.Language, error) {
+	return s.registry.ListLanguages(), nil
+}
+
+func (s *LanguageService) GetLanguage(_ context.Context, id string) (*domain.Language, error) {
+	language, ok := s.registry.GetLanguage(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", application.ErrLanguageNotFound, id)
+	}
+
+	return language, nil
+}
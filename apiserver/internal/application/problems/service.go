@@ -0,0 +1,172 @@
+package problems
+
+import (
+	"context"
+	"cplatform/internal/application/authorization"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+type ProblemService struct {
+	uow     infrastructure.UnitOfWork
+	storage infrastructure.TestStorage
+	access  *authorization.Authorizer
+	logger  *slog.Logger
+}
+
+func NewProblemService(uow infrastructure.UnitOfWork, storage infrastructure.TestStorage, logger *slog.Logger) *ProblemService {
+	return &ProblemService{
+		uow:     uow,
+		storage: storage,
+		access:  authorization.NewAuthorizer(uow),
+		logger:  logger,
+	}
+}
+
+func (s *ProblemService) CreateProblem(ctx context.Context, revision *domain.ProblemRevision) (*domain.Problem, error) {
+	problem := &domain.Problem{
+		AuthorId: revision.AuthorId,
+	}
+
+	err := s.uow.ProblemRepository(ctx).AddProblem(ctx, problem, revision)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return nil, fmt.Errorf("fail create problem: %w", err)
+	}
+
+	return problem, nil
+}
+
+func (s *ProblemService) GetProblem(ctx context.Context, id domain.ProblemId, viewerId domain.UserId) (*domain.Problem, *domain.ProblemRevision, error) {
+	problem, err := s.getAccessibleProblem(ctx, id, viewerId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revision, err := s.getRevision(ctx, id, problem.LatestRevision)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return problem, revision, nil
+}
+
+func (s *ProblemService) ListProblems(ctx context.Context, authorId domain.UserId, offset int, limit int) ([]*domain.ProblemRevision, error) {
+	revisions, err := s.uow.ProblemRepository(ctx).ListLatestRevisionsByAuthor(ctx, authorId, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fail list problems: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (s *ProblemService) UpdateProblem(ctx context.Context, revision *domain.ProblemRevision) error {
+	problem, err := s.getAccessibleProblem(ctx, revision.ProblemId, revision.AuthorId)
+	if err != nil {
+		return err
+	}
+
+	latest, err := s.getRevision(ctx, problem.Id, problem.LatestRevision)
+	if err != nil {
+		return err
+	}
+
+	revision.TestSetId = latest.TestSetId
+
+	if revision.Checker == domain.CheckerCustom {
+		if err := s.requireCheckerSource(ctx, revision.TestSetId); err != nil {
+			return err
+		}
+	}
+
+	err = s.uow.ProblemRepository(ctx).AddProblemRevision(ctx, revision)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail add problem revision: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProblemService) GetProblemRevision(ctx context.Context, id domain.ProblemId, revision int, viewerId domain.UserId) (*domain.ProblemRevision, error) {
+	if _, err := s.getAccessibleProblem(ctx, id, viewerId); err != nil {
+		return nil, err
+	}
+
+	return s.getRevision(ctx, id, revision)
+}
+
+func (s *ProblemService) ListProblemRevisions(ctx context.Context, id domain.ProblemId, viewerId domain.UserId) ([]*domain.ProblemRevision, error) {
+	if _, err := s.getAccessibleProblem(ctx, id, viewerId); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.uow.ProblemRepository(ctx).ListProblemRevisions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("fail list problem revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (s *ProblemService) getAccessibleProblem(ctx context.Context, id domain.ProblemId, userId domain.UserId) (*domain.Problem, error) {
+	problem, err := s.uow.ProblemRepository(ctx).GetProblemById(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrProblemNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrProblemNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get problem: %w", err)
+	}
+
+	editable, err := s.access.CanEditProblem(ctx, problem, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !editable {
+		return nil, fmt.Errorf("%w: problem %d belongs to another author", application.ErrAccessDenied, id)
+	}
+
+	return problem, nil
+}
+
+func (s *ProblemService) getRevision(ctx context.Context, id domain.ProblemId, revision int) (*domain.ProblemRevision, error) {
+	problemRevision, err := s.uow.ProblemRepository(ctx).GetProblemRevision(ctx, id, revision)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrProblemNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrProblemNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get problem revision: %w", err)
+	}
+
+	return problemRevision, nil
+}
+
+// requireCheckerSource fails when tests are uploaded without custom checker; absent tests are fine
+// since checker comes with them
+func (s *ProblemService) requireCheckerSource(ctx context.Context, setId *domain.TestSetId) error {
+	if setId == nil {
+		return nil
+	}
+
+	set, err := s.uow.ProblemRepository(ctx).GetTestSet(ctx, *setId)
+	if err != nil {
+		return fmt.Errorf("fail get test set: %w", err)
+	}
+
+	if set.CheckerSource == "" {
+		return fmt.Errorf("%w: custom checker requires tests uploaded together with checker source", application.ErrInvalidProblem)
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package problems
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+const maxTestsCount = 500
+
+var testFileNamePattern = regexp.MustCompile(`^(\d{1,4})\.(in|ans|out|a)$`)
+
+var testFileKinds = map[string]infrastructure.TestFileKind{
+	"in":  infrastructure.TestFileInput,
+	"ans": infrastructure.TestFileAnswer,
+	"out": infrastructure.TestFileAnswer,
+	"a":   infrastructure.TestFileAnswer,
+}
+
+type testFilePair struct {
+	input  *application.TestFile
+	answer *application.TestFile
+}
+
+func (s *ProblemService) UploadTests(ctx context.Context, upload *application.TestUpload) (*domain.ProblemRevision, *domain.TestSet, error) {
+	problem, err := s.getAccessibleProblem(ctx, upload.ProblemId, upload.EditorId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	latest, err := s.getRevision(ctx, problem.Id, problem.LatestRevision)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pairs, err := pairTestFiles(upload.Files)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if latest.Checker == domain.CheckerCustom && upload.CheckerSource == "" {
+		return nil, nil, fmt.Errorf("%w: problem uses custom checker, but checker source is not uploaded", application.ErrInvalidTests)
+	}
+
+	set, err := buildTestSet(problem.Id, pairs, upload.Manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set.CheckerSource = upload.CheckerSource
+
+	err = s.uow.ProblemRepository(ctx).AddTestSet(ctx, set)
+	if err != nil {
+		return nil, nil, s.rollback(ctx, fmt.Errorf("fail add test set: %w", err))
+	}
+
+	if err := s.storeTestFiles(ctx, set.Id, pairs); err != nil {
+		if deleteErr := s.storage.DeleteTestSet(context.WithoutCancel(ctx), set.Id); deleteErr != nil {
+			err = errors.Join(err, deleteErr)
+		}
+
+		return nil, nil, s.rollback(ctx, err)
+	}
+
+	revision := *latest
+	revision.TestSetId = &set.Id
+	revision.AuthorId = upload.EditorId
+
+	err = s.uow.ProblemRepository(ctx).AddProblemRevision(ctx, &revision)
+	if err != nil {
+		if deleteErr := s.storage.DeleteTestSet(context.WithoutCancel(ctx), set.Id); deleteErr != nil {
+			err = errors.Join(err, deleteErr)
+		}
+
+		return nil, nil, s.rollback(ctx, fmt.Errorf("fail add problem revision: %w", err))
+	}
+
+	return &revision, set, nil
+}
+
+func (s *ProblemService) GetTests(ctx context.Context, id domain.ProblemId, revision int, viewerId domain.UserId) (*domain.TestSet, error) {
+	problemRevision, err := s.GetProblemRevision(ctx, id, revision, viewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if problemRevision.TestSetId == nil {
+		return nil, fmt.Errorf("%w: revision %d of problem %d has no tests", application.ErrTestsNotFound, revision, id)
+	}
+
+	set, err := s.uow.ProblemRepository(ctx).GetTestSet(ctx, *problemRevision.TestSetId)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrTestSetNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrTestsNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get test set: %w", err)
+	}
+
+	return set, nil
+}
+
+func (s *ProblemService) storeTestFiles(ctx context.Context, setId domain.TestSetId, pairs []testFilePair) error {
+	for i, pair := range pairs {
+		ordinal := i + 1
+
+		if err := s.storeTestFile(ctx, setId, ordinal, infrastructure.TestFileInput, pair.input); err != nil {
+			return err
+		}
+
+		if err := s.storeTestFile(ctx, setId, ordinal, infrastructure.TestFileAnswer, pair.answer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ProblemService) storeTestFile(ctx context.Context, setId domain.TestSetId, ordinal int, kind infrastructure.TestFileKind, file *application.TestFile) error {
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("fail open uploaded file %q: %w", file.Name, err)
+	}
+	defer content.Close()
+
+	written, err := s.storage.SaveTestFile(ctx, setId, ordinal, kind, content)
+	if err != nil {
+		return fmt.Errorf("fail store test file %q: %w", file.Name, err)
+	}
+
+	if written != file.Size {
+		return fmt.Errorf("%w: file %q is truncated", application.ErrInvalidTests, file.Name)
+	}
+
+	return nil
+}
+
+func (s *ProblemService) rollback(ctx context.Context, err error) error {
+	rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+
+	return errors.Join(err, rollbackErr)
+}
+
+// pairTestFiles matches inputs with answers and orders pairs by test number which must go 1..N without gaps
+func pairTestFiles(files []application.TestFile) ([]testFilePair, error) {
+	byNumber := make(map[int]*testFilePair)
+
+	for i := range files {
+		file := &files[i]
+
+		match := testFileNamePattern.FindStringSubmatch(path.Base(file.Name))
+		if match == nil {
+			return nil, fmt.Errorf("%w: unexpected file %q, expected names like 01.in and 01.ans", application.ErrInvalidTests, file.Name)
+		}
+
+		number, _ := strconv.Atoi(match[1])
+		pair, ok := byNumber[number]
+		if !ok {
+			pair = &testFilePair{}
+			byNumber[number] = pair
+		}
+
+		slot := &pair.input
+		if testFileKinds[match[2]] == infrastructure.TestFileAnswer {
+			slot = &pair.answer
+		}
+
+		if *slot != nil {
+			return nil, fmt.Errorf("%w: duplicate file for test %d", application.ErrInvalidTests, number)
+		}
+
+		*slot = file
+	}
+
+	if len(byNumber) == 0 {
+		return nil, fmt.Errorf("%w: no tests uploaded", application.ErrInvalidTests)
+	}
+
+	if len(byNumber) > maxTestsCount {
+		return nil, fmt.Errorf("%w: at most %d tests allowed", application.ErrInvalidTests, maxTestsCount)
+	}
+
+	pairs := make([]testFilePair, 0, len(byNumber))
+	for number := 1; number <= len(byNumber); number++ {
+		pair, ok := byNumber[number]
+		if !ok {
+			return nil, fmt.Errorf("%w: test %d is missing, tests must be numbered from 1 without gaps", application.ErrInvalidTests, number)
+		}
+
+		if pair.input == nil || pair.answer == nil {
+			return nil, fmt.Errorf("%w: test %d must have both input and answer", application.ErrInvalidTests, number)
+		}
+
+		pairs = append(pairs, *pair)
+	}
+
+	return pairs, nil
+}
+
+func buildTestSet(problemId domain.ProblemId, pairs []testFilePair, manifest *application.TestManifest) (*domain.TestSet, error) {
+	set := &domain.TestSet{
+		ProblemId: problemId,
+		Tests:     make([]domain.ProblemTest, 0, len(pairs)),
+		Subtasks:  make([]domain.ProblemSubtask, 0),
+	}
+
+	for i, pair := range pairs {
+		set.Tests = append(set.Tests, domain.ProblemTest{
+			Ordinal:    i + 1,
+			InputSize:  pair.input.Size,
+			AnswerSize: pair.answer.Size,
+		})
+	}
+
+	if manifest == nil {
+		return set, nil
+	}
+
+	var errs []error
+
+	for _, ordinal := range manifest.Samples {
+		if ordinal < 1 || ordinal > len(set.Tests) {
+			errs = append(errs, fmt.Errorf("%w: sample references unknown test %d", application.ErrInvalidTests, ordinal))
+			continue
+		}
+
+		set.Tests[ordinal-1].IsSample = true
+	}
+
+	if len(manifest.Subtasks) > 0 {
+		totalScore := 0
+
+		for i, subtask := range manifest.Subtasks {
+			index := i + 1
+
+			if subtask.Score < 0 {
+				errs = append(errs, fmt.Errorf("%w: subtask %d has negative score", application.ErrInvalidTests, index))
+			}
+
+			totalScore += subtask.Score
+			set.Subtasks = append(set.Subtasks, domain.ProblemSubtask{Index: index, Score: subtask.Score})
+
+			for _, ordinal := range subtask.Tests {
+				if ordinal < 1 || ordinal > len(set.Tests) {
+					errs = append(errs, fmt.Errorf("%w: subtask %d references unknown test %d", application.ErrInvalidTests, index, ordinal))
+					continue
+				}
+
+				if set.Tests[ordinal-1].Subtask != 0 {
+					errs = append(errs, fmt.Errorf("%w: test %d belongs to several subtasks", application.ErrInvalidTests, ordinal))
+					continue
+				}
+
+				set.Tests[ordinal-1].Subtask = index
+			}
+		}
+
+		if totalScore != 100 {
+			errs = append(errs, fmt.Errorf("%w: subtask scores sum to %d instead of 100", application.ErrInvalidTests, totalScore))
+		}
+
+		unassigned := slices.IndexFunc(set.Tests, func(test domain.ProblemTest) bool { return test.Subtask == 0 })
+		if unassigned >= 0 {
+			errs = append(errs, fmt.Errorf("%w: test %d belongs to no subtask", application.ErrInvalidTests, unassigned+1))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return set, nil
+}
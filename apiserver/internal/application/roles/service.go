@@ -0,0 +1,178 @@
+package roles
+
+import (
+	"context"
+	"cplatform/internal/application/authorization"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+type RoleService struct {
+	uow    infrastructure.UnitOfWork
+	access *authorization.Authorizer
+	logger *slog.Logger
+}
+
+func NewRoleService(uow infrastructure.UnitOfWork, logger *slog.Logger) *RoleService {
+	return &RoleService{
+		uow:    uow,
+		access: authorization.NewAuthorizer(uow),
+		logger: logger,
+	}
+}
+
+func (s *RoleService) HasPermission(ctx context.Context, userId domain.UserId, permission domain.Permission) (bool, error) {
+	return s.access.HasPermission(ctx, userId, permission)
+}
+
+func (s *RoleService) GrantRole(ctx context.Context, grant *domain.RoleGrant) error {
+	if err := s.authorizeGrant(ctx, grant, grant.GrantedBy); err != nil {
+		return err
+	}
+
+	err := s.uow.RoleRepository(ctx).AddRoleGrant(ctx, grant)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrUserNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrUserNotFound, err.Error())
+		}
+
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail grant role: %w", err)
+	}
+
+	return nil
+}
+
+func (s *RoleService) RevokeRole(ctx context.Context, grant *domain.RoleGrant, revokerId domain.UserId) error {
+	if err := s.authorizeGrant(ctx, grant, revokerId); err != nil {
+		return err
+	}
+
+	// the last admin revoking own role would leave nobody able to manage roles
+	if grant.Role == domain.RoleAdmin && grant.UserId == revokerId {
+		return fmt.Errorf("%w: admin cannot revoke own admin role", application.ErrAccessDenied)
+	}
+
+	err := s.uow.RoleRepository(ctx).RemoveRoleGrant(ctx, grant)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrRoleGrantNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrRoleGrantNotFound, err.Error())
+		}
+
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail revoke role: %w", err)
+	}
+
+	return nil
+}
+
+func (s *RoleService) ListUserRoles(ctx context.Context, userId domain.UserId, viewerId domain.UserId) ([]*domain.RoleGrant, error) {
+	if userId != viewerId {
+		allowed, err := s.access.HasPermission(ctx, viewerId, domain.PermissionManageRoles)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			return nil, fmt.Errorf("%w: only admins can see roles of other users", application.ErrAccessDenied)
+		}
+	}
+
+	grants, err := s.uow.RoleRepository(ctx).ListGlobalRoleGrants(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("fail list user roles: %w", err)
+	}
+
+	return grants, nil
+}
+
+func (s *RoleService) ListContestRoles(ctx context.Context, contestId domain.ContestId, viewerId domain.UserId) ([]*domain.RoleGrant, error) {
+	contest, err := s.getContest(ctx, contestId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.requireVisible(ctx, contest, viewerId); err != nil {
+		return nil, err
+	}
+
+	grants, err := s.uow.RoleRepository(ctx).ListContestRoleGrants(ctx, contestId)
+	if err != nil {
+		return nil, fmt.Errorf("fail list contest roles: %w", err)
+	}
+
+	return grants, nil
+}
+
+// authorizeGrant checks that role fits grant scope and actor may manage it
+func (s *RoleService) authorizeGrant(ctx context.Context, grant *domain.RoleGrant, actorId domain.UserId) error {
+	if !grant.Role.IsGrantable() {
+		return fmt.Errorf("%w: %q cannot be granted", application.ErrInvalidRole, grant.Role)
+	}
+
+	if grant.Role.IsGlobal() != (grant.ContestId == nil) {
+		return fmt.Errorf("%w: %q does not apply to this scope", application.ErrInvalidRole, grant.Role)
+	}
+
+	if grant.ContestId == nil {
+		allowed, err := s.access.HasPermission(ctx, actorId, domain.PermissionManageRoles)
+		if err != nil {
+			return err
+		}
+
+		if !allowed {
+			return fmt.Errorf("%w: only admins can manage global roles", application.ErrAccessDenied)
+		}
+
+		return nil
+	}
+
+	contest, err := s.getContest(ctx, *grant.ContestId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.requireVisible(ctx, contest, actorId); err != nil {
+		return err
+	}
+
+	return s.access.RequireContestPermission(ctx, contest, actorId, domain.PermissionManageContest)
+}
+
+func (s *RoleService) getContest(ctx context.Context, id domain.ContestId) (*domain.Contest, error) {
+	contest, err := s.uow.ContestRepository(ctx).GetContestById(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrContestNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrContestNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get contest: %w", err)
+	}
+
+	return contest, nil
+}
+
+func (s *RoleService) requireVisible(ctx context.Context, contest *domain.Contest, viewerId domain.UserId) error {
+	if contest.Visibility == domain.ContestVisibilityPublic {
+		return nil
+	}
+
+	visible, err := s.access.HasContestPermission(ctx, contest, viewerId, domain.PermissionViewContest)
+	if err != nil {
+		return err
+	}
+
+	if !visible {
+		return fmt.Errorf("%w: contest %d is private", application.ErrContestNotFound, contest.Id)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package scoreboard
+
+import (
+	"context"
+	"cplatform/internal/application/authorization"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"cplatform/pkg/slogext"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type ScoreboardService struct {
+	uow    infrastructure.UnitOfWork
+	cache  infrastructure.Cache
+	access *authorization.Authorizer
+	logger *slog.Logger
+}
+
+func NewScoreboardService(uow infrastructure.UnitOfWork, cache infrastructure.Cache, logger *slog.Logger) *ScoreboardService {
+	return &ScoreboardService{
+		uow:    uow,
+		cache:  cache,
+		access: authorization.NewAuthorizer(uow),
+		logger: logger,
+	}
+}
+
+func (s *ScoreboardService) GetStandings(ctx context.Context, contestId domain.ContestId, viewerId domain.UserId) (*domain.Standings, error) {
+	contest, err := s.uow.ContestRepository(ctx).GetContestById(ctx, contestId)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrContestNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrContestNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get contest: %w", err)
+	}
+
+	roles, err := s.access.ContestRoles(ctx, contest, viewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if contest.Visibility != domain.ContestVisibilityPublic && !authorization.AnyGrants(roles, domain.PermissionViewContest) {
+		return nil, fmt.Errorf("%w: contest %d is private", application.ErrContestNotFound, contestId)
+	}
+
+	// owner and jury always see live standings, everyone else sees frozen ones until contest ends
+	isJury := authorization.AnyGrants(roles, domain.PermissionJudgeContest)
+	now := time.Now()
+	var hideAfter *time.Time
+	if !isJury && contest.FreezeTime != nil && !now.Before(*contest.FreezeTime) && now.Before(contest.EndTime()) {
+		hideAfter = contest.FreezeTime
+	}
+
+	standings, err := s.cache.GetStandings(ctx, contestId, hideAfter != nil)
+	if err != nil {
+		s.logger.Warn("fail fetch standings from cache", slogext.Cause(err))
+	}
+
+	if standings != nil {
+		return standings, nil
+	}
+
+	standings, err = s.computeStandings(ctx, contest, hideAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheErr := s.cache.SaveStandings(ctx, standings); cacheErr != nil {
+		s.logger.Warn("fail save standings to cache", slogext.Cause(cacheErr))
+	}
+
+	return standings, nil
+}
+
+func (s *ScoreboardService) computeStandings(ctx context.Context, contest *domain.Contest, hideAfter *time.Time) (*domain.Standings, error) {
+	problems, err := s.uow.ContestRepository(ctx).ListContestProblems(ctx, contest.Id)
+	if err != nil {
+		return nil, fmt.Errorf("fail list contest problems: %w", err)
+	}
+
+	labels := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		labels = append(labels, problem.Label)
+	}
+
+	submissions, err := s.uow.SubmissionRepository(ctx).ListContestSubmissions(ctx, contest.Id, contest.StartTime, contest.EndTime())
+	if err != nil {
+		return nil, fmt.Errorf("fail list contest submissions: %w", err)
+	}
+
+	participants, err := s.uow.ContestRepository(ctx).ListAllParticipants(ctx, contest.Id)
+	if err != nil {
+		return nil, fmt.Errorf("fail list participants: %w", err)
+	}
+
+	standings := buildStandings(contest, labels, participants, submissions, hideAfter)
+	standings.GeneratedAt = time.Now()
+
+	return standings, nil
+}
@@ -0,0 +1,211 @@
+package scoreboard
+
+import (
+	"cmp"
+	"cplatform/internal/domain"
+	"slices"
+	"time"
+)
+
+// icpcPenaltyMinutes is added to penalty for every rejected attempt on eventually solved problem
+const icpcPenaltyMinutes = 20
+
+// contestantKey identifies standings row; team members share key with zero userId
+type contestantKey struct {
+	userId domain.UserId
+	teamId domain.TeamId
+}
+
+type contestant struct {
+	userId domain.UserId
+	teamId *domain.TeamId
+	name   string
+	cells  map[string]*cellState
+}
+
+type cellState struct {
+	domain.StandingsCell
+	// bestSubtasks holds per subtask maximum across submissions in IOI mode
+	bestSubtasks []int
+}
+
+// buildStandings folds submissions ordered by time into standings with row for every individual participant
+// and registered team; submissions made at or after hideAfter count as pending, nil hideAfter shows every verdict
+func buildStandings(contest *domain.Contest, labels []string, participants []*domain.ContestParticipant, submissions []*domain.Submission, hideAfter *time.Time) *domain.Standings {
+	known := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		known[label] = true
+	}
+
+	contestants := make(map[contestantKey]*contestant)
+	byUser := make(map[domain.UserId]*contestant, len(participants))
+	for _, participant := range participants {
+		key := contestantKey{userId: participant.UserId}
+		if participant.TeamId != nil {
+			key = contestantKey{teamId: *participant.TeamId}
+		}
+
+		c, ok := contestants[key]
+		if !ok {
+			c = &contestant{cells: make(map[string]*cellState)}
+			if participant.TeamId != nil {
+				c.teamId = participant.TeamId
+				c.name = participant.TeamName
+			} else {
+				c.userId = participant.UserId
+				c.name = participant.UserName
+			}
+
+			contestants[key] = c
+		}
+
+		byUser[participant.UserId] = c
+	}
+
+	for _, submission := range submissions {
+		// owner test runs and submissions of unregistered users are not ranked
+		c, ok := byUser[submission.UserId]
+		if !known[submission.ProblemLabel] || !ok {
+			continue
+		}
+
+		// system failures and compilation errors do not affect standings
+		if submission.State == domain.SubmissionStateFailed {
+			continue
+		}
+
+		if submission.Result != nil && submission.Result.Verdict == domain.VerdictCompilationError {
+			continue
+		}
+
+		cell, ok := c.cells[submission.ProblemLabel]
+		if !ok {
+			cell = &cellState{StandingsCell: domain.StandingsCell{Label: submission.ProblemLabel}}
+			c.cells[submission.ProblemLabel] = cell
+		}
+
+		hidden := hideAfter != nil && !submission.SubmittedAt.Before(*hideAfter)
+		if hidden || submission.Result == nil {
+			cell.Pending++
+			continue
+		}
+
+		switch contest.ScoringMode {
+		case domain.ScoringModeIoi:
+			applyIoi(cell, submission.Result)
+		default:
+			applyIcpc(cell, submission, contest.StartTime)
+		}
+	}
+
+	standings := &domain.Standings{
+		ContestId: contest.Id,
+		Mode:      contest.ScoringMode,
+		Problems:  labels,
+		Frozen:    hideAfter != nil,
+		Rows:      make([]domain.StandingsRow, 0, len(contestants)),
+	}
+
+	for _, c := range contestants {
+		row := domain.StandingsRow{
+			UserId: c.userId,
+			TeamId: c.teamId,
+			Name:   c.name,
+			Cells:  make([]domain.StandingsCell, 0, len(labels)),
+		}
+
+		for _, label := range labels {
+			cell, ok := c.cells[label]
+			if !ok {
+				row.Cells = append(row.Cells, domain.StandingsCell{Label: label})
+				continue
+			}
+
+			if cell.Solved {
+				row.Solved++
+			}
+
+			if cell.Solved && contest.ScoringMode != domain.ScoringModeIoi {
+				row.PenaltyMinutes += int(cell.SolvedAt/time.Minute) + icpcPenaltyMinutes*cell.Attempts
+			}
+
+			row.Score += cell.Score
+			row.Cells = append(row.Cells, cell.StandingsCell)
+		}
+
+		standings.Rows = append(standings.Rows, row)
+	}
+
+	rank(standings)
+
+	return standings
+}
+
+func applyIcpc(cell *cellState, submission *domain.Submission, start time.Time) {
+	if cell.Solved {
+		return
+	}
+
+	if submission.Result.Verdict == domain.VerdictAccepted {
+		cell.Solved = true
+		cell.SolvedAt = submission.SubmittedAt.Sub(start)
+		return
+	}
+
+	cell.Attempts++
+}
+
+func applyIoi(cell *cellState, result *domain.SubmissionResult) {
+	if result.Verdict != domain.VerdictAccepted {
+		cell.Attempts++
+	}
+
+	if len(result.SubtaskScores) == 0 {
+		cell.Score = max(cell.Score, result.Score)
+	} else {
+		if len(cell.bestSubtasks) < len(result.SubtaskScores) {
+			cell.bestSubtasks = append(cell.bestSubtasks, make([]int, len(result.SubtaskScores)-len(cell.bestSubtasks))...)
+		}
+
+		total := 0
+		for i, score := range result.SubtaskScores {
+			cell.bestSubtasks[i] = max(cell.bestSubtasks[i], score)
+			total += cell.bestSubtasks[i]
+		}
+
+		cell.Score = max(cell.Score, total)
+	}
+
+	cell.Solved = cell.Solved || result.Verdict == domain.VerdictAccepted
+}
+
+func rank(standings *domain.Standings) {
+	compareResults := func(a, b *domain.StandingsRow) int {
+		if standings.Mode == domain.ScoringModeIoi {
+			return cmp.Compare(b.Score, a.Score)
+		}
+
+		return cmp.Or(cmp.Compare(b.Solved, a.Solved), cmp.Compare(a.PenaltyMinutes, b.PenaltyMinutes))
+	}
+
+	slices.SortFunc(standings.Rows, func(a, b domain.StandingsRow) int {
+		return cmp.Or(compareResults(&a, &b), cmp.Compare(a.Name, b.Name), cmp.Compare(a.UserId, b.UserId), cmp.Compare(valueOrZero(a.TeamId), valueOrZero(b.TeamId)))
+	})
+
+	for i := range standings.Rows {
+		if i > 0 && compareResults(&standings.Rows[i-1], &standings.Rows[i]) == 0 {
+			standings.Rows[i].Rank = standings.Rows[i-1].Rank
+		} else {
+			standings.Rows[i].Rank = i + 1
+		}
+	}
+}
+
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+
+	return *value
+}
@@ -0,0 +1,177 @@
+package scoreboard
+
+import (
+	"cplatform/internal/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var contestStart = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func participant(userId domain.UserId, name string) *domain.ContestParticipant {
+	return &domain.ContestParticipant{ContestId: 1, UserId: userId, UserName: name}
+}
+
+func teamMember(userId domain.UserId, teamId domain.TeamId, teamName string) *domain.ContestParticipant {
+	return &domain.ContestParticipant{ContestId: 1, UserId: userId, TeamId: &teamId, TeamName: teamName}
+}
+
+func judged(userId domain.UserId, label string, minute int, verdict domain.Verdict) *domain.Submission {
+	return &domain.Submission{
+		ContestId:    1,
+		ProblemLabel: label,
+		UserId:       userId,
+		State:        domain.SubmissionStateJudged,
+		SubmittedAt:  contestStart.Add(time.Duration(minute) * time.Minute),
+		Result:       &domain.SubmissionResult{Verdict: verdict},
+	}
+}
+
+func scored(userId domain.UserId, label string, minute int, verdict domain.Verdict, score int, subtasks ...int) *domain.Submission {
+	submission := judged(userId, label, minute, verdict)
+	submission.Result.Score = score
+	submission.Result.SubtaskScores = subtasks
+
+	return submission
+}
+
+func withState(submission *domain.Submission, state domain.SubmissionState) *domain.Submission {
+	submission.State = state
+	if state == domain.SubmissionStateQueued {
+		submission.Result = nil
+	}
+
+	return submission
+}
+
+func TestBuildStandings(t *testing.T) {
+	teamId := domain.TeamId(7)
+	freeze := contestStart.Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		mode         domain.ScoringMode
+		labels       []string
+		participants []*domain.ContestParticipant
+		submissions  []*domain.Submission
+		hideAfter    *time.Time
+		want         []domain.StandingsRow
+	}{
+		{
+			name:         "icpc penalty",
+			mode:         domain.ScoringModeIcpc,
+			labels:       []string{"A", "B"},
+			participants: []*domain.ContestParticipant{participant(1, "alice"), participant(2, "bob"), participant(3, "carol")},
+			submissions: []*domain.Submission{
+				judged(1, "B", 5, domain.VerdictCompilationError),
+				judged(3, "A", 5, domain.VerdictWrongAnswer),
+				withState(judged(3, "A", 6, domain.VerdictRuntimeError), domain.SubmissionStateFailed),
+				judged(1, "A", 10, domain.VerdictWrongAnswer),
+				judged(2, "A", 15, domain.VerdictAccepted),
+				judged(2, "B", 20, domain.VerdictWrongAnswer),
+				judged(4, "A", 25, domain.VerdictAccepted),
+				judged(1, "A", 30, domain.VerdictAccepted),
+				judged(1, "C", 35, domain.VerdictAccepted),
+				judged(1, "B", 40, domain.VerdictAccepted),
+				judged(1, "A", 50, domain.VerdictWrongAnswer),
+				judged(2, "B", 50, domain.VerdictAccepted),
+			},
+			want: []domain.StandingsRow{
+				{Rank: 1, UserId: 2, Name: "bob", Solved: 2, PenaltyMinutes: 15 + 50 + 20, Cells: []domain.StandingsCell{
+					{Label: "A", Solved: true, SolvedAt: 15 * time.Minute},
+					{Label: "B", Solved: true, Attempts: 1, SolvedAt: 50 * time.Minute},
+				}},
+				{Rank: 2, UserId: 1, Name: "alice", Solved: 2, PenaltyMinutes: 30 + 20 + 40, Cells: []domain.StandingsCell{
+					{Label: "A", Solved: true, Attempts: 1, SolvedAt: 30 * time.Minute},
+					{Label: "B", Solved: true, SolvedAt: 40 * time.Minute},
+				}},
+				{Rank: 3, UserId: 3, Name: "carol", Cells: []domain.StandingsCell{
+					{Label: "A", Attempts: 1},
+					{Label: "B"},
+				}},
+			},
+		},
+		{
+			name:         "team members share row and equal results share rank",
+			mode:         domain.ScoringModeIcpc,
+			labels:       []string{"A"},
+			participants: []*domain.ContestParticipant{teamMember(1, teamId, "Team Seven"), teamMember(2, teamId, "Team Seven"), participant(3, "carol")},
+			submissions: []*domain.Submission{
+				judged(1, "A", 10, domain.VerdictWrongAnswer),
+				judged(2, "A", 20, domain.VerdictAccepted),
+				judged(3, "A", 40, domain.VerdictAccepted),
+			},
+			want: []domain.StandingsRow{
+				{Rank: 1, TeamId: &teamId, Name: "Team Seven", Solved: 1, PenaltyMinutes: 40, Cells: []domain.StandingsCell{
+					{Label: "A", Solved: true, Attempts: 1, SolvedAt: 20 * time.Minute},
+				}},
+				{Rank: 1, UserId: 3, Name: "carol", Solved: 1, PenaltyMinutes: 40, Cells: []domain.StandingsCell{
+					{Label: "A", Solved: true, SolvedAt: 40 * time.Minute},
+				}},
+			},
+		},
+		{
+			name:         "ioi subtask maxima",
+			mode:         domain.ScoringModeIoi,
+			labels:       []string{"A"},
+			participants: []*domain.ContestParticipant{participant(1, "alice"), participant(2, "bob"), participant(3, "carol")},
+			submissions: []*domain.Submission{
+				scored(1, "A", 10, domain.VerdictWrongAnswer, 30, 30, 0, 10),
+				scored(3, "A", 15, domain.VerdictWrongAnswer, 60),
+				scored(1, "A", 20, domain.VerdictWrongAnswer, 20, 0, 20, 10),
+				scored(3, "A", 25, domain.VerdictWrongAnswer, 40),
+				scored(2, "A", 30, domain.VerdictAccepted, 100, 30, 20, 50),
+			},
+			want: []domain.StandingsRow{
+				{Rank: 1, UserId: 2, Name: "bob", Solved: 1, Score: 100, Cells: []domain.StandingsCell{
+					{Label: "A", Solved: true, Score: 100},
+				}},
+				{Rank: 2, UserId: 1, Name: "alice", Score: 60, Cells: []domain.StandingsCell{
+					{Label: "A", Attempts: 2, Score: 60},
+				}},
+				{Rank: 2, UserId: 3, Name: "carol", Score: 60, Cells: []domain.StandingsCell{
+					{Label: "A", Attempts: 2, Score: 60},
+				}},
+			},
+		},
+		{
+			name:         "freeze hides verdicts",
+			mode:         domain.ScoringModeIcpc,
+			labels:       []string{"A"},
+			participants: []*domain.ContestParticipant{participant(1, "alice"), participant(2, "bob")},
+			submissions: []*domain.Submission{
+				withState(judged(2, "A", 20, domain.VerdictAccepted), domain.SubmissionStateQueued),
+				judged(1, "A", 30, domain.VerdictWrongAnswer),
+				judged(2, "A", 59, domain.VerdictAccepted),
+				judged(1, "A", 60, domain.VerdictAccepted),
+				judged(1, "A", 70, domain.VerdictAccepted),
+			},
+			hideAfter: &freeze,
+			want: []domain.StandingsRow{
+				{Rank: 1, UserId: 2, Name: "bob", Solved: 1, PenaltyMinutes: 59, Cells: []domain.StandingsCell{
+					{Label: "A", Solved: true, SolvedAt: 59 * time.Minute, Pending: 1},
+				}},
+				{Rank: 2, UserId: 1, Name: "alice", Cells: []domain.StandingsCell{
+					{Label: "A", Attempts: 1, Pending: 2},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contest := &domain.Contest{Id: 1, StartTime: contestStart, Duration: 2 * time.Hour, ScoringMode: tt.mode}
+
+			standings := buildStandings(contest, tt.labels, tt.participants, tt.submissions, tt.hideAfter)
+
+			if standings.Frozen != (tt.hideAfter != nil) {
+				t.Errorf("frozen = %v, want %v", standings.Frozen, tt.hideAfter != nil)
+			}
+
+			if !reflect.DeepEqual(standings.Rows, tt.want) {
+				t.Errorf("rows =\n%+v\nwant\n%+v", standings.Rows, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package sessions
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"cplatform/pkg/securetoken"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const tokenBytes = 32
+
+type SessionService struct {
+	uow      infrastructure.UnitOfWork
+	users    application.UserService
+	sessions infrastructure.SessionStore
+	ttl      time.Duration
+	logger   *slog.Logger
+}
+
+func NewSessionService(uow infrastructure.UnitOfWork, users application.UserService, sessions infrastructure.SessionStore, ttl time.Duration, logger *slog.Logger) *SessionService {
+	return &SessionService{
+		uow:      uow,
+		users:    users,
+		sessions: sessions,
+		ttl:      ttl,
+		logger:   logger,
+	}
+}
+
+func (s *SessionService) CreateSession(ctx context.Context, email string, password string, clientIp string) (string, *domain.Session, error) {
+	user, err := s.users.GetUserWithCheckCredentials(ctx, email, password, clientIp)
+	if err != nil {
+		// unknown email is reported as wrong credentials to not reveal registered emails
+		if errors.Is(err, application.ErrUserNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrWrongCredentials, err.Error())
+		}
+
+		return "", nil, fmt.Errorf("fail check credentials: %w", err)
+	}
+
+	return s.StartSession(ctx, user)
+}
+
+func (s *SessionService) StartSession(ctx context.Context, user *domain.User) (string, *domain.Session, error) {
+	token, err := securetoken.New(tokenBytes)
+	if err != nil {
+		return "", nil, fmt.Errorf("fail generate session token: %w", err)
+	}
+
+	now := time.Now()
+	session := &domain.Session{
+		UserId:    user.Id,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.ttl),
+	}
+
+	err = s.sessions.SaveSession(ctx, securetoken.Hash(token), session)
+	if err != nil {
+		return "", nil, fmt.Errorf("fail save session: %w", err)
+	}
+
+	return token, session, nil
+}
+
+func (s *SessionService) Authenticate(ctx context.Context, token string) (*domain.User, error) {
+	session, err := s.sessions.GetSession(ctx, securetoken.Hash(token))
+	if err != nil {
+		return nil, fmt.Errorf("fail get session: %w", err)
+	}
+
+	if session == nil {
+		return nil, fmt.Errorf("%w: session is expired or revoked", application.ErrInvalidSession)
+	}
+
+	user, err := s.uow.UserRepository(ctx).GetUserById(ctx, session.UserId)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrUserNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrInvalidSession, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get session user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *SessionService) RevokeSession(ctx context.Context, token string) error {
+	err := s.sessions.DeleteSession(ctx, securetoken.Hash(token))
+	if err != nil {
+		return fmt.Errorf("fail revoke session: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) RevokeUserSessions(ctx context.Context, userId domain.UserId) error {
+	err := s.sessions.DeleteUserSessions(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("fail revoke user sessions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package submissions
+
+import (
+	"context"
+	"cplatform/internal/application/authorization"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type SubmissionService struct {
+	uow       infrastructure.UnitOfWork
+	queue     infrastructure.JobQueue
+	languages infrastructure.LanguageRegistry
+	access    *authorization.Authorizer
+	logger    *slog.Logger
+}
+
+func NewSubmissionService(uow infrastructure.UnitOfWork, queue infrastructure.JobQueue, languages infrastructure.LanguageRegistry, logger *slog.Logger) *SubmissionService {
+	return &SubmissionService{
+		uow:       uow,
+		queue:     queue,
+		languages: languages,
+		access:    authorization.NewAuthorizer(uow),
+		logger:    logger,
+	}
+}
+
+func (s *SubmissionService) Submit(ctx context.Context, submission *domain.Submission) error {
+	if _, ok := s.languages.GetLanguage(submission.LanguageId); !ok {
+		return fmt.Errorf("%w: %q is not supported", application.ErrLanguageNotFound, submission.LanguageId)
+	}
+
+	contest, err := s.uow.ContestRepository(ctx).GetContestById(ctx, submission.ContestId)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrContestNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrContestNotFound, err.Error())
+		}
+
+		return fmt.Errorf("fail get contest: %w", err)
+	}
+
+	isStaff, err := s.access.HasContestPermission(ctx, contest, submission.UserId, domain.PermissionJudgeContest)
+	if err != nil {
+		return err
+	}
+
+	if !isStaff {
+		participant, err := s.uow.ContestRepository(ctx).GetParticipant(ctx, contest.Id, submission.UserId)
+		if errors.Is(err, infrastructure.ErrParticipantNotFound) && contest.Visibility != domain.ContestVisibilityPublic {
+			return fmt.Errorf("%w: contest %d is private", application.ErrContestNotFound, contest.Id)
+		}
+
+		if err != nil {
+			if errors.Is(err, infrastructure.ErrParticipantNotFound) {
+				err = fmt.Errorf("%w: %s", application.ErrNotRegistered, err.Error())
+			}
+
+			return fmt.Errorf("fail get participant: %w", err)
+		}
+
+		submission.TeamId = participant.TeamId
+
+		if err := s.access.RequireVerifiedEmail(ctx, contest, submission.UserId); err != nil {
+			return err
+		}
+	}
+
+	// owner and jury may test problems outside of contest window
+	now := time.Now()
+	if !isStaff && !contest.IsRunning(now) {
+		return fmt.Errorf("%w: contest %d accepts submissions from %s to %s", application.ErrContestNotRunning, contest.Id, contest.StartTime, contest.EndTime())
+	}
+
+	problem, err := s.uow.ContestRepository(ctx).GetContestProblem(ctx, submission.ContestId, submission.ProblemLabel)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrContestProblemNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrContestProblemNotFound, err.Error())
+		}
+
+		return fmt.Errorf("fail get contest problem: %w", err)
+	}
+
+	submission.ProblemId = problem.ProblemId
+	submission.ProblemRevision = problem.Revision
+	submission.State = domain.SubmissionStateQueued
+
+	err = s.uow.SubmissionRepository(ctx).AddSubmission(ctx, submission)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail add submission: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SubmissionService) EnqueueSubmission(ctx context.Context, id domain.SubmissionId) error {
+	err := s.queue.Enqueue(ctx, &infrastructure.JudgeJob{SubmissionId: id})
+	if err != nil {
+		return fmt.Errorf("fail enqueue submission %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *SubmissionService) GetSubmission(ctx context.Context, id domain.SubmissionId, viewerId domain.UserId) (*domain.Submission, error) {
+	submission, err := s.uow.SubmissionRepository(ctx).GetSubmissionById(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrSubmissionNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrSubmissionNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get submission: %w", err)
+	}
+
+	if submission.UserId == viewerId {
+		return submission, nil
+	}
+
+	// teammates share submissions
+	if submission.TeamId != nil {
+		participant, err := s.uow.ContestRepository(ctx).GetParticipant(ctx, submission.ContestId, viewerId)
+		if err != nil && !errors.Is(err, infrastructure.ErrParticipantNotFound) {
+			return nil, fmt.Errorf("fail get participant: %w", err)
+		}
+
+		if err == nil && participant.TeamId != nil && *participant.TeamId == *submission.TeamId {
+			return submission, nil
+		}
+	}
+
+	contest, err := s.uow.ContestRepository(ctx).GetContestById(ctx, submission.ContestId)
+	if err != nil {
+		return nil, fmt.Errorf("fail get contest: %w", err)
+	}
+
+	isJury, err := s.access.HasContestPermission(ctx, contest, viewerId, domain.PermissionJudgeContest)
+	if err != nil {
+		return nil, err
+	}
+
+	if isJury {
+		return submission, nil
+	}
+
+	return nil, fmt.Errorf("%w: submission %d belongs to another contestant", application.ErrSubmissionNotFound, id)
+}
+
+func (s *SubmissionService) ListOwnSubmissions(ctx context.Context, contestId domain.ContestId, userId domain.UserId, offset int, limit int) ([]*domain.Submission, error) {
+	participant, err := s.uow.ContestRepository(ctx).GetParticipant(ctx, contestId, userId)
+	if err != nil && !errors.Is(err, infrastructure.ErrParticipantNotFound) {
+		return nil, fmt.Errorf("fail get participant: %w", err)
+	}
+
+	var list []*domain.Submission
+	if err == nil && participant.TeamId != nil {
+		list, err = s.uow.SubmissionRepository(ctx).ListSubmissionsByTeam(ctx, contestId, *participant.TeamId, offset, limit)
+	} else {
+		list, err = s.uow.SubmissionRepository(ctx).ListSubmissionsByUser(ctx, contestId, userId, offset, limit)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail list submissions: %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,212 @@
+package teams
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"cplatform/pkg/securetoken"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// maxTeamMembers follows ICPC rules
+const maxTeamMembers = 3
+
+const inviteTokenBytes = 18
+
+type TeamService struct {
+	uow    infrastructure.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewTeamService(uow infrastructure.UnitOfWork, logger *slog.Logger) *TeamService {
+	return &TeamService{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) error {
+	token, err := newInviteToken()
+	if err != nil {
+		return err
+	}
+
+	team.InviteToken = token
+
+	err = s.uow.TeamRepository(ctx).AddTeam(ctx, team)
+	if err == nil {
+		err = s.uow.TeamRepository(ctx).AddTeamMember(ctx, team.Id, team.CaptainId)
+	}
+
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrDuplicateTeamName) {
+			err = fmt.Errorf("%w: %s", application.ErrDuplicateTeamName, err.Error())
+		}
+
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail create team: %w", err)
+	}
+
+	created, err := s.uow.TeamRepository(ctx).GetTeamById(ctx, team.Id)
+	if err != nil {
+		return fmt.Errorf("fail get created team: %w", err)
+	}
+
+	*team = *created
+
+	return nil
+}
+
+func (s *TeamService) GetTeam(ctx context.Context, id domain.TeamId, viewerId domain.UserId) (*domain.Team, error) {
+	team, err := s.uow.TeamRepository(ctx).GetTeamById(ctx, id)
+	if err != nil {
+		return nil, wrapTeamError(err)
+	}
+
+	if !isMember(team, viewerId) {
+		// teams are private to their members
+		return nil, fmt.Errorf("%w: user %d is not member of team %d", application.ErrTeamNotFound, viewerId, id)
+	}
+
+	return team, nil
+}
+
+func (s *TeamService) ListTeams(ctx context.Context, userId domain.UserId) ([]*domain.Team, error) {
+	teams, err := s.uow.TeamRepository(ctx).ListTeamsByMember(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("fail list teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (s *TeamService) JoinTeam(ctx context.Context, inviteToken string, userId domain.UserId) (*domain.Team, error) {
+	team, err := s.uow.TeamRepository(ctx).GetTeamByInviteToken(ctx, inviteToken)
+	if err != nil {
+		return nil, wrapTeamError(err)
+	}
+
+	// re-read under lock so concurrent joins cannot exceed team size
+	team, err = s.uow.TeamRepository(ctx).GetTeamByIdForUpdate(ctx, team.Id)
+	if err != nil {
+		return nil, wrapTeamError(err)
+	}
+
+	if isMember(team, userId) {
+		return nil, fmt.Errorf("%w: user %d in team %d", application.ErrAlreadyTeamMember, userId, team.Id)
+	}
+
+	if len(team.Members) >= maxTeamMembers {
+		return nil, fmt.Errorf("%w: team %d has %d members", application.ErrTeamFull, team.Id, len(team.Members))
+	}
+
+	err = s.uow.TeamRepository(ctx).AddTeamMember(ctx, team.Id, userId)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrDuplicateTeamMember) {
+			err = fmt.Errorf("%w: %s", application.ErrAlreadyTeamMember, err.Error())
+		}
+
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return nil, fmt.Errorf("fail join team: %w", err)
+	}
+
+	joined, err := s.uow.TeamRepository(ctx).GetTeamById(ctx, team.Id)
+	if err != nil {
+		return nil, fmt.Errorf("fail get joined team: %w", err)
+	}
+
+	return joined, nil
+}
+
+func (s *TeamService) LeaveTeam(ctx context.Context, id domain.TeamId, userId domain.UserId) error {
+	team, err := s.uow.TeamRepository(ctx).GetTeamByIdForUpdate(ctx, id)
+	if err != nil {
+		return wrapTeamError(err)
+	}
+
+	if !isMember(team, userId) {
+		return fmt.Errorf("%w: user %d is not member of team %d", application.ErrTeamNotFound, userId, id)
+	}
+
+	err = s.uow.TeamRepository(ctx).RemoveTeamMember(ctx, id, userId)
+	if err == nil && team.CaptainId == userId && len(team.Members) > 1 {
+		// members are ordered by join time
+		for _, member := range team.Members {
+			if member.UserId != userId {
+				team.CaptainId = member.UserId
+				break
+			}
+		}
+
+		err = s.uow.TeamRepository(ctx).UpdateTeam(ctx, team)
+	}
+
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail leave team: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TeamService) RotateInviteToken(ctx context.Context, id domain.TeamId, captainId domain.UserId) (*domain.Team, error) {
+	team, err := s.GetTeam(ctx, id, captainId)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.CaptainId != captainId {
+		return nil, fmt.Errorf("%w: only captain can manage team invites", application.ErrAccessDenied)
+	}
+
+	team.InviteToken, err = newInviteToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.TeamRepository(ctx).UpdateTeam(ctx, team)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return nil, fmt.Errorf("fail rotate invite token: %w", err)
+	}
+
+	return team, nil
+}
+
+func wrapTeamError(err error) error {
+	if errors.Is(err, infrastructure.ErrTeamNotFound) {
+		err = fmt.Errorf("%w: %s", application.ErrTeamNotFound, err.Error())
+	}
+
+	return fmt.Errorf("fail get team: %w", err)
+}
+
+func isMember(team *domain.Team, userId domain.UserId) bool {
+	for _, member := range team.Members {
+		if member.UserId == userId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newInviteToken() (string, error) {
+	token, err := securetoken.New(inviteTokenBytes)
+	if err != nil {
+		return "", fmt.Errorf("fail generate invite token: %w", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,135 @@
+package users
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// hashParams are stored with every hash, so they can be raised without invalidating existing passwords
+type hashParams struct {
+	// Memory is in KiB
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+	KeyLen  uint32
+	SaltLen int
+}
+
+// currentHashParams are used for new hashes; hashes made with other params are replaced on next login
+var currentHashParams = hashParams{
+	Memory:  64 * 1024,
+	Time:    1,
+	Threads: 4,
+	KeyLen:  32,
+	SaltLen: 16,
+}
+
+// legacyHashParams describe raw hashes made before params were stored, their salt is kept in separate column
+var legacyHashParams = hashParams{
+	Memory:  64 * 1024,
+	Time:    1,
+	Threads: 4,
+	KeyLen:  32,
+	SaltLen: 10,
+}
+
+var errMalformedHash = errors.New("malformed password hash")
+
+// newPasswordHash returns hash in PHC string format together with its salt
+func newPasswordHash(password string, params hashParams) ([]byte, []byte, error) {
+	salt := make([]byte, params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, fmt.Errorf("fail generate salt: %w", err)
+	}
+
+	key := hashFunc([]byte(password), salt, params)
+
+	return encodeHash(params, salt, key), salt, nil
+}
+
+// verifyPassword reports whether password matches and whether stored hash should be replaced with one made by current params
+func verifyPassword(storedHash []byte, legacySalt []byte, password string) (match bool, outdated bool, err error) {
+	params := legacyHashParams
+	salt := legacySalt
+	key := storedHash
+
+	if bytes.HasPrefix(storedHash, []byte(argon2idPrefix)) {
+		params, salt, key, err = decodeHash(storedHash)
+		if err != nil {
+			return false, false, err
+		}
+	}
+
+	computed := hashFunc([]byte(password), salt, params)
+	match = subtle.ConstantTimeCompare(key, computed) == 1
+	outdated = params != currentHashParams || !bytes.HasPrefix(storedHash, []byte(argon2idPrefix))
+
+	return match, outdated, nil
+}
+
+func hashFunc(password []byte, salt []byte, params hashParams) []byte {
+	return argon2.IDKey(
+		password,
+		salt,
+		params.Time,
+		params.Memory,
+		params.Threads,
+		params.KeyLen,
+	)
+}
+
+// encodeHash formats hash like $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>
+func encodeHash(params hashParams, salt []byte, key []byte) []byte {
+	return fmt.Appendf(nil, "%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		params.Memory,
+		params.Time,
+		params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func decodeHash(encoded []byte) (hashParams, []byte, []byte, error) {
+	var params hashParams
+
+	parts := strings.Split(string(encoded), "$")
+	// leading $ gives empty first part
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, fmt.Errorf("%w: unexpected format", errMalformedHash)
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("%w: unsupported version %q", errMalformedHash, parts[2])
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: bad params %q", errMalformedHash, parts[3])
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: bad salt: %s", errMalformedHash, err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: bad key: %s", errMalformedHash, err)
+	}
+
+	params.SaltLen = len(salt)
+	params.KeyLen = uint32(len(key))
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,111 @@
+package users
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// cheapHashParams keep tests fast; hashes made with them are outdated by definition
+var cheapHashParams = hashParams{
+	Memory:  64,
+	Time:    1,
+	Threads: 1,
+	KeyLen:  16,
+	SaltLen: 8,
+}
+
+func TestEncodeDecodeHash(t *testing.T) {
+	params := hashParams{Memory: 65536, Time: 3, Threads: 2, KeyLen: 4, SaltLen: 4}
+	salt := []byte("salt")
+	key := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	encoded := encodeHash(params, salt, key)
+	if want := "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$3q2+7w"; string(encoded) != want {
+		t.Fatalf("encodeHash = %q, want %q", encoded, want)
+	}
+
+	decodedParams, decodedSalt, decodedKey, err := decodeHash(encoded)
+	if err != nil {
+		t.Fatalf("decodeHash: %v", err)
+	}
+
+	if decodedParams != params || string(decodedSalt) != string(salt) || string(decodedKey) != string(key) {
+		t.Errorf("decodeHash = %+v, %q, %x", decodedParams, decodedSalt, decodedKey)
+	}
+}
+
+func TestDecodeHashMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "other algorithm", encoded: "$argon2i$v=19$m=65536,t=1,p=4$c2FsdA$3q2+7w"},
+		{name: "other version", encoded: "$argon2id$v=16$m=65536,t=1,p=4$c2FsdA$3q2+7w"},
+		{name: "missing part", encoded: "$argon2id$v=19$m=65536,t=1,p=4$c2FsdA"},
+		{name: "bad params", encoded: "$argon2id$v=19$m=lots,t=1,p=4$c2FsdA$3q2+7w"},
+		{name: "bad salt", encoded: "$argon2id$v=19$m=65536,t=1,p=4$c2F*dA$3q2+7w"},
+		{name: "bad key", encoded: "$argon2id$v=19$m=65536,t=1,p=4$c2FsdA$3q2+7w=="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, err := decodeHash([]byte(tt.encoded)); !errors.Is(err, errMalformedHash) {
+				t.Errorf("decodeHash error = %v, want errMalformedHash", err)
+			}
+		})
+	}
+}
+
+func TestVerifyPassword(t *testing.T) {
+	const password = "correct horse battery staple"
+
+	currentHash, currentSalt, err := newPasswordHash(password, currentHashParams)
+	if err != nil {
+		t.Fatalf("newPasswordHash: %v", err)
+	}
+
+	if !strings.HasPrefix(string(currentHash), "$argon2id$v=19$m=65536,t=1,p=4$") {
+		t.Errorf("current hash = %q", currentHash)
+	}
+
+	cheapHash, _, err := newPasswordHash(password, cheapHashParams)
+	if err != nil {
+		t.Fatalf("newPasswordHash: %v", err)
+	}
+
+	legacySalt := []byte("legacysalt")
+	legacyHash := hashFunc([]byte(password), legacySalt, legacyHashParams)
+
+	tests := []struct {
+		name         string
+		hash         []byte
+		salt         []byte
+		password     string
+		wantMatch    bool
+		wantOutdated bool
+		wantErr      error
+	}{
+		{name: "current", hash: currentHash, salt: currentSalt, password: password, wantMatch: true},
+		{name: "current wrong password", hash: currentHash, salt: currentSalt, password: "wrong"},
+		{name: "current ignores salt column", hash: currentHash, salt: []byte("other"), password: password, wantMatch: true},
+		{name: "other params", hash: cheapHash, password: password, wantMatch: true, wantOutdated: true},
+		{name: "other params wrong password", hash: cheapHash, password: "wrong", wantOutdated: true},
+		{name: "legacy", hash: legacyHash, salt: legacySalt, password: password, wantMatch: true, wantOutdated: true},
+		{name: "legacy wrong password", hash: legacyHash, salt: legacySalt, password: "wrong", wantOutdated: true},
+		{name: "malformed", hash: []byte("$argon2id$v=19$broken"), password: password, wantErr: errMalformedHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, outdated, err := verifyPassword(tt.hash, tt.salt, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("verifyPassword error = %v, want %v", err, tt.wantErr)
+			}
+
+			if match != tt.wantMatch || outdated != tt.wantOutdated {
+				t.Errorf("verifyPassword = match %v, outdated %v; want match %v, outdated %v", match, outdated, tt.wantMatch, tt.wantOutdated)
+			}
+		})
+	}
+}
@@ -1,53 +1,74 @@
 package users
 
 import (
-	"bytes"
 	"context"
 	"cplatform/internal/application/contracts/application"
 	"cplatform/internal/application/contracts/infrastructure"
 	"cplatform/internal/domain"
+	"cplatform/pkg/securetoken"
 	"cplatform/pkg/slogext"
 	"errors"
 	"fmt"
 	"log/slog"
-	"math/rand/v2"
-
-	"golang.org/x/crypto/argon2"
+	"strings"
+	"time"
 )
 
+const emailTokenBytes = 32
+const emailChangeTokenTtl = 24 * time.Hour
+const passwordResetTokenTtl = time.Hour
+const emailVerificationTokenTtl = 24 * time.Hour
+
 type UserService struct {
-	uow        infrastructure.UnitOfWork
-	cache      infrastructure.Cache
-	logger     *slog.Logger
-	saltLength int
+	uow      infrastructure.UnitOfWork
+	cache    infrastructure.Cache
+	sessions infrastructure.SessionStore
+	tokens   infrastructure.TokenStore
+	mailer   infrastructure.Mailer
+	teams    application.TeamService
+	// accountLimiter and ipLimiter throttle credential checks, each costing argon2 memory
+	accountLimiter infrastructure.AttemptLimiter
+	ipLimiter      infrastructure.AttemptLimiter
+	logger         *slog.Logger
 }
 
-func NewUserService(uow infrastructure.UnitOfWork, cache infrastructure.Cache, logger *slog.Logger) *UserService {
+func NewUserService(
+	uow infrastructure.UnitOfWork,
+	cache infrastructure.Cache,
+	sessions infrastructure.SessionStore,
+	tokens infrastructure.TokenStore,
+	mailer infrastructure.Mailer,
+	teams application.TeamService,
+	accountLimiter infrastructure.AttemptLimiter,
+	ipLimiter infrastructure.AttemptLimiter,
+	logger *slog.Logger,
+) *UserService {
 	return &UserService{
-		uow:        uow,
-		cache:      cache,
-		logger:     logger,
-		saltLength: 10,
-	}
-}
+		uow:      uow,
+		cache:    cache,
+		sessions: sessions,
+		tokens:   tokens,
+		mailer:   mailer,
+		teams:    teams,
 
-func (s *UserService) RegisterUser(ctx context.Context, name string, email string, password string) error {
-	salt := make([]byte, s.saltLength)
-
-	for i := range len(salt) {
-		salt[i] = byte(rand.IntN(256))
+		accountLimiter: accountLimiter,
+		ipLimiter:      ipLimiter,
+		logger:         logger,
 	}
+}
 
-	hash := hashFunc([]byte(password), salt)
+func (s *UserService) RegisterUser(ctx context.Context, name string, email string, password string) (*domain.User, error) {
 	user := &domain.User{
-		Name:         name,
-		Email:        email,
-		Salt:         salt,
-		PasswordHash: hash,
+		Name:  name,
+		Email: email,
 	}
 
-	err := s.uow.UserRepository(ctx).AddUser(ctx, user)
+	err := setPassword(user, password)
+	if err != nil {
+		return nil, err
+	}
 
+	err = s.uow.UserRepository(ctx).AddUser(ctx, user)
 	if err != nil {
 		if errors.Is(err, infrastructure.ErrDuplicateEmail) {
 			err = fmt.Errorf("%w: %s", application.ErrDuplicateEmail, err.Error())
@@ -56,13 +77,86 @@ func (s *UserService) RegisterUser(ctx context.Context, name string, email strin
 		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
 		err = errors.Join(err, rollbackErr)
 
-		return fmt.Errorf("fail user registration: %w", err)
+		return nil, fmt.Errorf("fail user registration: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *UserService) GetUserWithCheckCredentials(ctx context.Context, email, password string, clientIp string) (*domain.User, error) {
+	accountKey := strings.ToLower(email)
+
+	if err := s.checkLockout(ctx, accountKey, clientIp); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUserWithCheckCredentials(ctx, email, password)
+	if errors.Is(err, application.ErrUserNotFound) || errors.Is(err, application.ErrWrongCredentials) {
+		// unknown emails are counted too, otherwise lockout would reveal registered ones
+		s.registerLoginFailure(ctx, accountKey, clientIp)
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// address counter is kept, so own account cannot be used to reset it while guessing others
+	if err := s.accountLimiter.Reset(ctx, accountKey); err != nil {
+		s.logger.Warn("fail reset login failures", "user_id", user.Id, slogext.Cause(err))
+	}
+
+	return user, nil
+}
+
+// checkLockout lets requests through when limiter is unavailable, since locking everybody out is worse
+func (s *UserService) checkLockout(ctx context.Context, accountKey string, clientIp string) error {
+	lockedFor, err := s.accountLimiter.LockedFor(ctx, accountKey)
+	if err != nil {
+		s.logger.Warn("fail check account lockout", slogext.Cause(err))
+	}
+
+	if lockedFor > 0 {
+		return fmt.Errorf("%w: account is locked, retry in %s", application.ErrTooManyAttempts, lockedFor.Round(time.Second))
+	}
+
+	if clientIp == "" {
+		return nil
+	}
+
+	lockedFor, err = s.ipLimiter.LockedFor(ctx, clientIp)
+	if err != nil {
+		s.logger.Warn("fail check address lockout", slogext.Cause(err))
+	}
+
+	if lockedFor > 0 {
+		return fmt.Errorf("%w: address is locked, retry in %s", application.ErrTooManyAttempts, lockedFor.Round(time.Second))
 	}
 
 	return nil
 }
 
-func (s *UserService) GetUserWithCheckCredentials(ctx context.Context, email, password string) (*domain.User, error) {
+func (s *UserService) registerLoginFailure(ctx context.Context, accountKey string, clientIp string) {
+	lockout, err := s.accountLimiter.RegisterFailure(ctx, accountKey)
+	if err != nil {
+		s.logger.Warn("fail count account login failure", slogext.Cause(err))
+	} else if lockout > 0 {
+		s.logger.Warn("account locked out after failed logins", "lockout", lockout)
+	}
+
+	if clientIp == "" {
+		return
+	}
+
+	lockout, err = s.ipLimiter.RegisterFailure(ctx, clientIp)
+	if err != nil {
+		s.logger.Warn("fail count address login failure", slogext.Cause(err))
+	} else if lockout > 0 {
+		s.logger.Warn("address locked out after failed logins", "client_ip", clientIp, "lockout", lockout)
+	}
+}
+
+func (s *UserService) findUserWithCheckCredentials(ctx context.Context, email, password string) (*domain.User, error) {
 	var user *domain.User
 
 	user, err := s.cache.GetUserByEmail(ctx, email)
@@ -84,25 +178,406 @@ func (s *UserService) GetUserWithCheckCredentials(ctx context.Context, email, pa
 		}
 	}
 
-	hash := hashFunc([]byte(password), user.Salt)
-	if !bytes.Equal(user.PasswordHash, hash) {
+	match, outdated := s.checkPassword(user, password)
+	if !match {
 		return nil, application.ErrWrongCredentials
 	}
 
+	if outdated {
+		s.rehashPassword(ctx, user, password)
+	}
+
+	return user, nil
+}
+
+// rehashPassword replaces outdated hash through request unit of work, so it is saved when request commits;
+// requests which never commit drop it and it is retried on next login. Failure is only logged because login itself has succeeded
+func (s *UserService) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	rehashed := *user
+	if err := setPassword(&rehashed, password); err != nil {
+		s.logger.Warn("fail rehash password", "user_id", user.Id, slogext.Cause(err))
+		return
+	}
+
+	err := s.uow.UserRepository(ctx).UpdatePassword(ctx, &rehashed)
+	if err != nil {
+		s.logger.Warn("fail save rehashed password", "user_id", user.Id, slogext.Cause(err))
+		return
+	}
+
+	// cached entry still holds old hash, so next login reads whichever hash database has
+	if err := s.cache.DeleteUserByEmail(ctx, user.Email); err != nil {
+		s.logger.Warn("fail evict rehashed user from cache", "user_id", user.Id, slogext.Cause(err))
+	}
+
+	s.logger.Info("password rehashed with current params", "user_id", user.Id)
+}
+
+func (s *UserService) GetUser(ctx context.Context, id domain.UserId) (*domain.User, error) {
+	user, err := s.uow.UserRepository(ctx).GetUserById(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrUserNotFound) {
+			err = fmt.Errorf("%w: %s", application.ErrUserNotFound, err.Error())
+		}
+
+		return nil, fmt.Errorf("fail get user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *UserService) UpdateProfile(ctx context.Context, update *application.ProfileUpdate) (*domain.User, error) {
+	user, err := s.GetUser(ctx, update.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		user.Name = *update.Name
+	}
+
+	if update.Country != nil {
+		user.Country = *update.Country
+	}
+
+	if update.Organization != nil {
+		user.Organization = *update.Organization
+	}
+
+	if update.Bio != nil {
+		user.Bio = *update.Bio
+	}
+
+	err = s.uow.UserRepository(ctx).UpdateUser(ctx, user)
+	if err == nil && update.Email != nil && *update.Email != user.Email {
+		err = s.checkEmailAvailable(ctx, *update.Email)
+	}
+
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return nil, fmt.Errorf("fail update profile: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *UserService) ConfirmEmailChange(ctx context.Context, token string) (*domain.User, error) {
+	change, err := s.tokens.ConsumeToken(ctx, domain.TokenPurposeEmailChange, securetoken.Hash(token))
+	if err != nil {
+		return nil, fmt.Errorf("fail consume token: %w", err)
+	}
+
+	if change == nil {
+		return nil, fmt.Errorf("%w: no pending email change", application.ErrInvalidToken)
+	}
+
+	user, err := s.GetUser(ctx, change.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *user
+	user.Email = change.Email
+	// token was delivered to new address, which proves its ownership
+	user.EmailVerified = true
+
+	err = s.uow.UserRepository(ctx).UpdateUser(ctx, user)
+	if err != nil {
+		// address may have been taken after token was issued
+		if errors.Is(err, infrastructure.ErrDuplicateEmail) {
+			err = fmt.Errorf("%w: %s", application.ErrDuplicateEmail, err.Error())
+		}
+
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return nil, fmt.Errorf("fail change email: %w", err)
+	}
+
+	return &previous, nil
+}
+
+func (s *UserService) VerifyEmail(ctx context.Context, token string) (*domain.User, error) {
+	verification, err := s.tokens.ConsumeToken(ctx, domain.TokenPurposeEmailVerification, securetoken.Hash(token))
+	if err != nil {
+		return nil, fmt.Errorf("fail consume token: %w", err)
+	}
+
+	if verification == nil {
+		return nil, fmt.Errorf("%w: no pending email verification", application.ErrInvalidToken)
+	}
+
+	user, err := s.GetUser(ctx, verification.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Email != verification.Email {
+		return nil, fmt.Errorf("%w: email changed after verification was requested", application.ErrInvalidToken)
+	}
+
+	if user.EmailVerified {
+		return user, nil
+	}
+
+	user.EmailVerified = true
+
+	err = s.uow.UserRepository(ctx).UpdateUser(ctx, user)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return nil, fmt.Errorf("fail verify email: %w", err)
+	}
+
 	return user, nil
 }
 
-func (s *UserService) DeleteUser(ctx context.Context, id domain.UserId) error {
-	return errors.New("not implemented")
+func (s *UserService) ResendVerification(ctx context.Context, id domain.UserId) error {
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	return s.sendVerification(ctx, user)
+}
+
+func (s *UserService) InvalidateUserCache(ctx context.Context, email string) error {
+	if err := s.cache.DeleteUserByEmail(ctx, email); err != nil {
+		return fmt.Errorf("fail evict user from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (s *UserService) ChangePassword(ctx context.Context, id domain.UserId, oldPassword string, newPassword string, clientIp string) (*domain.User, error) {
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.confirmPassword(ctx, user, oldPassword, clientIp); err != nil {
+		return nil, fmt.Errorf("old password does not match: %w", err)
+	}
+
+	return s.updatePassword(ctx, user, newPassword)
+}
+
+func (s *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.uow.UserRepository(ctx).GetUserByEmail(ctx, email)
+	if errors.Is(err, infrastructure.ErrUserNotFound) {
+		s.logger.Info("password reset requested for unknown email")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("fail get user: %w", err)
+	}
+
+	return s.sendToken(ctx, user, &domain.OneTimeToken{
+		Purpose:   domain.TokenPurposePasswordReset,
+		UserId:    user.Id,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(passwordResetTokenTtl),
+	}, "Reset your password", "use this token to set new password")
 }
 
-func hashFunc(password []byte, salt []byte) []byte {
-	return argon2.IDKey(
-		password,
-		salt,
-		1,
-		64*1024,
-		4,
-		32,
-	)
+func (s *UserService) ResetPassword(ctx context.Context, token string, newPassword string) (*domain.User, error) {
+	reset, err := s.tokens.ConsumeToken(ctx, domain.TokenPurposePasswordReset, securetoken.Hash(token))
+	if err != nil {
+		return nil, fmt.Errorf("fail consume token: %w", err)
+	}
+
+	if reset == nil {
+		return nil, fmt.Errorf("%w: no pending password reset", application.ErrInvalidToken)
+	}
+
+	user, err := s.GetUser(ctx, reset.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// token was mailed to address which is no longer owned by user
+	if user.Email != reset.Email {
+		return nil, fmt.Errorf("%w: email changed after reset was requested", application.ErrInvalidToken)
+	}
+
+	return s.updatePassword(ctx, user, newPassword)
+}
+
+func (s *UserService) updatePassword(ctx context.Context, user *domain.User, password string) (*domain.User, error) {
+	err := setPassword(user, password)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.UserRepository(ctx).UpdatePassword(ctx, user)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return nil, fmt.Errorf("fail update password: %w", err)
+	}
+
+	return user, nil
+}
+
+// setPassword stores PHC string as hash; salt is embedded there and duplicated in Salt only to keep column filled
+func setPassword(user *domain.User, password string) error {
+	hash, salt, err := newPasswordHash(password, currentHashParams)
+	if err != nil {
+		return fmt.Errorf("fail hash password: %w", err)
+	}
+
+	user.PasswordHash = hash
+	user.Salt = salt
+
+	return nil
+}
+
+// checkEmailAvailable rejects taken address early; it can still be taken before confirmation, which ConfirmEmailChange reports
+func (s *UserService) checkEmailAvailable(ctx context.Context, email string) error {
+	_, err := s.uow.UserRepository(ctx).GetUserByEmail(ctx, email)
+	if err == nil {
+		return fmt.Errorf("%w: %s is taken", application.ErrDuplicateEmail, email)
+	}
+
+	if !errors.Is(err, infrastructure.ErrUserNotFound) {
+		return fmt.Errorf("fail check email: %w", err)
+	}
+
+	return nil
+}
+
+// RequestEmailChange keeps current email until owner of new address confirms it
+func (s *UserService) RequestEmailChange(ctx context.Context, id domain.UserId, email string) error {
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.sendToken(ctx, user, &domain.OneTimeToken{
+		Purpose:   domain.TokenPurposeEmailChange,
+		UserId:    user.Id,
+		Email:     email,
+		ExpiresAt: time.Now().Add(emailChangeTokenTtl),
+	}, "Confirm your new email", "use this token to confirm your new email")
+}
+
+func (s *UserService) sendVerification(ctx context.Context, user *domain.User) error {
+	return s.sendToken(ctx, user, &domain.OneTimeToken{
+		Purpose:   domain.TokenPurposeEmailVerification,
+		UserId:    user.Id,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(emailVerificationTokenTtl),
+	}, "Verify your email", "use this token to verify your email")
+}
+
+// sendToken issues one-time token and mails it to token email, which is not always current email of user
+func (s *UserService) sendToken(ctx context.Context, user *domain.User, token *domain.OneTimeToken, subject string, action string) error {
+	secret, err := securetoken.New(emailTokenBytes)
+	if err != nil {
+		return fmt.Errorf("fail generate %s token: %w", token.Purpose, err)
+	}
+
+	err = s.tokens.SaveToken(ctx, securetoken.Hash(secret), token)
+	if err != nil {
+		return fmt.Errorf("fail save %s token: %w", token.Purpose, err)
+	}
+
+	err = s.mailer.Send(ctx, &infrastructure.Mail{
+		To:      token.Email,
+		Subject: subject,
+		Body: fmt.Sprintf("Hello %s,\n\n%s: %s\n\nIt expires at %s.",
+			user.Name, action, secret, token.ExpiresAt.UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		return fmt.Errorf("fail send %s mail: %w", token.Purpose, err)
+	}
+
+	return nil
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, id domain.UserId, password string, clientIp string) error {
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// password is asked again since bearer token alone must not be enough to destroy account
+	if err := s.confirmPassword(ctx, user, password, clientIp); err != nil {
+		return fmt.Errorf("password confirmation failed: %w", err)
+	}
+
+	teams, err := s.teams.ListTeams(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// leaving passes captaincy on, so remaining members keep managing their teams
+	for _, team := range teams {
+		if err := s.teams.LeaveTeam(ctx, team.Id, id); err != nil {
+			return err
+		}
+	}
+
+	err = s.uow.UserRepository(ctx).DeleteUser(ctx, id)
+	if err != nil {
+		rollbackErr := s.uow.RollbackChanges(context.WithoutCancel(ctx))
+		err = errors.Join(err, rollbackErr)
+
+		return fmt.Errorf("fail delete user: %w", err)
+	}
+
+	return nil
+}
+
+func (s *UserService) RevokeUserAccess(ctx context.Context, user *domain.User) error {
+	var errs []error
+
+	if err := s.InvalidateUserCache(ctx, user.Email); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := s.sessions.DeleteUserSessions(ctx, user.Id); err != nil {
+		errs = append(errs, fmt.Errorf("fail revoke user sessions: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// confirmPassword re-authenticates signed in user under the same lockout as login, so session holder cannot guess password freely
+func (s *UserService) confirmPassword(ctx context.Context, user *domain.User, password string, clientIp string) error {
+	accountKey := strings.ToLower(user.Email)
+
+	if err := s.checkLockout(ctx, accountKey, clientIp); err != nil {
+		return err
+	}
+
+	if match, _ := s.checkPassword(user, password); !match {
+		s.registerLoginFailure(ctx, accountKey, clientIp)
+		return application.ErrWrongCredentials
+	}
+
+	if err := s.accountLimiter.Reset(ctx, accountKey); err != nil {
+		s.logger.Warn("fail reset login failures", "user_id", user.Id, slogext.Cause(err))
+	}
+
+	return nil
+}
+
+func (s *UserService) checkPassword(user *domain.User, password string) (match bool, outdated bool) {
+	match, outdated, err := verifyPassword(user.PasswordHash, user.Salt, password)
+	if err != nil {
+		s.logger.Error("fail verify password", "user_id", user.Id, slogext.Cause(err))
+		return false, false
+	}
+
+	return match, outdated
 }
@@ -3,25 +3,63 @@ package scope
 import (
 	"cplatform/internal/application/contracts/infrastructure"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
 type Factory struct {
-	uowFactory infrastructure.UnitOfWorkFactory
-	cache      infrastructure.Cache
-	logger     *slog.Logger
+	uowFactory  infrastructure.UnitOfWorkFactory
+	cache       infrastructure.Cache
+	testStorage infrastructure.TestStorage
+	jobQueue    infrastructure.JobQueue
+	languages   infrastructure.LanguageRegistry
+	sessions    infrastructure.SessionStore
+	sessionTtl  time.Duration
+	tokens      infrastructure.TokenStore
+	mailer      infrastructure.Mailer
+	// accountLimiter and ipLimiter throttle failed logins
+	accountLimiter infrastructure.AttemptLimiter
+	ipLimiter      infrastructure.AttemptLimiter
+	// identityProviders and externalLogins serve sign in through OpenID Connect providers
+	identityProviders infrastructure.IdentityProviderRegistry
+	externalLogins    infrastructure.ExternalLoginStore
+	logger            *slog.Logger
 }
 
 func NewFactory(
 	uowFactory infrastructure.UnitOfWorkFactory,
 	cache infrastructure.Cache,
+	testStorage infrastructure.TestStorage,
+	jobQueue infrastructure.JobQueue,
+	languages infrastructure.LanguageRegistry,
+	sessions infrastructure.SessionStore,
+	sessionTtl time.Duration,
+	tokens infrastructure.TokenStore,
+	mailer infrastructure.Mailer,
+	accountLimiter infrastructure.AttemptLimiter,
+	ipLimiter infrastructure.AttemptLimiter,
+	identityProviders infrastructure.IdentityProviderRegistry,
+	externalLogins infrastructure.ExternalLoginStore,
 	logger *slog.Logger,
 ) *Factory {
 	return &Factory{
-		uowFactory: uowFactory,
-		cache:      cache,
-		logger:     logger,
+		uowFactory:  uowFactory,
+		cache:       cache,
+		testStorage: testStorage,
+		jobQueue:    jobQueue,
+		languages:   languages,
+		sessions:    sessions,
+		sessionTtl:  sessionTtl,
+		tokens:      tokens,
+		mailer:      mailer,
+
+		accountLimiter: accountLimiter,
+		ipLimiter:      ipLimiter,
+
+		identityProviders: identityProviders,
+		externalLogins:    externalLogins,
+		logger:            logger,
 	}
 }
 
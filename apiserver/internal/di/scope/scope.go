@@ -2,8 +2,18 @@ package scope
 
 import (
 	"context"
+	"cplatform/internal/application/accesstokens"
+	"cplatform/internal/application/contests"
 	"cplatform/internal/application/contracts/application"
 	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/application/identities"
+	"cplatform/internal/application/languages"
+	"cplatform/internal/application/problems"
+	"cplatform/internal/application/roles"
+	"cplatform/internal/application/scoreboard"
+	"cplatform/internal/application/sessions"
+	"cplatform/internal/application/submissions"
+	"cplatform/internal/application/teams"
 	"cplatform/internal/application/users"
 	"fmt"
 	"sync"
@@ -20,6 +30,36 @@ type Scope struct {
 
 	userServiceMux sync.Mutex
 	userService    application.UserService
+
+	contestServiceMux sync.Mutex
+	contestService    application.ContestService
+
+	problemServiceMux sync.Mutex
+	problemService    application.ProblemService
+
+	submissionServiceMux sync.Mutex
+	submissionService    application.SubmissionService
+
+	teamServiceMux sync.Mutex
+	teamService    application.TeamService
+
+	scoreboardServiceMux sync.Mutex
+	scoreboardService    application.ScoreboardService
+
+	roleServiceMux sync.Mutex
+	roleService    application.RoleService
+
+	sessionServiceMux sync.Mutex
+	sessionService    application.SessionService
+
+	languageServiceMux sync.Mutex
+	languageService    application.LanguageService
+
+	identityServiceMux sync.Mutex
+	identityService    application.IdentityService
+
+	accessTokenServiceMux sync.Mutex
+	accessTokenService    application.AccessTokenService
 }
 
 func (s *Scope) UserService(ctx context.Context) application.UserService {
@@ -28,13 +68,143 @@ func (s *Scope) UserService(ctx context.Context) application.UserService {
 		defer s.userServiceMux.Unlock()
 
 		if s.userService == nil {
-			s.userService = users.NewUserService(s.UnitOfWork(ctx), s.factory.cache, s.factory.logger)
+			s.userService = users.NewUserService(s.UnitOfWork(ctx), s.factory.cache, s.factory.sessions, s.factory.tokens, s.factory.mailer, s.TeamService(ctx), s.factory.accountLimiter, s.factory.ipLimiter, s.factory.logger)
 		}
 	}
 
 	return s.userService
 }
 
+func (s *Scope) ContestService(ctx context.Context) application.ContestService {
+	if s.contestService == nil {
+		s.contestServiceMux.Lock()
+		defer s.contestServiceMux.Unlock()
+
+		if s.contestService == nil {
+			s.contestService = contests.NewContestService(s.UnitOfWork(ctx), s.factory.logger)
+		}
+	}
+
+	return s.contestService
+}
+
+func (s *Scope) ProblemService(ctx context.Context) application.ProblemService {
+	if s.problemService == nil {
+		s.problemServiceMux.Lock()
+		defer s.problemServiceMux.Unlock()
+
+		if s.problemService == nil {
+			s.problemService = problems.NewProblemService(s.UnitOfWork(ctx), s.factory.testStorage, s.factory.logger)
+		}
+	}
+
+	return s.problemService
+}
+
+func (s *Scope) SubmissionService(ctx context.Context) application.SubmissionService {
+	if s.submissionService == nil {
+		s.submissionServiceMux.Lock()
+		defer s.submissionServiceMux.Unlock()
+
+		if s.submissionService == nil {
+			s.submissionService = submissions.NewSubmissionService(s.UnitOfWork(ctx), s.factory.jobQueue, s.factory.languages, s.factory.logger)
+		}
+	}
+
+	return s.submissionService
+}
+
+func (s *Scope) TeamService(ctx context.Context) application.TeamService {
+	if s.teamService == nil {
+		s.teamServiceMux.Lock()
+		defer s.teamServiceMux.Unlock()
+
+		if s.teamService == nil {
+			s.teamService = teams.NewTeamService(s.UnitOfWork(ctx), s.factory.logger)
+		}
+	}
+
+	return s.teamService
+}
+
+func (s *Scope) ScoreboardService(ctx context.Context) application.ScoreboardService {
+	if s.scoreboardService == nil {
+		s.scoreboardServiceMux.Lock()
+		defer s.scoreboardServiceMux.Unlock()
+
+		if s.scoreboardService == nil {
+			s.scoreboardService = scoreboard.NewScoreboardService(s.UnitOfWork(ctx), s.factory.cache, s.factory.logger)
+		}
+	}
+
+	return s.scoreboardService
+}
+
+func (s *Scope) RoleService(ctx context.Context) application.RoleService {
+	if s.roleService == nil {
+		s.roleServiceMux.Lock()
+		defer s.roleServiceMux.Unlock()
+
+		if s.roleService == nil {
+			s.roleService = roles.NewRoleService(s.UnitOfWork(ctx), s.factory.logger)
+		}
+	}
+
+	return s.roleService
+}
+
+func (s *Scope) SessionService(ctx context.Context) application.SessionService {
+	if s.sessionService == nil {
+		s.sessionServiceMux.Lock()
+		defer s.sessionServiceMux.Unlock()
+
+		if s.sessionService == nil {
+			s.sessionService = sessions.NewSessionService(s.UnitOfWork(ctx), s.UserService(ctx), s.factory.sessions, s.factory.sessionTtl, s.factory.logger)
+		}
+	}
+
+	return s.sessionService
+}
+
+func (s *Scope) LanguageService(context.Context) application.LanguageService {
+	if s.languageService == nil {
+		s.languageServiceMux.Lock()
+		defer s.languageServiceMux.Unlock()
+
+		if s.languageService == nil {
+			s.languageService = languages.NewLanguageService(s.factory.languages, s.factory.logger)
+		}
+	}
+
+	return s.languageService
+}
+
+func (s *Scope) IdentityService(ctx context.Context) application.IdentityService {
+	if s.identityService == nil {
+		s.identityServiceMux.Lock()
+		defer s.identityServiceMux.Unlock()
+
+		if s.identityService == nil {
+			s.identityService = identities.NewIdentityService(s.UnitOfWork(ctx), s.factory.identityProviders, s.factory.externalLogins, s.factory.logger)
+		}
+	}
+
+	return s.identityService
+}
+
+func (s *Scope) AccessTokenService(ctx context.Context) application.AccessTokenService {
+	if s.accessTokenService == nil {
+		s.accessTokenServiceMux.Lock()
+		defer s.accessTokenServiceMux.Unlock()
+
+		if s.accessTokenService == nil {
+			s.accessTokenService = accesstokens.NewAccessTokenService(s.UnitOfWork(ctx), s.factory.logger)
+		}
+	}
+
+	return s.accessTokenService
+}
+
 func (s *Scope) UnitOfWork(ctx context.Context) infrastructure.UnitOfWork {
 	if s.uow == nil {
 		s.uowMux.Lock()
@@ -47,6 +217,11 @@ func (s *Scope) UnitOfWork(ctx context.Context) infrastructure.UnitOfWork {
 }
 
 func (s *Scope) Close(ctx context.Context) error {
+	// scopes of handlers which never touched database have no unit of work
+	if s.uow == nil {
+		return nil
+	}
+
 	err := s.uow.Close(ctx)
 	if err != nil {
 		return fmt.Errorf("fail close req scoped services: %w", err)
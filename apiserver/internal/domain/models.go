@@ -1,9 +1,438 @@
 package domain
 
+import (
+	"slices"
+	"time"
+)
+
 type User struct {
 	Id           UserId
 	Name         string
 	Email        string
 	Salt         []byte
 	PasswordHash []byte
+	// Country is ISO 3166-1 alpha-2 code or empty
+	Country      string
+	Organization string
+	Bio          string
+	// EmailVerified is set once user proves ownership of Email
+	EmailVerified bool
+}
+
+// UserIdentity links account at external identity provider to user
+type UserIdentity struct {
+	Provider string
+	// Subject is stable id of account at provider, unlike email it never changes
+	Subject   string
+	UserId    UserId
+	Email     string
+	CreatedAt time.Time
+}
+
+// ExternalIdentity is what provider asserts about user on successful login
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// ExternalLogin keeps secrets of started login until provider redirects user back
+type ExternalLogin struct {
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
+
+type TokenPurpose string
+
+const (
+	// TokenPurposeEmailChange confirms that user owns new email address
+	TokenPurposeEmailChange TokenPurpose = "email_change"
+	// TokenPurposePasswordReset lets user set new password without knowing old one
+	TokenPurposePasswordReset TokenPurpose = "password_reset"
+	// TokenPurposeEmailVerification confirms email given on registration
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+)
+
+// OneTimeToken is mailed to user and consumed on first use
+type OneTimeToken struct {
+	Purpose   TokenPurpose
+	UserId    UserId
+	Email     string
+	ExpiresAt time.Time
+}
+
+// Session is issued in exchange for credentials; its opaque token is known only to client
+type Session struct {
+	UserId    UserId
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// AccessTokenPrefix tells personal access tokens from session tokens sent with the same bearer scheme
+const AccessTokenPrefix = "cpat_"
+
+// TokenScope limits what personal access token may be used for
+type TokenScope string
+
+const (
+	// TokenScopeRead allows viewing contests, problems, submissions and standings
+	TokenScopeRead TokenScope = "read"
+	// TokenScopeSubmit allows sending solutions
+	TokenScopeSubmit TokenScope = "submit"
+	// TokenScopeProblems allows creating and updating problems and uploading their tests
+	TokenScopeProblems TokenScope = "problems"
+)
+
+var tokenScopes = []TokenScope{TokenScopeRead, TokenScopeSubmit, TokenScopeProblems}
+
+func (s TokenScope) IsValid() bool {
+	return slices.Contains(tokenScopes, s)
+}
+
+// AccessToken is long lived credential for scripts; only hash of its secret is stored
+type AccessToken struct {
+	Id     AccessTokenId
+	UserId UserId
+	Name   string
+	Scopes []TokenScope
+	// ExpiresAt is nil for tokens valid until revoked
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+func (t *AccessToken) Allows(scope TokenScope) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+func (t *AccessToken) IsExpired(at time.Time) bool {
+	return t.ExpiresAt != nil && !at.Before(*t.ExpiresAt)
+}
+
+type Role string
+
+const (
+	// RoleAdmin is global role granting every permission on every resource
+	RoleAdmin Role = "admin"
+	// RoleProblemSetter is global role allowing to author problems
+	RoleProblemSetter Role = "problem_setter"
+	// RoleContestOwner is held by contest creator and co-owners granted by them
+	RoleContestOwner Role = "contest_owner"
+	// RoleJury sees live standings and every submission of contest without taking part in it
+	RoleJury Role = "jury"
+	// RoleParticipant is held by registered contestants
+	RoleParticipant Role = "participant"
+)
+
+func (r Role) IsGlobal() bool {
+	return r == RoleAdmin || r == RoleProblemSetter
+}
+
+// IsGrantable reports whether role is stored as explicit grant; participation comes from registration
+func (r Role) IsGrantable() bool {
+	return r.IsGlobal() || r == RoleContestOwner || r == RoleJury
+}
+
+type Permission string
+
+const (
+	PermissionManageRoles   Permission = "roles.manage"
+	PermissionCreateProblem Permission = "problems.create"
+	// PermissionEditAnyProblem lets to view and change problems of other authors
+	PermissionEditAnyProblem Permission = "problems.edit_any"
+	PermissionViewContest    Permission = "contest.view"
+	PermissionManageContest  Permission = "contest.manage"
+	// PermissionJudgeContest covers live standings, every submission and problems before start
+	PermissionJudgeContest Permission = "contest.judge"
+	PermissionSubmit       Permission = "contest.submit"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleProblemSetter: {PermissionCreateProblem},
+	RoleContestOwner:  {PermissionViewContest, PermissionManageContest, PermissionJudgeContest, PermissionSubmit},
+	RoleJury:          {PermissionViewContest, PermissionJudgeContest, PermissionSubmit},
+	RoleParticipant:   {PermissionViewContest, PermissionSubmit},
+}
+
+func (r Role) Grants(permission Permission) bool {
+	if r == RoleAdmin {
+		return true
+	}
+
+	return slices.Contains(rolePermissions[r], permission)
+}
+
+// RoleGrant assigns role to user; contest roles carry ContestId, global ones leave it nil
+type RoleGrant struct {
+	UserId    UserId
+	Role      Role
+	ContestId *ContestId
+	GrantedBy UserId
+	GrantedAt time.Time
+}
+
+type ContestVisibility string
+
+const (
+	ContestVisibilityPublic  ContestVisibility = "public"
+	ContestVisibilityPrivate ContestVisibility = "private"
+)
+
+type ScoringMode string
+
+const (
+	// ScoringModeIcpc ranks by solved problems, then by penalty time
+	ScoringModeIcpc ScoringMode = "icpc"
+	// ScoringModeIoi ranks by sum of best subtask scores
+	ScoringModeIoi ScoringMode = "ioi"
+)
+
+type Contest struct {
+	Id          ContestId
+	Title       string
+	Description string
+	StartTime   time.Time
+	Duration    time.Duration
+	// FreezeTime is the moment the scoreboard stops updating for participants; nil means no freeze
+	FreezeTime  *time.Time
+	Visibility  ContestVisibility
+	ScoringMode ScoringMode
+	// RegistrationDeadline closes registration; nil means registration is open until contest ends
+	RegistrationDeadline *time.Time
+	// Capacity limits number of participants; 0 means unlimited
+	Capacity int
+	// InviteCode must be presented on registration when not empty
+	InviteCode string
+	// RequireVerifiedEmail denies registration and submissions to users with unverified email
+	RequireVerifiedEmail bool
+	OwnerId              UserId
+}
+
+func (c *Contest) EndTime() time.Time {
+	return c.StartTime.Add(c.Duration)
+}
+
+func (c *Contest) IsRunning(at time.Time) bool {
+	return !at.Before(c.StartTime) && at.Before(c.EndTime())
+}
+
+func (c *Contest) IsRegistrationOpen(at time.Time) bool {
+	if c.RegistrationDeadline != nil {
+		return at.Before(*c.RegistrationDeadline)
+	}
+
+	return at.Before(c.EndTime())
+}
+
+// Team competes as single contestant; roster is copied to contest participants on registration
+type Team struct {
+	Id        TeamId
+	Name      string
+	CaptainId UserId
+	// InviteToken lets users join team; captain can rotate it to revoke old invites
+	InviteToken string
+	Members     []TeamMember
+	CreatedAt   time.Time
+}
+
+type TeamMember struct {
+	UserId   UserId
+	Name     string
+	JoinedAt time.Time
+}
+
+// ContestParticipant is registered user; members of registered team share TeamId
+type ContestParticipant struct {
+	ContestId    ContestId
+	UserId       UserId
+	UserName     string
+	TeamId       *TeamId
+	TeamName     string
+	RegisteredAt time.Time
+}
+
+type Problem struct {
+	Id             ProblemId
+	AuthorId       UserId
+	LatestRevision int
+	CreatedAt      time.Time
+}
+
+// ProblemRevision is an immutable snapshot of problem; every edit produces the next revision
+type CheckerKind string
+
+// Default epsilons are used by float checker when problem does not set them
+const (
+	DefaultCheckerAbsEpsilon = 1e-6
+	DefaultCheckerRelEpsilon = 1e-6
+)
+
+const (
+	// CheckerExact compares output with answer byte by byte
+	CheckerExact CheckerKind = "exact"
+	// CheckerTokens compares whitespace separated tokens
+	CheckerTokens CheckerKind = "tokens"
+	// CheckerFloat compares tokens as numbers when both parse, accepting error within absolute or relative epsilon
+	CheckerFloat CheckerKind = "float"
+	// CheckerCustom runs testlib style checker uploaded together with tests
+	CheckerCustom CheckerKind = "custom"
+)
+
+type ProblemRevision struct {
+	ProblemId     ProblemId
+	Revision      int
+	Title         string
+	Statement     string
+	InputSpec     string
+	OutputSpec    string
+	TimeLimit     time.Duration
+	MemoryLimitKb int64
+	Tags          []string
+	Checker       CheckerKind
+	// CheckerAbsEpsilon and CheckerRelEpsilon are used only by CheckerFloat, zero disables that comparison
+	CheckerAbsEpsilon float64
+	CheckerRelEpsilon float64
+	// TestSetId is nil until tests are uploaded; statement edits carry it over unchanged
+	TestSetId *TestSetId
+	AuthorId  UserId
+	CreatedAt time.Time
+}
+
+type ProblemTest struct {
+	// Ordinal is 1-based position of test in judging order
+	Ordinal int
+	// Subtask is 0 when test set has no subtasks
+	Subtask    int
+	IsSample   bool
+	InputSize  int64
+	AnswerSize int64
+}
+
+type ProblemSubtask struct {
+	Index int
+	Score int
+}
+
+// TestSet is an immutable group of tests; revisions reference it, so re-upload creates new set
+type TestSet struct {
+	Id        TestSetId
+	ProblemId ProblemId
+	Tests     []ProblemTest
+	Subtasks  []ProblemSubtask
+	// CheckerSource is C++ source of custom checker, empty when none uploaded
+	CheckerSource string
+	CreatedAt     time.Time
+}
+
+// ContestProblem pins exact problem revision to contest under label
+type ContestProblem struct {
+	ContestId ContestId
+	Label     string
+	ProblemId ProblemId
+	Revision  int
+	Title     string
+}
+
+// Language describes how judge builds and runs submissions; commands run in directory containing SourceFile
+type Language struct {
+	Id         string
+	Name       string
+	SourceFile string
+	// CompileCommand is empty for interpreted languages
+	CompileCommand []string
+	RunCommand     []string
+	// TimeMultiplier scales problem time limit for slow languages
+	TimeMultiplier float64
+}
+
+type SubmissionState string
+
+const (
+	SubmissionStateQueued  SubmissionState = "queued"
+	SubmissionStateJudging SubmissionState = "judging"
+	SubmissionStateJudged  SubmissionState = "judged"
+	// SubmissionStateFailed means judge could not produce verdict because of system error
+	SubmissionStateFailed SubmissionState = "failed"
+)
+
+type Verdict string
+
+const (
+	VerdictAccepted            Verdict = "OK"
+	VerdictWrongAnswer         Verdict = "WA"
+	VerdictTimeLimitExceeded   Verdict = "TLE"
+	VerdictMemoryLimitExceeded Verdict = "MLE"
+	VerdictRuntimeError        Verdict = "RE"
+	VerdictCompilationError    Verdict = "CE"
+)
+
+type Submission struct {
+	Id              SubmissionId
+	ContestId       ContestId
+	ProblemLabel    string
+	ProblemId       ProblemId
+	ProblemRevision int
+	UserId          UserId
+	// TeamId is set when author participates as team member
+	TeamId      *TeamId
+	LanguageId  string
+	Source      string
+	State       SubmissionState
+	SubmittedAt time.Time
+	Result      *SubmissionResult
+}
+
+// SubmissionResult is filled by judge once submission is judged
+type SubmissionResult struct {
+	Verdict Verdict
+	// Score is sum of fully passed subtasks, 100 or 0 for problems without subtasks
+	Score         int
+	SubtaskScores []int
+	// FailedTest is ordinal of first test with verdict other than OK, 0 when all passed
+	FailedTest   int
+	TimeUsed     time.Duration
+	MemoryUsedKb int64
+	JudgeLog     string
+	JudgedAt     time.Time
+}
+
+// StandingsCell is result of one contestant on one problem
+type StandingsCell struct {
+	Label  string
+	Solved bool
+	// Attempts counts rejected submissions, for solved problems only ones before first accepted
+	Attempts int
+	// SolvedAt is time from contest start to first accepted submission
+	SolvedAt time.Duration
+	// Score is sum of best subtask scores in IOI mode
+	Score int
+	// Pending counts submissions without verdict, including ones hidden by freeze
+	Pending int
+}
+
+type StandingsRow struct {
+	// Rank is shared by contestants with equal results
+	Rank int
+	// UserId is zero for team rows
+	UserId         UserId
+	TeamId         *TeamId
+	Name           string
+	Solved         int
+	PenaltyMinutes int
+	Score          int
+	// Cells follow order of Standings.Problems
+	Cells []StandingsCell
+}
+
+type Standings struct {
+	ContestId ContestId
+	Mode      ScoringMode
+	Problems  []string
+	// Frozen standings ignore verdicts of submissions made after contest freeze time
+	Frozen      bool
+	Rows        []StandingsRow
+	GeneratedAt time.Time
 }
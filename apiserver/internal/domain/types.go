@@ -3,3 +3,15 @@ package domain
 type defaultId int64
 
 type UserId defaultId
+
+type ContestId defaultId
+
+type TeamId defaultId
+
+type ProblemId defaultId
+
+type TestSetId defaultId
+
+type SubmissionId defaultId
+
+type AccessTokenId defaultId
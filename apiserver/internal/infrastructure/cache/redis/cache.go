@@ -59,3 +59,12 @@ func (r *Cache) GetUserByEmail(ctx context.Context, email string) (*domain.User,
 
 	return user, nil
 }
+
+func (r *Cache) DeleteUserByEmail(ctx context.Context, email string) error {
+	err := r.client.Del(ctx, email).Err()
+	if err != nil {
+		return fmt.Errorf("could not delete user: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"context"
+	"cplatform/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// standingsTtl bounds staleness after contest edits, which do not invalidate standings explicitly
+const standingsTtl = 30 * time.Second
+
+type StandingsCellDto struct {
+	Label    string        `json:"label"`
+	Solved   bool          `json:"solved"`
+	Attempts int           `json:"attempts"`
+	SolvedAt time.Duration `json:"solved_at"`
+	Score    int           `json:"score"`
+	Pending  int           `json:"pending"`
+}
+
+type StandingsRowDto struct {
+	Rank           int                `json:"rank"`
+	UserId         int64              `json:"user_id"`
+	TeamId         *int64             `json:"team_id,omitempty"`
+	Name           string             `json:"name"`
+	Solved         int                `json:"solved"`
+	PenaltyMinutes int                `json:"penalty_minutes"`
+	Score          int                `json:"score"`
+	Cells          []StandingsCellDto `json:"cells"`
+}
+
+type StandingsDto struct {
+	ContestId   int64             `json:"contest_id"`
+	Mode        string            `json:"mode"`
+	Problems    []string          `json:"problems"`
+	Frozen      bool              `json:"frozen"`
+	Rows        []StandingsRowDto `json:"rows"`
+	GeneratedAt time.Time         `json:"generated_at"`
+}
+
+func (d StandingsDto) MarshalBinary() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *StandingsDto) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+func (r *Cache) SaveStandings(ctx context.Context, standings *domain.Standings) error {
+	dto := StandingsDto{
+		ContestId:   int64(standings.ContestId),
+		Mode:        string(standings.Mode),
+		Problems:    standings.Problems,
+		Frozen:      standings.Frozen,
+		Rows:        make([]StandingsRowDto, 0, len(standings.Rows)),
+		GeneratedAt: standings.GeneratedAt,
+	}
+
+	for _, row := range standings.Rows {
+		rowDto := StandingsRowDto{
+			Rank:           row.Rank,
+			UserId:         int64(row.UserId),
+			TeamId:         (*int64)(row.TeamId),
+			Name:           row.Name,
+			Solved:         row.Solved,
+			PenaltyMinutes: row.PenaltyMinutes,
+			Score:          row.Score,
+			Cells:          make([]StandingsCellDto, 0, len(row.Cells)),
+		}
+
+		for _, cell := range row.Cells {
+			rowDto.Cells = append(rowDto.Cells, StandingsCellDto(cell))
+		}
+
+		dto.Rows = append(dto.Rows, rowDto)
+	}
+
+	err := r.client.Set(ctx, standingsKey(standings.ContestId, standings.Frozen), dto, standingsTtl).Err()
+	if err != nil {
+		return fmt.Errorf("could not save standings: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Cache) GetStandings(ctx context.Context, contestId domain.ContestId, frozen bool) (*domain.Standings, error) {
+	var dto StandingsDto
+	err := r.client.Get(ctx, standingsKey(contestId, frozen)).Scan(&dto)
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not get standings: %w", err)
+	}
+
+	standings := &domain.Standings{
+		ContestId:   domain.ContestId(dto.ContestId),
+		Mode:        domain.ScoringMode(dto.Mode),
+		Problems:    dto.Problems,
+		Frozen:      dto.Frozen,
+		Rows:        make([]domain.StandingsRow, 0, len(dto.Rows)),
+		GeneratedAt: dto.GeneratedAt,
+	}
+
+	for _, rowDto := range dto.Rows {
+		row := domain.StandingsRow{
+			Rank:           rowDto.Rank,
+			UserId:         domain.UserId(rowDto.UserId),
+			TeamId:         (*domain.TeamId)(rowDto.TeamId),
+			Name:           rowDto.Name,
+			Solved:         rowDto.Solved,
+			PenaltyMinutes: rowDto.PenaltyMinutes,
+			Score:          rowDto.Score,
+			Cells:          make([]domain.StandingsCell, 0, len(rowDto.Cells)),
+		}
+
+		for _, cell := range rowDto.Cells {
+			row.Cells = append(row.Cells, domain.StandingsCell(cell))
+		}
+
+		standings.Rows = append(standings.Rows, row)
+	}
+
+	return standings, nil
+}
+
+func (r *Cache) InvalidateStandings(ctx context.Context, contestId domain.ContestId) error {
+	err := r.client.Del(ctx, standingsKey(contestId, false), standingsKey(contestId, true)).Err()
+	if err != nil {
+		return fmt.Errorf("could not invalidate standings: %w", err)
+	}
+
+	return nil
+}
+
+func standingsKey(contestId domain.ContestId, frozen bool) string {
+	view := "full"
+	if frozen {
+		view = "frozen"
+	}
+
+	return fmt.Sprintf("cplatform:standings:%d:%s", contestId, view)
+}
@@ -0,0 +1,184 @@
+package oidc
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// clockSkew tolerates small drift between our clock and provider clock
+const clockSkew = time.Minute
+
+type idTokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyId     string `json:"kid"`
+}
+
+type idTokenClaims struct {
+	Issuer            string       `json:"iss"`
+	Subject           string       `json:"sub"`
+	Audience          audience     `json:"aud"`
+	AuthorizedParty   string       `json:"azp"`
+	Expiry            int64        `json:"exp"`
+	Nonce             string       `json:"nonce"`
+	Email             string       `json:"email"`
+	EmailVerified     flexibleBool `json:"email_verified"`
+	Name              string       `json:"name"`
+	PreferredUsername string       `json:"preferred_username"`
+}
+
+// audience is either single string or array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+
+	*a = many
+
+	return nil
+}
+
+// flexibleBool accepts "true" string some providers send instead of boolean
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = flexibleBool(value)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	*b = text == "true"
+
+	return nil
+}
+
+func (p *Provider) verifyIdToken(ctx context.Context, token string, nonce string) (*idTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: id token is not signed jwt", infrastructure.ErrExternalLoginRejected)
+	}
+
+	var header idTokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed id token header: %s", infrastructure.ErrExternalLoginRejected, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed id token signature: %s", infrastructure.ErrExternalLoginRejected, err)
+	}
+
+	key, err := p.signingKey(ctx, header.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := verifySignature(header.Algorithm, key, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: %s", infrastructure.ErrExternalLoginRejected, err)
+	}
+
+	var claims idTokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed id token claims: %s", infrastructure.ErrExternalLoginRejected, err)
+	}
+
+	if err := p.validateClaims(&claims, nonce); err != nil {
+		return nil, fmt.Errorf("%w: %s", infrastructure.ErrExternalLoginRejected, err)
+	}
+
+	return &claims, nil
+}
+
+func (p *Provider) validateClaims(claims *idTokenClaims, nonce string) error {
+	if claims.Issuer != p.config.Issuer {
+		return fmt.Errorf("id token issued by %q", claims.Issuer)
+	}
+
+	if !slices.Contains(claims.Audience, p.config.ClientId) {
+		return fmt.Errorf("id token is not issued for client %q", p.config.ClientId)
+	}
+
+	if claims.AuthorizedParty != "" && claims.AuthorizedParty != p.config.ClientId {
+		return fmt.Errorf("id token is authorized for %q", claims.AuthorizedParty)
+	}
+
+	if !time.Now().Before(time.Unix(claims.Expiry, 0).Add(clockSkew)) {
+		return fmt.Errorf("id token expired")
+	}
+
+	// nonce binds token to login started by this user, so stolen tokens cannot be replayed
+	if claims.Nonce != nonce {
+		return fmt.Errorf("id token nonce mismatch")
+	}
+
+	if claims.Subject == "" {
+		return fmt.Errorf("id token has no subject")
+	}
+
+	return nil
+}
+
+// verifySignature accepts only asymmetric algorithms; none and HMAC would let anybody knowing client secret forge tokens
+func verifySignature(algorithm string, key crypto.PublicKey, digest []byte, signature []byte) error {
+	switch algorithm {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key does not match %s", algorithm)
+		}
+
+		if err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest, signature); err != nil {
+			return fmt.Errorf("invalid id token signature: %w", err)
+		}
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			return fmt.Errorf("key or signature does not match %s", algorithm)
+		}
+
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return fmt.Errorf("invalid id token signature")
+		}
+	default:
+		return fmt.Errorf("unsupported id token algorithm %q", algorithm)
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, target)
+}
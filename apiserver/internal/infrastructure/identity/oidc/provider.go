@@ -0,0 +1,229 @@
+package oidc
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxResponseSize bounds documents read from provider
+const maxResponseSize = 1 << 20
+
+// keysRefreshInterval limits refetching of keys when tokens come with unknown key id
+const keysRefreshInterval = time.Minute
+
+type Config struct {
+	// Name identifies provider in api paths and linked identities, so it must not change once users signed in
+	Name         string
+	Issuer       string
+	ClientId     string
+	ClientSecret string
+	RedirectUrl  string
+	Scopes       []string
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IdToken string `json:"id_token"`
+}
+
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Provider reads discovery document on first use, so server starts while provider is unreachable
+type Provider struct {
+	config Config
+	client *http.Client
+	logger *slog.Logger
+
+	mu            sync.Mutex
+	discovery     *discoveryDocument
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+func NewProvider(config Config, client *http.Client, logger *slog.Logger) *Provider {
+	return &Provider{
+		config: config,
+		client: client,
+		logger: logger,
+	}
+}
+
+func (p *Provider) AuthorizationUrl(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientId)
+	query.Set("redirect_uri", p.config.RedirectUrl)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*domain.ExternalIdentity, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectUrl)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.config.ClientId)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("fail create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	// public clients authenticate by code verifier alone
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientId), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	status, body, err := p.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fail redeem code: %w", err)
+	}
+
+	if status != http.StatusOK {
+		var tokenErr tokenErrorResponse
+		_ = json.Unmarshal(body, &tokenErr)
+
+		return nil, fmt.Errorf("%w: token endpoint responded %d %s %s", infrastructure.ErrExternalLoginRejected, status, tokenErr.Error, tokenErr.ErrorDescription)
+	}
+
+	var tokens tokenResponse
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf("%w: malformed token response: %s", infrastructure.ErrExternalLoginRejected, err)
+	}
+
+	if tokens.IdToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id token", infrastructure.ErrExternalLoginRejected)
+	}
+
+	claims, err := p.verifyIdToken(ctx, tokens.IdToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	name := claims.Name
+	if claims.PreferredUsername != "" {
+		name = claims.PreferredUsername
+	}
+
+	identity := &domain.ExternalIdentity{
+		Provider:      p.config.Name,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          name,
+	}
+
+	return identity, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var document discoveryDocument
+	err := p.getJson(ctx, strings.TrimSuffix(p.config.Issuer, "/")+"/.well-known/openid-configuration", &document)
+	if err != nil {
+		return nil, fmt.Errorf("fail discover %s provider: %w", p.config.Name, err)
+	}
+
+	// issuer is compared exactly, otherwise tokens of other tenant could be accepted
+	if document.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("discovery of %s provider returned issuer %q instead of %q", p.config.Name, document.Issuer, p.config.Issuer)
+	}
+
+	if document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" || document.JwksUri == "" {
+		return nil, fmt.Errorf("discovery of %s provider lacks endpoints", p.config.Name)
+	}
+
+	p.discovery = &document
+
+	return p.discovery, nil
+}
+
+func (p *Provider) getJson(ctx context.Context, address string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return fmt.Errorf("fail create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	status, body, err := p.do(req)
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("%s responded %d", address, status)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("malformed response of %s: %w", address, err)
+	}
+
+	return nil
+}
+
+func (p *Provider) do(req *http.Request) (int, []byte, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("fail request provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, nil, fmt.Errorf("fail read provider response: %w", err)
+	}
+
+	return resp.StatusCode, body, nil
+}
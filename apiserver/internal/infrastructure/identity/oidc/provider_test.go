@@ -0,0 +1,332 @@
+package oidc
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testClientId     = "cplatform"
+	testClientSecret = "client-secret"
+	testRedirectUrl  = "https://cplatform.test/auth/callback"
+	testKeyId        = "key-1"
+	testCode         = "auth-code"
+	testVerifier     = "verifier-with-enough-entropy-0123456789abcdef"
+	testNonce        = "nonce-1"
+)
+
+// fakeProvider is stand-in OIDC server issuing tokens for single authorization code
+type fakeProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	// issuer is reported by discovery, defaults to server address
+	issuer string
+	// claims are signed into id token returned by token endpoint
+	claims map[string]any
+	// signer overrides key signing id token
+	signer *rsa.PrivateKey
+
+	discoveries atomic.Int32
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+
+	f := &fakeProvider{key: generateKey(t)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", f.handleDiscovery)
+	mux.HandleFunc("GET /jwks", f.handleKeys)
+	mux.HandleFunc("POST /token", f.handleToken)
+
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	f.issuer = f.server.URL
+	f.claims = map[string]any{
+		"iss":                f.server.URL,
+		"sub":                "subject-1",
+		"aud":                testClientId,
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"nonce":              testNonce,
+		"email":              "user@example.com",
+		"email_verified":     "true",
+		"name":               "Full Name",
+		"preferred_username": "user",
+	}
+
+	return f
+}
+
+func (f *fakeProvider) provider() *Provider {
+	config := Config{
+		Name:         "fake",
+		Issuer:       f.server.URL,
+		ClientId:     testClientId,
+		ClientSecret: testClientSecret,
+		RedirectUrl:  testRedirectUrl,
+		Scopes:       []string{"openid", "email"},
+	}
+
+	return NewProvider(config, f.server.Client(), slog.New(slog.DiscardHandler))
+}
+
+func (f *fakeProvider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	f.discoveries.Add(1)
+
+	writeTestJson(w, http.StatusOK, map[string]string{
+		"issuer":                 f.issuer,
+		"authorization_endpoint": f.server.URL + "/authorize",
+		"token_endpoint":         f.server.URL + "/token",
+		"jwks_uri":               f.server.URL + "/jwks",
+	})
+}
+
+func (f *fakeProvider) handleKeys(w http.ResponseWriter, r *http.Request) {
+	writeTestJson(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testKeyId,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(f.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(f.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (f *fakeProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTestJson(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientId, clientSecret, _ := r.BasicAuth()
+	if clientId != testClientId || clientSecret != testClientSecret {
+		writeTestJson(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	// code was issued for challenge of testVerifier, so exchange must present that verifier
+	if r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("code") != testCode ||
+		r.PostForm.Get("redirect_uri") != testRedirectUrl ||
+		codeChallenge(r.PostForm.Get("code_verifier")) != codeChallenge(testVerifier) {
+		writeTestJson(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "code verifier mismatch"})
+		return
+	}
+
+	signer := f.key
+	if f.signer != nil {
+		signer = f.signer
+	}
+
+	writeTestJson(w, http.StatusOK, map[string]string{"id_token": signIdToken(f.claims, signer)})
+}
+
+func TestAuthorizationUrl(t *testing.T) {
+	fake := newFakeProvider(t)
+	provider := fake.provider()
+
+	for range 2 {
+		address, err := provider.AuthorizationUrl(context.Background(), "state-1", testNonce, codeChallenge(testVerifier))
+		if err != nil {
+			t.Fatalf("AuthorizationUrl: %v", err)
+		}
+
+		u, err := url.Parse(address)
+		if err != nil {
+			t.Fatalf("parse authorization url: %v", err)
+		}
+
+		if got, want := u.Scheme+"://"+u.Host+u.Path, fake.server.URL+"/authorize"; got != want {
+			t.Errorf("endpoint = %q, want %q", got, want)
+		}
+
+		want := map[string]string{
+			"response_type":         "code",
+			"client_id":             testClientId,
+			"redirect_uri":          testRedirectUrl,
+			"scope":                 "openid email",
+			"state":                 "state-1",
+			"nonce":                 testNonce,
+			"code_challenge":        codeChallenge(testVerifier),
+			"code_challenge_method": "S256",
+		}
+		for name, value := range want {
+			if got := u.Query().Get(name); got != value {
+				t.Errorf("%s = %q, want %q", name, got, value)
+			}
+		}
+	}
+
+	if got := fake.discoveries.Load(); got != 1 {
+		t.Errorf("discovery fetched %d times, want 1", got)
+	}
+}
+
+func TestDiscoveryRejectsForeignIssuer(t *testing.T) {
+	fake := newFakeProvider(t)
+	fake.issuer = "https://other-tenant.test"
+
+	_, err := fake.provider().AuthorizationUrl(context.Background(), "state-1", testNonce, codeChallenge(testVerifier))
+	if err == nil || !strings.Contains(err.Error(), "other-tenant") {
+		t.Fatalf("AuthorizationUrl error = %v, want issuer mismatch", err)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	fake := newFakeProvider(t)
+
+	identity, err := fake.provider().Exchange(context.Background(), testCode, testVerifier, testNonce)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	if identity.Provider != "fake" || identity.Subject != "subject-1" || identity.Email != "user@example.com" ||
+		!identity.EmailVerified || identity.Name != "user" {
+		t.Errorf("identity = %+v", identity)
+	}
+}
+
+func TestExchangeRejects(t *testing.T) {
+	otherKey := generateKey(t)
+
+	tests := []struct {
+		name     string
+		verifier string
+		nonce    string
+		prepare  func(f *fakeProvider)
+	}{
+		{
+			name:     "wrong code verifier",
+			verifier: "another-verifier-0123456789abcdef0123456789",
+		},
+		{
+			name:    "bad signature",
+			prepare: func(f *fakeProvider) { f.signer = otherKey },
+		},
+		{
+			name:    "wrong audience",
+			prepare: func(f *fakeProvider) { f.claims["aud"] = []string{"other-client"} },
+		},
+		{
+			name:    "foreign authorized party",
+			prepare: func(f *fakeProvider) { f.claims["azp"] = "other-client" },
+		},
+		{
+			name:    "wrong issuer",
+			prepare: func(f *fakeProvider) { f.claims["iss"] = "https://other-tenant.test" },
+		},
+		{
+			name:    "expired",
+			prepare: func(f *fakeProvider) { f.claims["exp"] = time.Now().Add(-clockSkew - time.Minute).Unix() },
+		},
+		{
+			name:  "nonce mismatch",
+			nonce: "nonce-of-other-login",
+		},
+		{
+			name:    "missing subject",
+			prepare: func(f *fakeProvider) { delete(f.claims, "sub") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeProvider(t)
+			if tt.prepare != nil {
+				tt.prepare(fake)
+			}
+
+			verifier := testVerifier
+			if tt.verifier != "" {
+				verifier = tt.verifier
+			}
+
+			nonce := testNonce
+			if tt.nonce != "" {
+				nonce = tt.nonce
+			}
+
+			identity, err := fake.provider().Exchange(context.Background(), testCode, verifier, nonce)
+			if !errors.Is(err, infrastructure.ErrExternalLoginRejected) {
+				t.Fatalf("Exchange = %+v, %v; want ErrExternalLoginRejected", identity, err)
+			}
+		})
+	}
+}
+
+func TestVerifyIdTokenRejectsSymmetricAlgorithms(t *testing.T) {
+	fake := newFakeProvider(t)
+
+	for _, algorithm := range []string{"none", "HS256"} {
+		t.Run(algorithm, func(t *testing.T) {
+			header := encodeTestSegment(map[string]string{"alg": algorithm, "kid": testKeyId})
+			token := header + "." + encodeTestSegment(fake.claims) + "."
+
+			_, err := fake.provider().verifyIdToken(context.Background(), token, testNonce)
+			if !errors.Is(err, infrastructure.ErrExternalLoginRejected) {
+				t.Fatalf("verifyIdToken error = %v, want ErrExternalLoginRejected", err)
+			}
+		})
+	}
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	return key
+}
+
+func signIdToken(claims map[string]any, key *rsa.PrivateKey) string {
+	signingInput := encodeTestSegment(map[string]string{"alg": "RS256", "kid": testKeyId}) + "." + encodeTestSegment(claims)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeTestSegment(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func codeChallenge(verifier string) string {
+	digest := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func writeTestJson(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"cplatform/internal/application/contracts/infrastructure"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+)
+
+// providerNamePattern keeps names usable in paths and env variable names
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+var ErrInvalidProvider = errors.New("invalid oidc provider")
+
+// Registry holds providers configured at startup
+type Registry struct {
+	providers map[string]*Provider
+}
+
+func NewRegistry(configs []Config, client *http.Client, logger *slog.Logger) (*Registry, error) {
+	providers := make(map[string]*Provider, len(configs))
+
+	for _, config := range configs {
+		if !providerNamePattern.MatchString(config.Name) {
+			return nil, fmt.Errorf("%w: name %q must match %s", ErrInvalidProvider, config.Name, providerNamePattern)
+		}
+
+		if _, ok := providers[config.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate name %q", ErrInvalidProvider, config.Name)
+		}
+
+		if config.Issuer == "" || config.ClientId == "" || config.RedirectUrl == "" {
+			return nil, fmt.Errorf("%w: %s needs issuer, client id and redirect url", ErrInvalidProvider, config.Name)
+		}
+
+		providers[config.Name] = NewProvider(config, client, logger)
+	}
+
+	return &Registry{providers: providers}, nil
+}
+
+func (r *Registry) GetProvider(name string) (infrastructure.IdentityProvider, bool) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, false
+	}
+
+	return provider, true
+}
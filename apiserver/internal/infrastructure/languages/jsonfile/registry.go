@@ -0,0 +1,126 @@
+package jsonfile
+
+import (
+	"cplatform/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+const defaultTimeMultiplier = 1.0
+const maxTimeMultiplier = 10.0
+
+// languageIdPattern must stay in sync with submission request validation
+var languageIdPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+_.-]{0,31}$`)
+
+var ErrInvalidLanguage = errors.New("invalid language")
+
+type languageDto struct {
+	Id             string   `json:"id"`
+	Name           string   `json:"name"`
+	SourceFile     string   `json:"source_file"`
+	CompileCommand []string `json:"compile"`
+	RunCommand     []string `json:"run"`
+	TimeMultiplier float64  `json:"time_multiplier"`
+}
+
+// Registry is immutable list of languages read from json array
+type Registry struct {
+	languages []*domain.Language
+	byId      map[string]*domain.Language
+}
+
+func LoadRegistry(path string) (*Registry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read languages file: %w", err)
+	}
+
+	var dtos []languageDto
+	if err := json.Unmarshal(content, &dtos); err != nil {
+		return nil, fmt.Errorf("cannot parse languages file %s: %w", path, err)
+	}
+
+	registry := &Registry{
+		languages: make([]*domain.Language, 0, len(dtos)),
+		byId:      make(map[string]*domain.Language, len(dtos)),
+	}
+
+	errs := make([]error, 0)
+	for i, dto := range dtos {
+		if dto.TimeMultiplier == 0 {
+			dto.TimeMultiplier = defaultTimeMultiplier
+		}
+
+		if err := validateLanguage(&dto); err != nil {
+			errs = append(errs, fmt.Errorf("language #%d: %w", i+1, err))
+			continue
+		}
+
+		if _, ok := registry.byId[dto.Id]; ok {
+			errs = append(errs, fmt.Errorf("%w: language #%d: duplicate id %q", ErrInvalidLanguage, i+1, dto.Id))
+			continue
+		}
+
+		language := &domain.Language{
+			Id:             dto.Id,
+			Name:           dto.Name,
+			SourceFile:     dto.SourceFile,
+			CompileCommand: dto.CompileCommand,
+			RunCommand:     dto.RunCommand,
+			TimeMultiplier: dto.TimeMultiplier,
+		}
+
+		registry.languages = append(registry.languages, language)
+		registry.byId[language.Id] = language
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return registry, nil
+}
+
+func (r *Registry) GetLanguage(id string) (*domain.Language, bool) {
+	language, ok := r.byId[id]
+	return language, ok
+}
+
+func (r *Registry) ListLanguages() []*domain.Language {
+	return r.languages
+}
+
+func validateLanguage(dto *languageDto) error {
+	errs := make([]error, 0)
+
+	if !languageIdPattern.MatchString(dto.Id) {
+		errs = append(errs, fmt.Errorf("%w: id %q must match %s", ErrInvalidLanguage, dto.Id, languageIdPattern))
+	}
+
+	if dto.Name == "" {
+		errs = append(errs, fmt.Errorf("%w: name is empty", ErrInvalidLanguage))
+	}
+
+	// source is written into judge work dir, so it must be a plain file name
+	if dto.SourceFile == "" || filepath.Base(dto.SourceFile) != dto.SourceFile || dto.SourceFile == "." || dto.SourceFile == ".." {
+		errs = append(errs, fmt.Errorf("%w: source file %q must be plain file name", ErrInvalidLanguage, dto.SourceFile))
+	}
+
+	if len(dto.RunCommand) == 0 || dto.RunCommand[0] == "" {
+		errs = append(errs, fmt.Errorf("%w: run command is empty", ErrInvalidLanguage))
+	}
+
+	if len(dto.CompileCommand) > 0 && dto.CompileCommand[0] == "" {
+		errs = append(errs, fmt.Errorf("%w: compile command has empty program", ErrInvalidLanguage))
+	}
+
+	if dto.TimeMultiplier < 0 || dto.TimeMultiplier > maxTimeMultiplier {
+		errs = append(errs, fmt.Errorf("%w: time multiplier must be in (0, %g]", ErrInvalidLanguage, maxTimeMultiplier))
+	}
+
+	return errors.Join(errs...)
+}
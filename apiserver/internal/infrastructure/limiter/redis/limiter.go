@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AttemptLimiter keeps failure counter expiring after policy window and separate lock key expiring with lockout
+type AttemptLimiter struct {
+	client *redis.Client
+	name   string
+	policy infrastructure.AttemptPolicy
+	logger *slog.Logger
+}
+
+// NewAttemptLimiter creates limiter; name separates keys of limiters sharing one redis
+func NewAttemptLimiter(client *redis.Client, name string, policy infrastructure.AttemptPolicy, logger *slog.Logger) *AttemptLimiter {
+	return &AttemptLimiter{
+		client: client,
+		name:   name,
+		policy: policy,
+		logger: logger,
+	}
+}
+
+func (l *AttemptLimiter) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := l.client.PTTL(ctx, l.lockKey(key)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("could not get lockout: %w", err)
+	}
+
+	// negative ttl means key is missing
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (l *AttemptLimiter) RegisterFailure(ctx context.Context, key string) (time.Duration, error) {
+	var incr *redis.IntCmd
+
+	_, err := l.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, l.failuresKey(key))
+		// window starts with first failure and is not prolonged by further ones
+		pipe.ExpireNX(ctx, l.failuresKey(key), l.policy.Window)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not count failure: %w", err)
+	}
+
+	lockout := l.lockout(incr.Val())
+	if lockout == 0 {
+		return 0, nil
+	}
+
+	err = l.client.Set(ctx, l.lockKey(key), incr.Val(), lockout).Err()
+	if err != nil {
+		return 0, fmt.Errorf("could not lock out: %w", err)
+	}
+
+	return lockout, nil
+}
+
+func (l *AttemptLimiter) Reset(ctx context.Context, key string) error {
+	err := l.client.Del(ctx, l.failuresKey(key), l.lockKey(key)).Err()
+	if err != nil {
+		return fmt.Errorf("could not reset failures: %w", err)
+	}
+
+	return nil
+}
+
+func (l *AttemptLimiter) lockout(failures int64) time.Duration {
+	over := failures - int64(l.policy.MaxAttempts)
+	if over < 0 {
+		return 0
+	}
+
+	lockout := l.policy.BaseLockout
+	for range over {
+		lockout *= 2
+		if lockout >= l.policy.MaxLockout {
+			return l.policy.MaxLockout
+		}
+	}
+
+	return min(lockout, l.policy.MaxLockout)
+}
+
+func (l *AttemptLimiter) failuresKey(key string) string {
+	return fmt.Sprintf("cplatform:limiter:%s:failures:%s", l.name, key)
+}
+
+func (l *AttemptLimiter) lockKey(key string) string {
+	return fmt.Sprintf("cplatform:limiter:%s:lock:%s", l.name, key)
+}
@@ -0,0 +1,23 @@
+package logmail
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"log/slog"
+)
+
+// Mailer writes mails to log instead of delivering them; it is meant for local development
+type Mailer struct {
+	logger *slog.Logger
+}
+
+func NewMailer(logger *slog.Logger) *Mailer {
+	return &Mailer{
+		logger: logger,
+	}
+}
+
+func (m *Mailer) Send(ctx context.Context, mail *infrastructure.Mail) error {
+	m.logger.InfoContext(ctx, "mail sent", "to", mail.To, "subject", mail.Subject, "body", mail.Body)
+	return nil
+}
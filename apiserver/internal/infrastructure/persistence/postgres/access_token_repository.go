@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const accessTokenColumns = "id, user_id, name, scopes, expires_at, created_at"
+
+type accessTokenRepository struct {
+	logger *slog.Logger
+	uow    *UnitOfWork
+}
+
+func newAccessTokenRepository(uow *UnitOfWork, logger *slog.Logger) *accessTokenRepository {
+	return &accessTokenRepository{
+		logger: logger,
+		uow:    uow,
+	}
+}
+
+func (repo *accessTokenRepository) AddAccessToken(ctx context.Context, token *domain.AccessToken, tokenHash string) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	scopes := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	err = tx.QueryRow(ctx,
+		"INSERT INTO public.access_tokens (user_id, name, token_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at",
+		token.UserId,
+		token.Name,
+		tokenHash,
+		scopes,
+		token.ExpiresAt,
+	).Scan(&token.Id, &token.CreatedAt)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.ConstraintName {
+			case "c_unique_access_token_name":
+				err = fmt.Errorf("%w: %s", infrastructure.ErrDuplicateAccessToken, err.Error())
+			case "fk_access_token_user":
+				err = fmt.Errorf("%w: %s", infrastructure.ErrUserNotFound, err.Error())
+			}
+		}
+
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *accessTokenRepository) GetAccessTokenByHash(ctx context.Context, tokenHash string) (*domain.AccessToken, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	token, err := scanAccessToken(tx.QueryRow(ctx,
+		"SELECT "+accessTokenColumns+" FROM public.access_tokens WHERE token_hash = $1",
+		tokenHash,
+	))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no token with given hash", infrastructure.ErrAccessTokenNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get access token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (repo *accessTokenRepository) ListAccessTokens(ctx context.Context, userId domain.UserId) ([]*domain.AccessToken, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT "+accessTokenColumns+" FROM public.access_tokens WHERE user_id = $1 ORDER BY created_at, id",
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := make([]*domain.AccessToken, 0)
+	for rows.Next() {
+		token, err := scanAccessToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("fail scan access token: %w", err)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read access tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (repo *accessTokenRepository) DeleteAccessToken(ctx context.Context, userId domain.UserId, id domain.AccessTokenId) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, "DELETE FROM public.access_tokens WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: user %d has no token %d", infrastructure.ErrAccessTokenNotFound, userId, id)
+	}
+
+	return nil
+}
+
+func scanAccessToken(row pgx.Row) (*domain.AccessToken, error) {
+	var dto AccessTokenDto
+	err := row.Scan(&dto.Id, &dto.UserId, &dto.Name, &dto.Scopes, &dto.ExpiresAt, &dto.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &domain.AccessToken{
+		Id:        domain.AccessTokenId(dto.Id),
+		UserId:    domain.UserId(dto.UserId),
+		Name:      dto.Name,
+		Scopes:    make([]domain.TokenScope, 0, len(dto.Scopes)),
+		ExpiresAt: dto.ExpiresAt,
+		CreatedAt: dto.CreatedAt,
+	}
+
+	for _, scope := range dto.Scopes {
+		token.Scopes = append(token.Scopes, domain.TokenScope(scope))
+	}
+
+	return token, nil
+}
@@ -0,0 +1,533 @@
+package postgres
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const contestColumns = "id, title, description, start_time, duration_seconds, freeze_time, visibility, scoring_mode, registration_deadline, capacity, invite_code, require_verified_email, owner_id"
+
+type contestRepository struct {
+	logger *slog.Logger
+	uow    *UnitOfWork
+}
+
+func newContestRepository(uow *UnitOfWork, logger *slog.Logger) *contestRepository {
+	return &contestRepository{
+		logger: logger,
+		uow:    uow,
+	}
+}
+
+func (repo *contestRepository) AddContest(ctx context.Context, contest *domain.Contest) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var id domain.ContestId
+	err = tx.QueryRow(ctx,
+		"INSERT INTO public.contests (title, description, start_time, duration_seconds, freeze_time, visibility, scoring_mode, registration_deadline, capacity, invite_code, require_verified_email, owner_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id",
+		contest.Title,
+		contest.Description,
+		contest.StartTime,
+		int64(contest.Duration/time.Second),
+		contest.FreezeTime,
+		string(contest.Visibility),
+		string(contest.ScoringMode),
+		contest.RegistrationDeadline,
+		contest.Capacity,
+		contest.InviteCode,
+		contest.RequireVerifiedEmail,
+		contest.OwnerId,
+	).Scan(&id)
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	contest.Id = id
+
+	return nil
+}
+
+func (repo *contestRepository) GetContestById(ctx context.Context, id domain.ContestId) (*domain.Contest, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	row := tx.QueryRow(ctx, "SELECT "+contestColumns+" FROM public.contests WHERE id = $1", id)
+
+	contest, err := scanContest(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no contest with id %d", infrastructure.ErrContestNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get contest by id: %w", err)
+	}
+
+	return contest, nil
+}
+
+func (repo *contestRepository) GetContestByIdForUpdate(ctx context.Context, id domain.ContestId) (*domain.Contest, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	row := tx.QueryRow(ctx, "SELECT "+contestColumns+" FROM public.contests WHERE id = $1 FOR UPDATE", id)
+
+	contest, err := scanContest(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no contest with id %d", infrastructure.ErrContestNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail lock contest by id: %w", err)
+	}
+
+	return contest, nil
+}
+
+func (repo *contestRepository) UpdateContest(ctx context.Context, contest *domain.Contest) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx,
+		"UPDATE public.contests SET title = $2, description = $3, start_time = $4, duration_seconds = $5, freeze_time = $6, visibility = $7, scoring_mode = $8, registration_deadline = $9, capacity = $10, invite_code = $11, require_verified_email = $12 WHERE id = $1",
+		contest.Id,
+		contest.Title,
+		contest.Description,
+		contest.StartTime,
+		int64(contest.Duration/time.Second),
+		contest.FreezeTime,
+		string(contest.Visibility),
+		string(contest.ScoringMode),
+		contest.RegistrationDeadline,
+		contest.Capacity,
+		contest.InviteCode,
+		contest.RequireVerifiedEmail,
+	)
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: no contest with id %d", infrastructure.ErrContestNotFound, contest.Id)
+	}
+
+	return nil
+}
+
+func (repo *contestRepository) DeleteContest(ctx context.Context, id domain.ContestId) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	newCtx := context.WithoutCancel(ctx)
+	_, err = tx.Exec(newCtx, "DELETE FROM public.contests WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *contestRepository) ListContestsVisibleTo(ctx context.Context, userId domain.UserId, offset int, limit int) ([]*domain.Contest, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT "+contestColumns+" FROM public.contests WHERE visibility = 'public' OR owner_id = $1 OR id IN (SELECT contest_id FROM public.contest_participants WHERE user_id = $1) OR id IN (SELECT contest_id FROM public.contest_roles WHERE user_id = $1) ORDER BY start_time DESC, id DESC OFFSET $2 LIMIT $3",
+		userId,
+		offset,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+	defer rows.Close()
+
+	contests := make([]*domain.Contest, 0)
+	for rows.Next() {
+		contest, err := scanContest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("fail scan contest: %w", err)
+		}
+
+		contests = append(contests, contest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read contests: %w", err)
+	}
+
+	return contests, nil
+}
+
+func scanContest(row pgx.Row) (*domain.Contest, error) {
+	var dto ContestDto
+	err := row.Scan(
+		&dto.Id,
+		&dto.Title,
+		&dto.Description,
+		&dto.StartTime,
+		&dto.DurationSeconds,
+		&dto.FreezeTime,
+		&dto.Visibility,
+		&dto.ScoringMode,
+		&dto.RegDeadline,
+		&dto.Capacity,
+		&dto.InviteCode,
+		&dto.RequireVerified,
+		&dto.OwnerId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	contest := &domain.Contest{
+		Id:                   domain.ContestId(dto.Id),
+		Title:                dto.Title,
+		Description:          dto.Description,
+		StartTime:            dto.StartTime,
+		Duration:             time.Duration(dto.DurationSeconds) * time.Second,
+		FreezeTime:           dto.FreezeTime,
+		Visibility:           domain.ContestVisibility(dto.Visibility),
+		ScoringMode:          domain.ScoringMode(dto.ScoringMode),
+		RegistrationDeadline: dto.RegDeadline,
+		Capacity:             dto.Capacity,
+		InviteCode:           dto.InviteCode,
+		RequireVerifiedEmail: dto.RequireVerified,
+		OwnerId:              domain.UserId(dto.OwnerId),
+	}
+
+	return contest, nil
+}
+
+func (repo *contestRepository) SetContestProblem(ctx context.Context, problem *domain.ContestProblem) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		"INSERT INTO public.contest_problems (contest_id, label, problem_id, revision) VALUES ($1, $2, $3, $4) ON CONFLICT (contest_id, label) DO UPDATE SET problem_id = EXCLUDED.problem_id, revision = EXCLUDED.revision",
+		problem.ContestId,
+		problem.Label,
+		problem.ProblemId,
+		problem.Revision,
+	)
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *contestRepository) RemoveContestProblem(ctx context.Context, contestId domain.ContestId, label string) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, "DELETE FROM public.contest_problems WHERE contest_id = $1 AND label = $2", contestId, label)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: no problem %q in contest %d", infrastructure.ErrContestProblemNotFound, label, contestId)
+	}
+
+	return nil
+}
+
+func (repo *contestRepository) GetContestProblem(ctx context.Context, contestId domain.ContestId, label string) (*domain.ContestProblem, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	row := tx.QueryRow(ctx,
+		"SELECT cp.contest_id, cp.label, cp.problem_id, cp.revision, r.title FROM public.contest_problems cp JOIN public.problem_revisions r ON r.problem_id = cp.problem_id AND r.revision = cp.revision WHERE cp.contest_id = $1 AND cp.label = $2",
+		contestId,
+		label,
+	)
+
+	problem, err := scanContestProblem(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no problem %q in contest %d", infrastructure.ErrContestProblemNotFound, label, contestId)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get contest problem: %w", err)
+	}
+
+	return problem, nil
+}
+
+func (repo *contestRepository) ListContestProblems(ctx context.Context, contestId domain.ContestId) ([]*domain.ContestProblem, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT cp.contest_id, cp.label, cp.problem_id, cp.revision, r.title FROM public.contest_problems cp JOIN public.problem_revisions r ON r.problem_id = cp.problem_id AND r.revision = cp.revision WHERE cp.contest_id = $1 ORDER BY cp.label",
+		contestId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+	defer rows.Close()
+
+	problems := make([]*domain.ContestProblem, 0)
+	for rows.Next() {
+		problem, err := scanContestProblem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("fail scan contest problem: %w", err)
+		}
+
+		problems = append(problems, problem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read contest problems: %w", err)
+	}
+
+	return problems, nil
+}
+
+func scanContestProblem(row pgx.Row) (*domain.ContestProblem, error) {
+	var dto ContestProblemDto
+	err := row.Scan(&dto.ContestId, &dto.Label, &dto.ProblemId, &dto.Revision, &dto.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	problem := &domain.ContestProblem{
+		ContestId: domain.ContestId(dto.ContestId),
+		Label:     dto.Label,
+		ProblemId: domain.ProblemId(dto.ProblemId),
+		Revision:  dto.Revision,
+		Title:     dto.Title,
+	}
+
+	return problem, nil
+}
+
+const participantSelect = "SELECT p.contest_id, p.user_id, u.name, p.team_id, t.name AS team_name, p.registered_at FROM public.contest_participants p JOIN public.users u ON u.id = p.user_id LEFT JOIN public.teams t ON t.id = p.team_id"
+
+func (repo *contestRepository) AddParticipant(ctx context.Context, participant *domain.ContestParticipant) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var registeredAt time.Time
+	err = tx.QueryRow(ctx,
+		"INSERT INTO public.contest_participants (contest_id, user_id, team_id) VALUES ($1, $2, $3) ON CONFLICT (contest_id, user_id) DO NOTHING RETURNING registered_at",
+		participant.ContestId,
+		participant.UserId,
+		participant.TeamId,
+	).Scan(&registeredAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: user %d in contest %d", infrastructure.ErrDuplicateParticipant, participant.UserId, participant.ContestId)
+	}
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	participant.RegisteredAt = registeredAt
+
+	return nil
+}
+
+func (repo *contestRepository) RemoveParticipant(ctx context.Context, contestId domain.ContestId, userId domain.UserId) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, "DELETE FROM public.contest_participants WHERE contest_id = $1 AND user_id = $2", contestId, userId)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: user %d in contest %d", infrastructure.ErrParticipantNotFound, userId, contestId)
+	}
+
+	return nil
+}
+
+func (repo *contestRepository) RemoveTeamParticipants(ctx context.Context, contestId domain.ContestId, teamId domain.TeamId) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, "DELETE FROM public.contest_participants WHERE contest_id = $1 AND team_id = $2", contestId, teamId)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: team %d in contest %d", infrastructure.ErrParticipantNotFound, teamId, contestId)
+	}
+
+	return nil
+}
+
+func (repo *contestRepository) GetParticipant(ctx context.Context, contestId domain.ContestId, userId domain.UserId) (*domain.ContestParticipant, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	row := tx.QueryRow(ctx, participantSelect+" WHERE p.contest_id = $1 AND p.user_id = $2", contestId, userId)
+
+	participant, err := scanParticipant(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: user %d in contest %d", infrastructure.ErrParticipantNotFound, userId, contestId)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get participant: %w", err)
+	}
+
+	return participant, nil
+}
+
+func (repo *contestRepository) IsParticipant(ctx context.Context, contestId domain.ContestId, userId domain.UserId) (bool, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return false, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM public.contest_participants WHERE contest_id = $1 AND user_id = $2)",
+		contestId,
+		userId,
+	).Scan(&exists)
+
+	if err != nil {
+		return false, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (repo *contestRepository) CountContestants(ctx context.Context, contestId domain.ContestId) (int, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var count int
+	err = tx.QueryRow(ctx,
+		"SELECT count(*) FILTER (WHERE team_id IS NULL) + count(DISTINCT team_id) FROM public.contest_participants WHERE contest_id = $1",
+		contestId,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return count, nil
+}
+
+func (repo *contestRepository) ListParticipants(ctx context.Context, contestId domain.ContestId, offset int, limit int) ([]*domain.ContestParticipant, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		participantSelect+" WHERE p.contest_id = $1 ORDER BY p.registered_at, p.user_id OFFSET $2 LIMIT $3",
+		contestId,
+		offset,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return collectParticipants(rows)
+}
+
+func (repo *contestRepository) ListAllParticipants(ctx context.Context, contestId domain.ContestId) ([]*domain.ContestParticipant, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, participantSelect+" WHERE p.contest_id = $1", contestId)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return collectParticipants(rows)
+}
+
+func collectParticipants(rows pgx.Rows) ([]*domain.ContestParticipant, error) {
+	defer rows.Close()
+
+	participants := make([]*domain.ContestParticipant, 0)
+	for rows.Next() {
+		participant, err := scanParticipant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("fail scan participant: %w", err)
+		}
+
+		participants = append(participants, participant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read participants: %w", err)
+	}
+
+	return participants, nil
+}
+
+func scanParticipant(row pgx.Row) (*domain.ContestParticipant, error) {
+	var dto ContestParticipantDto
+	err := row.Scan(&dto.ContestId, &dto.UserId, &dto.UserName, &dto.TeamId, &dto.TeamName, &dto.RegisteredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	participant := &domain.ContestParticipant{
+		ContestId:    domain.ContestId(dto.ContestId),
+		UserId:       domain.UserId(dto.UserId),
+		UserName:     dto.UserName,
+		TeamName:     valueOrZero(dto.TeamName),
+		RegisteredAt: dto.RegisteredAt,
+	}
+
+	if dto.TeamId != nil {
+		teamId := domain.TeamId(*dto.TeamId)
+		participant.TeamId = &teamId
+	}
+
+	return participant, nil
+}
@@ -1,9 +1,149 @@
 package postgres
 
+import "time"
+
 type UserDto struct {
-	Id           int64  `db:"id"`
-	Name         string `db:"name"`
-	Email        string `db:"email"`
-	PasswordHash []byte `db:"password_hash"`
-	Salt         []byte `db:"salt"`
+	Id            int64  `db:"id"`
+	Name          string `db:"name"`
+	Email         string `db:"email"`
+	PasswordHash  []byte `db:"password_hash"`
+	Salt          []byte `db:"salt"`
+	Country       string `db:"country"`
+	Organization  string `db:"organization"`
+	Bio           string `db:"bio"`
+	EmailVerified bool   `db:"email_verified"`
+}
+
+type ContestDto struct {
+	Id              int64      `db:"id"`
+	Title           string     `db:"title"`
+	Description     string     `db:"description"`
+	StartTime       time.Time  `db:"start_time"`
+	DurationSeconds int64      `db:"duration_seconds"`
+	FreezeTime      *time.Time `db:"freeze_time"`
+	Visibility      string     `db:"visibility"`
+	ScoringMode     string     `db:"scoring_mode"`
+	RegDeadline     *time.Time `db:"registration_deadline"`
+	Capacity        int        `db:"capacity"`
+	InviteCode      string     `db:"invite_code"`
+	RequireVerified bool       `db:"require_verified_email"`
+	OwnerId         int64      `db:"owner_id"`
+}
+
+type ContestParticipantDto struct {
+	ContestId    int64     `db:"contest_id"`
+	UserId       int64     `db:"user_id"`
+	UserName     string    `db:"name"`
+	TeamId       *int64    `db:"team_id"`
+	TeamName     *string   `db:"team_name"`
+	RegisteredAt time.Time `db:"registered_at"`
+}
+
+type TeamDto struct {
+	Id          int64     `db:"id"`
+	Name        string    `db:"name"`
+	CaptainId   int64     `db:"captain_id"`
+	InviteToken string    `db:"invite_token"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type TeamMemberDto struct {
+	UserId   int64     `db:"user_id"`
+	Name     string    `db:"name"`
+	JoinedAt time.Time `db:"joined_at"`
+}
+
+type ProblemDto struct {
+	Id             int64     `db:"id"`
+	AuthorId       int64     `db:"author_id"`
+	LatestRevision int       `db:"latest_revision"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type ProblemRevisionDto struct {
+	ProblemId     int64     `db:"problem_id"`
+	Revision      int       `db:"revision"`
+	Title         string    `db:"title"`
+	Statement     string    `db:"statement"`
+	InputSpec     string    `db:"input_spec"`
+	OutputSpec    string    `db:"output_spec"`
+	TimeLimitMs   int64     `db:"time_limit_ms"`
+	MemoryLimitKb int64     `db:"memory_limit_kb"`
+	Tags          []string  `db:"tags"`
+	Checker       string    `db:"checker"`
+	CheckerAbsEps float64   `db:"checker_abs_epsilon"`
+	CheckerRelEps float64   `db:"checker_rel_epsilon"`
+	TestSetId     *int64    `db:"test_set_id"`
+	AuthorId      int64     `db:"author_id"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+type ContestProblemDto struct {
+	ContestId int64  `db:"contest_id"`
+	Label     string `db:"label"`
+	ProblemId int64  `db:"problem_id"`
+	Revision  int    `db:"revision"`
+	Title     string `db:"title"`
+}
+
+type TestSetDto struct {
+	Id            int64     `db:"id"`
+	ProblemId     int64     `db:"problem_id"`
+	CheckerSource string    `db:"checker_source"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+type TestDto struct {
+	Ordinal    int   `db:"ordinal"`
+	Subtask    int   `db:"subtask"`
+	IsSample   bool  `db:"is_sample"`
+	InputSize  int64 `db:"input_size"`
+	AnswerSize int64 `db:"answer_size"`
+}
+
+type SubmissionDto struct {
+	Id              int64      `db:"id"`
+	ContestId       int64      `db:"contest_id"`
+	ProblemLabel    string     `db:"problem_label"`
+	ProblemId       int64      `db:"problem_id"`
+	ProblemRevision int        `db:"problem_revision"`
+	UserId          int64      `db:"user_id"`
+	TeamId          *int64     `db:"team_id"`
+	LanguageId      string     `db:"language_id"`
+	Source          string     `db:"source"`
+	State           string     `db:"state"`
+	SubmittedAt     time.Time  `db:"submitted_at"`
+	Verdict         *string    `db:"verdict"`
+	Score           *int       `db:"score"`
+	SubtaskScores   []int      `db:"subtask_scores"`
+	FailedTest      *int       `db:"failed_test"`
+	TimeMs          *int64     `db:"time_ms"`
+	MemoryKb        *int64     `db:"memory_kb"`
+	JudgeLog        *string    `db:"judge_log"`
+	JudgedAt        *time.Time `db:"judged_at"`
+}
+
+type RoleGrantDto struct {
+	UserId    int64     `db:"user_id"`
+	Role      string    `db:"role"`
+	ContestId *int64    `db:"contest_id"`
+	GrantedBy int64     `db:"granted_by"`
+	GrantedAt time.Time `db:"granted_at"`
+}
+
+type UserIdentityDto struct {
+	Provider  string    `db:"provider"`
+	Subject   string    `db:"subject"`
+	UserId    int64     `db:"user_id"`
+	Email     string    `db:"email"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type AccessTokenDto struct {
+	Id        int64      `db:"id"`
+	UserId    int64      `db:"user_id"`
+	Name      string     `db:"name"`
+	Scopes    []string   `db:"scopes"`
+	ExpiresAt *time.Time `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
 }
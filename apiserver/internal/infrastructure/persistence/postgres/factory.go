@@ -29,11 +29,23 @@ func (f *UnitOfWorkFactory) Create(ctx context.Context) infrastructure.UnitOfWor
 }
 
 func (f *UnitOfWorkFactory) CreateWithIsolationLevel(ctx context.Context, txIsoLevel pgx.TxIsoLevel) infrastructure.UnitOfWork {
-	conn, err := f.pool.Acquire(ctx)
+	uow, err := f.tryCreate(ctx, txIsoLevel)
 	if err != nil {
-		err = fmt.Errorf("failed to create unit of work: %w", err)
 		panic(err)
 	}
 
-	return newUnitOfWorkWithIsoLevel(conn, f.logger, txIsoLevel)
+	return uow
+}
+
+func (f *UnitOfWorkFactory) TryCreate(ctx context.Context) (infrastructure.UnitOfWork, error) {
+	return f.tryCreate(ctx, defaultIsoLevel)
+}
+
+func (f *UnitOfWorkFactory) tryCreate(ctx context.Context, txIsoLevel pgx.TxIsoLevel) (infrastructure.UnitOfWork, error) {
+	conn, err := f.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unit of work: %w", err)
+	}
+
+	return newUnitOfWorkWithIsoLevel(conn, f.logger, txIsoLevel), nil
 }
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type identityRepository struct {
+	logger *slog.Logger
+	uow    *UnitOfWork
+}
+
+func newIdentityRepository(uow *UnitOfWork, logger *slog.Logger) *identityRepository {
+	return &identityRepository{
+		logger: logger,
+		uow:    uow,
+	}
+}
+
+func (repo *identityRepository) GetIdentity(ctx context.Context, provider string, subject string) (*domain.UserIdentity, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var dto UserIdentityDto
+	err = tx.QueryRow(ctx,
+		"SELECT provider, subject, user_id, email, created_at FROM public.user_identities WHERE provider = $1 AND subject = $2",
+		provider,
+		subject,
+	).Scan(&dto.Provider, &dto.Subject, &dto.UserId, &dto.Email, &dto.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no %s identity %s", infrastructure.ErrIdentityNotFound, provider, subject)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get identity: %w", err)
+	}
+
+	identity := &domain.UserIdentity{
+		Provider:  dto.Provider,
+		Subject:   dto.Subject,
+		UserId:    domain.UserId(dto.UserId),
+		Email:     dto.Email,
+		CreatedAt: dto.CreatedAt,
+	}
+
+	return identity, nil
+}
+
+func (repo *identityRepository) AddIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	err = tx.QueryRow(ctx,
+		"INSERT INTO public.user_identities (provider, subject, user_id, email) VALUES ($1, $2, $3, $4) RETURNING created_at",
+		identity.Provider,
+		identity.Subject,
+		identity.UserId,
+		identity.Email,
+	).Scan(&identity.CreatedAt)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.ConstraintName {
+			case "user_identities_pkey":
+				err = fmt.Errorf("%w: %s", infrastructure.ErrDuplicateIdentity, err.Error())
+			case "fk_user_identity_user":
+				err = fmt.Errorf("%w: %s", infrastructure.ErrUserNotFound, err.Error())
+			}
+		}
+
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package migrations
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// fileNamePattern is like 0002_add_user_bio.up.sql; version is number ordering migrations
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var ErrInvalidMigration = errors.New("invalid migration")
+
+// Migration runs in single transaction together with its bookkeeping, so statements that cannot run in transaction are not supported
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	// Down is empty for irreversible migrations
+	Down string
+	// Checksum is hex sha256 of Up; it is recorded on apply, so editing applied migration is noticed
+	Checksum string
+}
+
+// Load returns embedded migrations ordered by version
+func Load() ([]*Migration, error) {
+	return load(files)
+}
+
+func load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("fail read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: file name %q must match %s", ErrInvalidMigration, entry.Name(), fileNamePattern)
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: %q has bad version", ErrInvalidMigration, entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, "sql/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("fail read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %s and %s", ErrInvalidMigration, version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("%w: %d_%s has no up script", ErrInvalidMigration, migration.Version, migration.Name)
+		}
+
+		checksum := sha256.Sum256([]byte(migration.Up))
+		migration.Checksum = hex.EncodeToString(checksum[:])
+
+		migrations = append(migrations, migration)
+	}
+
+	slices.SortFunc(migrations, func(a, b *Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
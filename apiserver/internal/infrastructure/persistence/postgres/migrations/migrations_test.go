@@ -0,0 +1,197 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func sqlFiles(files map[string]string) fstest.MapFS {
+	fsys := make(fstest.MapFS, len(files))
+	for name, content := range files {
+		fsys["sql/"+name] = &fstest.MapFile{Data: []byte(content)}
+	}
+
+	return fsys
+}
+
+func checksumOf(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := load(sqlFiles(map[string]string{
+		"10_add_index.up.sql":      "CREATE INDEX i;",
+		"2_add_column.up.sql":      "ALTER TABLE t ADD c int;",
+		"2_add_column.down.sql":    "ALTER TABLE t DROP c;",
+		"0001_initial.up.sql":      "CREATE TABLE t ();",
+		"0001_initial.down.sql":    "DROP TABLE t;",
+		"0003_backfill.up.sql":     "UPDATE t SET c = 0;",
+		"0004_rename_col.up.sql":   "ALTER TABLE t RENAME c TO d;",
+		"0004_rename_col.down.sql": "ALTER TABLE t RENAME d TO c;",
+	}))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	want := []struct {
+		version int64
+		name    string
+		up      string
+		down    string
+	}{
+		{1, "initial", "CREATE TABLE t ();", "DROP TABLE t;"},
+		{2, "add_column", "ALTER TABLE t ADD c int;", "ALTER TABLE t DROP c;"},
+		{3, "backfill", "UPDATE t SET c = 0;", ""},
+		{4, "rename_col", "ALTER TABLE t RENAME c TO d;", "ALTER TABLE t RENAME d TO c;"},
+		{10, "add_index", "CREATE INDEX i;", ""},
+	}
+
+	if len(migrations) != len(want) {
+		t.Fatalf("loaded %d migrations, want %d", len(migrations), len(want))
+	}
+
+	for i, w := range want {
+		got := migrations[i]
+		if got.Version != w.version || got.Name != w.name || got.Up != w.up || got.Down != w.down {
+			t.Errorf("migration %d = %+v, want %+v", i, got, w)
+		}
+
+		if got.Checksum != checksumOf(w.up) {
+			t.Errorf("migration %d checksum = %s, want sha256 of up script", i, got.Checksum)
+		}
+	}
+}
+
+func TestLoadChecksumIgnoresDown(t *testing.T) {
+	withDown, err := load(sqlFiles(map[string]string{
+		"0001_initial.up.sql":   "CREATE TABLE t ();",
+		"0001_initial.down.sql": "DROP TABLE t;",
+	}))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	withoutDown, err := load(sqlFiles(map[string]string{
+		"0001_initial.up.sql": "CREATE TABLE t ();",
+	}))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if withDown[0].Checksum != withoutDown[0].Checksum {
+		t.Errorf("checksum depends on down script")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "bad file name",
+			files: map[string]string{"0001-initial.up.sql": "SELECT 1;"},
+		},
+		{
+			name:  "upper case name",
+			files: map[string]string{"0001_Initial.up.sql": "SELECT 1;"},
+		},
+		{
+			name:  "zero version",
+			files: map[string]string{"0000_initial.up.sql": "SELECT 1;"},
+		},
+		{
+			name: "version reused",
+			files: map[string]string{
+				"0001_initial.up.sql": "SELECT 1;",
+				"0001_other.up.sql":   "SELECT 2;",
+			},
+		},
+		{
+			name:  "down without up",
+			files: map[string]string{"0001_initial.down.sql": "SELECT 1;"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := load(sqlFiles(tt.files)); !errors.Is(err, ErrInvalidMigration) {
+				t.Errorf("load error = %v, want ErrInvalidMigration", err)
+			}
+		})
+	}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if len(migrations) == 0 || migrations[0].Version != 1 {
+		t.Fatalf("embedded migrations must start with version 1")
+	}
+
+	for i, migration := range migrations {
+		if i > 0 && migration.Version <= migrations[i-1].Version {
+			t.Errorf("migration %d_%s is out of order", migration.Version, migration.Name)
+		}
+
+		if migration.Checksum != checksumOf(migration.Up) {
+			t.Errorf("migration %d_%s has wrong checksum", migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	migrations := []*Migration{
+		{Version: 1, Name: "initial", Up: "CREATE TABLE t ();", Checksum: checksumOf("CREATE TABLE t ();")},
+		{Version: 2, Name: "add_column", Up: "ALTER TABLE t ADD c int;", Checksum: checksumOf("ALTER TABLE t ADD c int;")},
+	}
+
+	recordedAs := func(script string) record {
+		checksum := checksumOf(script)
+		return record{Name: "initial", AppliedAt: time.Now(), Checksum: &checksum}
+	}
+
+	tests := []struct {
+		name     string
+		recorded map[int64]record
+		wantErr  error
+	}{
+		{
+			name:     "nothing applied",
+			recorded: map[int64]record{},
+		},
+		{
+			name:     "unchanged",
+			recorded: map[int64]record{1: recordedAs("CREATE TABLE t ();")},
+		},
+		{
+			name:     "recorded before checksums",
+			recorded: map[int64]record{1: {Name: "initial", AppliedAt: time.Now()}},
+		},
+		{
+			name:     "unknown version",
+			recorded: map[int64]record{1: recordedAs("CREATE TABLE t ();"), 7: recordedAs("DROP TABLE t;")},
+		},
+		{
+			name:     "modified",
+			recorded: map[int64]record{1: recordedAs("CREATE TABLE t (id int);")},
+			wantErr:  ErrChecksumMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyChecksums(migrations, tt.recorded); !errors.Is(err, tt.wantErr) {
+				t.Errorf("verifyChecksums error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
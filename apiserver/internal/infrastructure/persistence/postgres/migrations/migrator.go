@@ -0,0 +1,399 @@
+package migrations
+
+import (
+	"cmp"
+	"context"
+	"cplatform/pkg/slogext"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgres error codes telling schema_migrations is missing or predates checksum column
+const (
+	undefinedTableCode  = "42P01"
+	undefinedColumnCode = "42703"
+)
+
+// lockKey identifies advisory lock held while migrating, so replicas starting together apply every migration once
+const lockKey int64 = 0x63706c6174666f72
+
+var (
+	ErrMigrationNotFound = errors.New("migration not found")
+	ErrIrreversible      = errors.New("migration has no down script")
+	ErrAlreadyBaselined  = errors.New("migrations are already recorded")
+	ErrPendingMigration  = errors.New("migration is pending")
+	ErrUnknownMigration  = errors.New("database has migration unknown to this build")
+	ErrChecksumMismatch  = errors.New("applied migration was modified")
+)
+
+// Status describes migration known to build or recorded in database
+type Status struct {
+	Version int64
+	Name    string
+	// AppliedAt is nil for pending migrations
+	AppliedAt *time.Time
+	// Unknown marks migrations recorded in database but missing in this build
+	Unknown bool
+	// Modified marks applied migrations whose script differs from one recorded at apply
+	Modified bool
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []*Migration
+	logger     *slog.Logger
+}
+
+func NewMigrator(pool *pgxpool.Pool, logger *slog.Logger) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+// Up applies pending migrations up to target version in order; target 0 means latest
+func (m *Migrator) Up(ctx context.Context, target int64) ([]*Migration, error) {
+	if target != 0 && m.find(target) == nil {
+		return nil, fmt.Errorf("%w: version %d", ErrMigrationNotFound, target)
+	}
+
+	applied := make([]*Migration, 0)
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		recorded, err := m.recorded(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if err := verifyChecksums(m.migrations, recorded); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if target != 0 && migration.Version > target {
+				break
+			}
+
+			if _, ok := recorded[migration.Version]; ok {
+				continue
+			}
+
+			err := m.apply(ctx, conn, migration.Up,
+				"INSERT INTO public.schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+				migration.Version, migration.Name, migration.Checksum,
+			)
+			if err != nil {
+				return fmt.Errorf("fail apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			m.logger.Info("migration applied", "version", migration.Version, "name", migration.Name)
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts given number of most recently applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	reverted := make([]*Migration, 0, steps)
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		recorded, err := m.recorded(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := recorded[migration.Version]; !ok {
+				continue
+			}
+
+			if migration.Down == "" {
+				return fmt.Errorf("%w: %d_%s", ErrIrreversible, migration.Version, migration.Name)
+			}
+
+			err := m.apply(ctx, conn, migration.Down,
+				"DELETE FROM public.schema_migrations WHERE version = $1",
+				migration.Version,
+			)
+			if err != nil {
+				return fmt.Errorf("fail revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			m.logger.Info("migration reverted", "version", migration.Version, "name", migration.Name)
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Baseline records migrations up to version as applied without running them; it is meant for databases created before migrations
+func (m *Migrator) Baseline(ctx context.Context, version int64) error {
+	if m.find(version) == nil {
+		return fmt.Errorf("%w: version %d", ErrMigrationNotFound, version)
+	}
+
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		recorded, err := m.recorded(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if len(recorded) > 0 {
+			return fmt.Errorf("%w: %d recorded", ErrAlreadyBaselined, len(recorded))
+		}
+
+		batch := &pgx.Batch{}
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+
+			batch.Queue("INSERT INTO public.schema_migrations (version, name, checksum) VALUES ($1, $2, $3)", migration.Version, migration.Name, migration.Checksum)
+		}
+
+		err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			return tx.SendBatch(ctx, batch).Close()
+		})
+		if err != nil {
+			return fmt.Errorf("fail record baseline: %w", err)
+		}
+
+		m.logger.Info("migrations baselined", "version", version)
+
+		return nil
+	})
+}
+
+// Status lists known migrations in order followed by applied ones missing in this build; it only reads
+// schema_migrations without lock, so it neither waits for running migration nor needs rights to create table
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	recorded, err := m.recorded(ctx, m.pool)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != undefinedTableCode {
+			return nil, err
+		}
+
+		// table is created by first migration run
+		recorded = make(map[int64]record)
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := recorded[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			status.Modified = record.modified(migration)
+			delete(recorded, migration.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	unknown := make([]Status, 0, len(recorded))
+	for version, record := range recorded {
+		unknown = append(unknown, Status{Version: version, Name: record.Name, AppliedAt: &record.AppliedAt, Unknown: true})
+	}
+
+	slices.SortFunc(unknown, func(a, b Status) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return append(statuses, unknown...), nil
+}
+
+// CheckCurrent fails when migrations are pending or modified, or database was migrated by newer build
+func (m *Migrator) CheckCurrent(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		if status.Unknown {
+			return fmt.Errorf("%w: %d_%s", ErrUnknownMigration, status.Version, status.Name)
+		}
+
+		if status.AppliedAt == nil {
+			return fmt.Errorf("%w: %d_%s", ErrPendingMigration, status.Version, status.Name)
+		}
+
+		if status.Modified {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, status.Version, status.Name)
+		}
+	}
+
+	return nil
+}
+
+type record struct {
+	Name      string
+	AppliedAt time.Time
+	// Checksum is nil for migrations recorded before checksums were kept
+	Checksum *string
+}
+
+func (r *record) modified(migration *Migration) bool {
+	return r.Checksum != nil && *r.Checksum != migration.Checksum
+}
+
+// verifyChecksums fails on first applied migration whose script differs from one recorded at apply
+func verifyChecksums(migrations []*Migration, recorded map[int64]record) error {
+	for _, migration := range migrations {
+		if record, ok := recorded[migration.Version]; ok && record.modified(migration) {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+
+	return nil
+}
+
+// withLock runs fn on connection holding session advisory lock; lock is released with connection even if unlock fails.
+// Pool statement_timeout is lifted for the session, since waiting for lock and long migrations must not be cut off
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("fail acquire connection: %w", err)
+	}
+
+	_, err = conn.Exec(ctx, "SET statement_timeout = 0")
+	if err != nil {
+		conn.Release()
+		return fmt.Errorf("fail disable statement timeout: %w", err)
+	}
+
+	_, err = conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey)
+	if err != nil {
+		m.release(ctx, conn)
+		return fmt.Errorf("fail take migration lock: %w", err)
+	}
+
+	defer func() {
+		_, unlockErr := conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", lockKey)
+		if unlockErr != nil {
+			m.logger.Error("fail release migration lock", slogext.Cause(unlockErr))
+			// closing connection drops lock held by its session
+			_ = conn.Conn().Close(context.WithoutCancel(ctx))
+		}
+
+		m.release(ctx, conn)
+	}()
+
+	_, err = conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS public.schema_migrations
+(
+    version bigint NOT NULL,
+    name text NOT NULL,
+    applied_at timestamp with time zone NOT NULL DEFAULT now(),
+    checksum text,
+    PRIMARY KEY (version)
+)`)
+	if err != nil {
+		return fmt.Errorf("fail create schema_migrations: %w", err)
+	}
+
+	// tables created before checksums were kept lack the column
+	_, err = conn.Exec(ctx, "ALTER TABLE public.schema_migrations ADD COLUMN IF NOT EXISTS checksum text")
+	if err != nil {
+		return fmt.Errorf("fail add schema_migrations checksum: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// release returns connection to pool with statement_timeout of pool restored, or closes it when reset fails
+func (m *Migrator) release(ctx context.Context, conn *pgxpool.Conn) {
+	if !conn.Conn().IsClosed() {
+		_, err := conn.Exec(context.WithoutCancel(ctx), "RESET statement_timeout")
+		if err != nil {
+			m.logger.Error("fail restore statement timeout", slogext.Cause(err))
+			_ = conn.Conn().Close(context.WithoutCancel(ctx))
+		}
+	}
+
+	conn.Release()
+}
+
+// querier is either locked connection or pool
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func (m *Migrator) recorded(ctx context.Context, q querier) (map[int64]record, error) {
+	recorded, err := queryRecorded(ctx, q, "SELECT version, name, applied_at, checksum FROM public.schema_migrations")
+
+	// table created before checksums were kept lacks the column until next locked run adds it
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == undefinedColumnCode {
+		recorded, err = queryRecorded(ctx, q, "SELECT version, name, applied_at, NULL::text FROM public.schema_migrations")
+	}
+
+	return recorded, err
+}
+
+func queryRecorded(ctx context.Context, q querier, sql string) (map[int64]record, error) {
+	rows, err := q.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+	defer rows.Close()
+
+	recorded := make(map[int64]record)
+	for rows.Next() {
+		var version int64
+		var r record
+		if err := rows.Scan(&version, &r.Name, &r.AppliedAt, &r.Checksum); err != nil {
+			return nil, fmt.Errorf("fail scan migration: %w", err)
+		}
+
+		recorded[version] = r
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read migrations: %w", err)
+	}
+
+	return recorded, nil
+}
+
+// apply runs script and bookkeeping statement in one transaction, so failed migration leaves no trace
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, script string, bookkeeping string, args ...any) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, script); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, bookkeeping, args...)
+		return err
+	})
+}
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS public.submissions;
+DROP TABLE IF EXISTS public.contest_problems;
+DROP TABLE IF EXISTS public.test_set_tests;
+DROP TABLE IF EXISTS public.test_set_subtasks;
+DROP TABLE IF EXISTS public.problem_revisions;
+DROP TABLE IF EXISTS public.test_sets;
+DROP TABLE IF EXISTS public.problems;
+DROP TABLE IF EXISTS public.contest_participants;
+DROP TABLE IF EXISTS public.team_members;
+DROP TABLE IF EXISTS public.teams;
+DROP TABLE IF EXISTS public.contest_roles;
+DROP TABLE IF EXISTS public.contests;
+DROP TABLE IF EXISTS public.access_tokens;
+DROP TABLE IF EXISTS public.user_identities;
+DROP TABLE IF EXISTS public.user_roles;
+DROP TABLE IF EXISTS public.users;
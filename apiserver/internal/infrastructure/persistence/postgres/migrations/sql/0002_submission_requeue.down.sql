@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS public.i_submission_queued;
+
+ALTER TABLE IF EXISTS public.submissions
+    DROP COLUMN IF EXISTS enqueued_at;
@@ -0,0 +1,7 @@
+-- enqueued_at is set when sweeper puts queued submission back to judge queue; null means only enqueued on submit
+ALTER TABLE IF EXISTS public.submissions
+    ADD COLUMN enqueued_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS i_submission_queued
+    ON public.submissions(submitted_at)
+    WHERE state = 'queued';
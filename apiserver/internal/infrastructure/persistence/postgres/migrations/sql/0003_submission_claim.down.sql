@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS public.submissions
+    DROP COLUMN IF EXISTS claimed_at;
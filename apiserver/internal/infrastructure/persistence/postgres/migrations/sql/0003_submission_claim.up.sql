@@ -0,0 +1,3 @@
+-- claimed_at is renewed by judge while it judges submission; stale value means judge died and submission may be claimed again
+ALTER TABLE IF EXISTS public.submissions
+    ADD COLUMN claimed_at timestamp with time zone;
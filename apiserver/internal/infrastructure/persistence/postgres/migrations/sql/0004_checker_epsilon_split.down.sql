@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS public.problem_revisions
+    DROP COLUMN IF EXISTS checker_rel_epsilon;
+
+ALTER TABLE IF EXISTS public.problem_revisions
+    RENAME COLUMN checker_abs_epsilon TO checker_epsilon;
@@ -0,0 +1,10 @@
+-- float checker used one epsilon for both absolute and relative error; existing revisions keep that behaviour
+ALTER TABLE IF EXISTS public.problem_revisions
+    RENAME COLUMN checker_epsilon TO checker_abs_epsilon;
+
+ALTER TABLE IF EXISTS public.problem_revisions
+    ADD COLUMN checker_rel_epsilon double precision NOT NULL DEFAULT 0;
+
+UPDATE public.problem_revisions
+    SET checker_rel_epsilon = checker_abs_epsilon
+    WHERE checker = 'float';
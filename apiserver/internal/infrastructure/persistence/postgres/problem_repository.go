@@ -0,0 +1,395 @@
+package postgres
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const problemRevisionColumns = "r.problem_id, r.revision, r.title, r.statement, r.input_spec, r.output_spec, r.time_limit_ms, r.memory_limit_kb, r.tags, r.checker, r.checker_abs_epsilon, r.checker_rel_epsilon, r.test_set_id, r.author_id, r.created_at"
+
+type problemRepository struct {
+	logger *slog.Logger
+	uow    *UnitOfWork
+}
+
+func newProblemRepository(uow *UnitOfWork, logger *slog.Logger) *problemRepository {
+	return &problemRepository{
+		logger: logger,
+		uow:    uow,
+	}
+}
+
+func (repo *problemRepository) AddProblem(ctx context.Context, problem *domain.Problem, revision *domain.ProblemRevision) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var dto ProblemDto
+	err = tx.QueryRow(ctx,
+		"INSERT INTO public.problems (author_id, latest_revision) VALUES ($1, 1) RETURNING id, latest_revision, created_at",
+		problem.AuthorId,
+	).Scan(&dto.Id, &dto.LatestRevision, &dto.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	problem.Id = domain.ProblemId(dto.Id)
+	problem.LatestRevision = dto.LatestRevision
+	problem.CreatedAt = dto.CreatedAt
+
+	revision.ProblemId = problem.Id
+	revision.Revision = dto.LatestRevision
+
+	return repo.insertRevision(ctx, tx, revision)
+}
+
+func (repo *problemRepository) GetProblemById(ctx context.Context, id domain.ProblemId) (*domain.Problem, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var dto ProblemDto
+	err = tx.QueryRow(ctx,
+		"SELECT id, author_id, latest_revision, created_at FROM public.problems WHERE id = $1",
+		id,
+	).Scan(&dto.Id, &dto.AuthorId, &dto.LatestRevision, &dto.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no problem with id %d", infrastructure.ErrProblemNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get problem by id: %w", err)
+	}
+
+	problem := &domain.Problem{
+		Id:             domain.ProblemId(dto.Id),
+		AuthorId:       domain.UserId(dto.AuthorId),
+		LatestRevision: dto.LatestRevision,
+		CreatedAt:      dto.CreatedAt,
+	}
+
+	return problem, nil
+}
+
+func (repo *problemRepository) ListLatestRevisionsByAuthor(ctx context.Context, authorId domain.UserId, offset int, limit int) ([]*domain.ProblemRevision, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT "+problemRevisionColumns+" FROM public.problems p JOIN public.problem_revisions r ON r.problem_id = p.id AND r.revision = p.latest_revision WHERE p.author_id = $1 ORDER BY p.id DESC OFFSET $2 LIMIT $3",
+		authorId,
+		offset,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return collectRevisions(rows)
+}
+
+func (repo *problemRepository) AddProblemRevision(ctx context.Context, revision *domain.ProblemRevision) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	// row lock taken by update serializes concurrent edits of the same problem
+	var next int
+	err = tx.QueryRow(ctx,
+		"UPDATE public.problems SET latest_revision = latest_revision + 1 WHERE id = $1 RETURNING latest_revision",
+		revision.ProblemId,
+	).Scan(&next)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: no problem with id %d", infrastructure.ErrProblemNotFound, revision.ProblemId)
+	}
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	revision.Revision = next
+
+	return repo.insertRevision(ctx, tx, revision)
+}
+
+func (repo *problemRepository) GetProblemRevision(ctx context.Context, id domain.ProblemId, revision int) (*domain.ProblemRevision, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	row := tx.QueryRow(ctx,
+		"SELECT "+problemRevisionColumns+" FROM public.problem_revisions r WHERE r.problem_id = $1 AND r.revision = $2",
+		id,
+		revision,
+	)
+
+	problemRevision, err := scanProblemRevision(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no revision %d of problem %d", infrastructure.ErrProblemNotFound, revision, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get problem revision: %w", err)
+	}
+
+	return problemRevision, nil
+}
+
+func (repo *problemRepository) ListProblemRevisions(ctx context.Context, id domain.ProblemId) ([]*domain.ProblemRevision, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT "+problemRevisionColumns+" FROM public.problem_revisions r WHERE r.problem_id = $1 ORDER BY r.revision DESC",
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return collectRevisions(rows)
+}
+
+func (repo *problemRepository) AddTestSet(ctx context.Context, set *domain.TestSet) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var id domain.TestSetId
+	err = tx.QueryRow(ctx,
+		"INSERT INTO public.test_sets (problem_id, checker_source) VALUES ($1, $2) RETURNING id, created_at",
+		set.ProblemId,
+		set.CheckerSource,
+	).Scan(&id, &set.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	set.Id = id
+
+	for _, subtask := range set.Subtasks {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO public.test_set_subtasks (test_set_id, subtask, score) VALUES ($1, $2, $3)",
+			set.Id,
+			subtask.Index,
+			subtask.Score,
+		)
+		if err != nil {
+			return fmt.Errorf("fail insert subtask: %w", err)
+		}
+	}
+
+	rows := make([][]any, 0, len(set.Tests))
+	for _, test := range set.Tests {
+		rows = append(rows, []any{set.Id, test.Ordinal, test.Subtask, test.IsSample, test.InputSize, test.AnswerSize})
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"public", "test_set_tests"},
+		[]string{"test_set_id", "ordinal", "subtask", "is_sample", "input_size", "answer_size"},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return fmt.Errorf("fail insert tests: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *problemRepository) GetTestSet(ctx context.Context, id domain.TestSetId) (*domain.TestSet, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var setDto TestSetDto
+	err = tx.QueryRow(ctx,
+		"SELECT id, problem_id, checker_source, created_at FROM public.test_sets WHERE id = $1",
+		id,
+	).Scan(&setDto.Id, &setDto.ProblemId, &setDto.CheckerSource, &setDto.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no test set with id %d", infrastructure.ErrTestSetNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get test set: %w", err)
+	}
+
+	set := &domain.TestSet{
+		Id:            domain.TestSetId(setDto.Id),
+		ProblemId:     domain.ProblemId(setDto.ProblemId),
+		Tests:         make([]domain.ProblemTest, 0),
+		Subtasks:      make([]domain.ProblemSubtask, 0),
+		CheckerSource: setDto.CheckerSource,
+		CreatedAt:     setDto.CreatedAt,
+	}
+
+	testRows, err := tx.Query(ctx,
+		"SELECT ordinal, subtask, is_sample, input_size, answer_size FROM public.test_set_tests WHERE test_set_id = $1 ORDER BY ordinal",
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	for testRows.Next() {
+		var dto TestDto
+		if err := testRows.Scan(&dto.Ordinal, &dto.Subtask, &dto.IsSample, &dto.InputSize, &dto.AnswerSize); err != nil {
+			testRows.Close()
+			return nil, fmt.Errorf("fail scan test: %w", err)
+		}
+
+		set.Tests = append(set.Tests, domain.ProblemTest{
+			Ordinal:    dto.Ordinal,
+			Subtask:    dto.Subtask,
+			IsSample:   dto.IsSample,
+			InputSize:  dto.InputSize,
+			AnswerSize: dto.AnswerSize,
+		})
+	}
+	testRows.Close()
+
+	if err := testRows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read tests: %w", err)
+	}
+
+	subtaskRows, err := tx.Query(ctx,
+		"SELECT subtask, score FROM public.test_set_subtasks WHERE test_set_id = $1 ORDER BY subtask",
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+	defer subtaskRows.Close()
+
+	for subtaskRows.Next() {
+		var subtask domain.ProblemSubtask
+		if err := subtaskRows.Scan(&subtask.Index, &subtask.Score); err != nil {
+			return nil, fmt.Errorf("fail scan subtask: %w", err)
+		}
+
+		set.Subtasks = append(set.Subtasks, subtask)
+	}
+
+	if err := subtaskRows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read subtasks: %w", err)
+	}
+
+	return set, nil
+}
+
+func (repo *problemRepository) insertRevision(ctx context.Context, tx pgx.Tx, revision *domain.ProblemRevision) error {
+	tags := revision.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	err := tx.QueryRow(ctx,
+		"INSERT INTO public.problem_revisions (problem_id, revision, title, statement, input_spec, output_spec, time_limit_ms, memory_limit_kb, tags, checker, checker_abs_epsilon, checker_rel_epsilon, test_set_id, author_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING created_at",
+		revision.ProblemId,
+		revision.Revision,
+		revision.Title,
+		revision.Statement,
+		revision.InputSpec,
+		revision.OutputSpec,
+		revision.TimeLimit.Milliseconds(),
+		revision.MemoryLimitKb,
+		tags,
+		string(revision.Checker),
+		revision.CheckerAbsEpsilon,
+		revision.CheckerRelEpsilon,
+		revision.TestSetId,
+		revision.AuthorId,
+	).Scan(&revision.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("fail insert problem revision: %w", err)
+	}
+
+	return nil
+}
+
+func collectRevisions(rows pgx.Rows) ([]*domain.ProblemRevision, error) {
+	defer rows.Close()
+
+	revisions := make([]*domain.ProblemRevision, 0)
+	for rows.Next() {
+		revision, err := scanProblemRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("fail scan problem revision: %w", err)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read problem revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func scanProblemRevision(row pgx.Row) (*domain.ProblemRevision, error) {
+	var dto ProblemRevisionDto
+	err := row.Scan(
+		&dto.ProblemId,
+		&dto.Revision,
+		&dto.Title,
+		&dto.Statement,
+		&dto.InputSpec,
+		&dto.OutputSpec,
+		&dto.TimeLimitMs,
+		&dto.MemoryLimitKb,
+		&dto.Tags,
+		&dto.Checker,
+		&dto.CheckerAbsEps,
+		&dto.CheckerRelEps,
+		&dto.TestSetId,
+		&dto.AuthorId,
+		&dto.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &domain.ProblemRevision{
+		ProblemId:         domain.ProblemId(dto.ProblemId),
+		Revision:          dto.Revision,
+		Title:             dto.Title,
+		Statement:         dto.Statement,
+		InputSpec:         dto.InputSpec,
+		OutputSpec:        dto.OutputSpec,
+		TimeLimit:         time.Duration(dto.TimeLimitMs) * time.Millisecond,
+		MemoryLimitKb:     dto.MemoryLimitKb,
+		Tags:              dto.Tags,
+		Checker:           domain.CheckerKind(dto.Checker),
+		CheckerAbsEpsilon: dto.CheckerAbsEps,
+		CheckerRelEpsilon: dto.CheckerRelEps,
+		TestSetId:         (*domain.TestSetId)(dto.TestSetId),
+		AuthorId:          domain.UserId(dto.AuthorId),
+		CreatedAt:         dto.CreatedAt,
+	}
+
+	return revision, nil
+}
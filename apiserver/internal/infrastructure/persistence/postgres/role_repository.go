@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type roleRepository struct {
+	logger *slog.Logger
+	uow    *UnitOfWork
+}
+
+func newRoleRepository(uow *UnitOfWork, logger *slog.Logger) *roleRepository {
+	return &roleRepository{
+		logger: logger,
+		uow:    uow,
+	}
+}
+
+func (repo *roleRepository) AddRoleGrant(ctx context.Context, grant *domain.RoleGrant) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	if grant.ContestId == nil {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO public.user_roles (user_id, role, granted_by) VALUES ($1, $2, $3) ON CONFLICT (user_id, role) DO NOTHING",
+			grant.UserId,
+			grant.Role,
+			grant.GrantedBy,
+		)
+	} else {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO public.contest_roles (contest_id, user_id, role, granted_by) VALUES ($1, $2, $3, $4) ON CONFLICT (contest_id, user_id, role) DO NOTHING",
+			*grant.ContestId,
+			grant.UserId,
+			grant.Role,
+			grant.GrantedBy,
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", mapRoleConstraintError(err))
+	}
+
+	return nil
+}
+
+func (repo *roleRepository) RemoveRoleGrant(ctx context.Context, grant *domain.RoleGrant) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var tag pgconn.CommandTag
+	if grant.ContestId == nil {
+		tag, err = tx.Exec(ctx, "DELETE FROM public.user_roles WHERE user_id = $1 AND role = $2", grant.UserId, grant.Role)
+	} else {
+		tag, err = tx.Exec(ctx,
+			"DELETE FROM public.contest_roles WHERE contest_id = $1 AND user_id = $2 AND role = $3",
+			*grant.ContestId,
+			grant.UserId,
+			grant.Role,
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: user %d has no %s role", infrastructure.ErrRoleGrantNotFound, grant.UserId, grant.Role)
+	}
+
+	return nil
+}
+
+func (repo *roleRepository) ListGlobalRoles(ctx context.Context, userId domain.UserId) ([]domain.Role, error) {
+	return repo.listRoles(ctx, "SELECT role FROM public.user_roles WHERE user_id = $1 ORDER BY role", userId)
+}
+
+func (repo *roleRepository) ListContestRoles(ctx context.Context, contestId domain.ContestId, userId domain.UserId) ([]domain.Role, error) {
+	return repo.listRoles(ctx, "SELECT role FROM public.contest_roles WHERE contest_id = $1 AND user_id = $2 ORDER BY role", contestId, userId)
+}
+
+func (repo *roleRepository) ListGlobalRoleGrants(ctx context.Context, userId domain.UserId) ([]*domain.RoleGrant, error) {
+	return repo.listGrants(ctx,
+		"SELECT user_id, role, NULL::bigint, granted_by, granted_at FROM public.user_roles WHERE user_id = $1 ORDER BY role",
+		userId,
+	)
+}
+
+func (repo *roleRepository) ListContestRoleGrants(ctx context.Context, contestId domain.ContestId) ([]*domain.RoleGrant, error) {
+	return repo.listGrants(ctx,
+		"SELECT user_id, role, contest_id, granted_by, granted_at FROM public.contest_roles WHERE contest_id = $1 ORDER BY granted_at, user_id, role",
+		contestId,
+	)
+}
+
+func (repo *roleRepository) listRoles(ctx context.Context, query string, args ...any) ([]domain.Role, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+	defer rows.Close()
+
+	roles := make([]domain.Role, 0)
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, fmt.Errorf("fail scan role: %w", err)
+		}
+
+		roles = append(roles, domain.Role(role))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (repo *roleRepository) listGrants(ctx context.Context, query string, args ...any) ([]*domain.RoleGrant, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+	defer rows.Close()
+
+	grants := make([]*domain.RoleGrant, 0)
+	for rows.Next() {
+		var dto RoleGrantDto
+		if err := rows.Scan(&dto.UserId, &dto.Role, &dto.ContestId, &dto.GrantedBy, &dto.GrantedAt); err != nil {
+			return nil, fmt.Errorf("fail scan role grant: %w", err)
+		}
+
+		grants = append(grants, &domain.RoleGrant{
+			UserId:    domain.UserId(dto.UserId),
+			Role:      domain.Role(dto.Role),
+			ContestId: (*domain.ContestId)(dto.ContestId),
+			GrantedBy: domain.UserId(dto.GrantedBy),
+			GrantedAt: dto.GrantedAt,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read role grants: %w", err)
+	}
+
+	return grants, nil
+}
+
+func mapRoleConstraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case "fk_user_role_user", "fk_contest_role_user":
+		return fmt.Errorf("%w: %s", infrastructure.ErrUserNotFound, err.Error())
+	case "fk_contest_role_contest":
+		return fmt.Errorf("%w: %s", infrastructure.ErrContestNotFound, err.Error())
+	}
+
+	return err
+}
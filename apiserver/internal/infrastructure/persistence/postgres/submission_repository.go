@@ -0,0 +1,362 @@
+package postgres
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const submissionColumns = "id, contest_id, problem_label, problem_id, problem_revision, user_id, team_id, language_id, source, state, submitted_at, verdict, score, subtask_scores, failed_test, time_ms, memory_kb, judge_log, judged_at"
+
+// submissionSummaryColumns skip source which is not needed for standings and can take 64KB per row
+const submissionSummaryColumns = "id, contest_id, problem_label, problem_id, problem_revision, user_id, team_id, language_id, '' AS source, state, submitted_at, verdict, score, subtask_scores, failed_test, time_ms, memory_kb, judge_log, judged_at"
+
+type submissionRepository struct {
+	logger *slog.Logger
+	uow    *UnitOfWork
+}
+
+func newSubmissionRepository(uow *UnitOfWork, logger *slog.Logger) *submissionRepository {
+	return &submissionRepository{
+		logger: logger,
+		uow:    uow,
+	}
+}
+
+func (repo *submissionRepository) AddSubmission(ctx context.Context, submission *domain.Submission) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var id domain.SubmissionId
+	err = tx.QueryRow(ctx,
+		"INSERT INTO public.submissions (contest_id, problem_label, problem_id, problem_revision, user_id, team_id, language_id, source, state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, submitted_at",
+		submission.ContestId,
+		submission.ProblemLabel,
+		submission.ProblemId,
+		submission.ProblemRevision,
+		submission.UserId,
+		submission.TeamId,
+		submission.LanguageId,
+		submission.Source,
+		string(submission.State),
+	).Scan(&id, &submission.SubmittedAt)
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	submission.Id = id
+
+	return nil
+}
+
+func (repo *submissionRepository) GetSubmissionById(ctx context.Context, id domain.SubmissionId) (*domain.Submission, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	row := tx.QueryRow(ctx, "SELECT "+submissionColumns+" FROM public.submissions WHERE id = $1", id)
+
+	submission, err := scanSubmission(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no submission with id %d", infrastructure.ErrSubmissionNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get submission by id: %w", err)
+	}
+
+	return submission, nil
+}
+
+func (repo *submissionRepository) ListSubmissionsByUser(ctx context.Context, contestId domain.ContestId, userId domain.UserId, offset int, limit int) ([]*domain.Submission, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT "+submissionColumns+" FROM public.submissions WHERE contest_id = $1 AND user_id = $2 ORDER BY id DESC OFFSET $3 LIMIT $4",
+		contestId,
+		userId,
+		offset,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return collectSubmissions(rows)
+}
+
+func (repo *submissionRepository) ListSubmissionsByTeam(ctx context.Context, contestId domain.ContestId, teamId domain.TeamId, offset int, limit int) ([]*domain.Submission, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT "+submissionColumns+" FROM public.submissions WHERE contest_id = $1 AND team_id = $2 ORDER BY id DESC OFFSET $3 LIMIT $4",
+		contestId,
+		teamId,
+		offset,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return collectSubmissions(rows)
+}
+
+func (repo *submissionRepository) ListContestSubmissions(ctx context.Context, contestId domain.ContestId, from time.Time, to time.Time) ([]*domain.Submission, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT "+submissionSummaryColumns+" FROM public.submissions WHERE contest_id = $1 AND submitted_at >= $2 AND submitted_at < $3 ORDER BY submitted_at, id",
+		contestId,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return collectSubmissions(rows)
+}
+
+func (repo *submissionRepository) UpdateSubmissionState(ctx context.Context, id domain.SubmissionId, state domain.SubmissionState) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, "UPDATE public.submissions SET state = $2 WHERE id = $1", id, string(state))
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: no submission with id %d", infrastructure.ErrSubmissionNotFound, id)
+	}
+
+	return nil
+}
+
+func (repo *submissionRepository) SaveSubmissionResult(ctx context.Context, id domain.SubmissionId, result *domain.SubmissionResult) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	subtaskScores := result.SubtaskScores
+	if subtaskScores == nil {
+		subtaskScores = []int{}
+	}
+
+	tag, err := tx.Exec(ctx,
+		"UPDATE public.submissions SET state = $2, verdict = $3, score = $4, subtask_scores = $5, failed_test = $6, time_ms = $7, memory_kb = $8, judge_log = $9, judged_at = $10 WHERE id = $1",
+		id,
+		string(domain.SubmissionStateJudged),
+		string(result.Verdict),
+		result.Score,
+		subtaskScores,
+		result.FailedTest,
+		result.TimeUsed.Milliseconds(),
+		result.MemoryUsedKb,
+		result.JudgeLog,
+		result.JudgedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: no submission with id %d", infrastructure.ErrSubmissionNotFound, id)
+	}
+
+	return nil
+}
+
+func (repo *submissionRepository) ClaimSubmission(ctx context.Context, id domain.SubmissionId, staleBefore time.Time) (bool, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return false, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx,
+		"UPDATE public.submissions SET state = $2, claimed_at = now() WHERE id = $1 AND (state = $3 OR (state = $2 AND (claimed_at IS NULL OR claimed_at < $4)))",
+		id,
+		string(domain.SubmissionStateJudging),
+		string(domain.SubmissionStateQueued),
+		staleBefore,
+	)
+	if err != nil {
+		return false, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (repo *submissionRepository) RenewSubmissionClaim(ctx context.Context, id domain.SubmissionId) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		"UPDATE public.submissions SET claimed_at = now() WHERE id = $1 AND state = $2",
+		id,
+		string(domain.SubmissionStateJudging),
+	)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *submissionRepository) RequeueStaleSubmissions(ctx context.Context, before time.Time, limit int) ([]domain.SubmissionId, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		`UPDATE public.submissions SET enqueued_at = now()
+		WHERE id IN (
+			SELECT id FROM public.submissions
+			WHERE state = $1 AND COALESCE(enqueued_at, submitted_at) < $2
+			ORDER BY submitted_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		string(domain.SubmissionStateQueued),
+		before,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	defer rows.Close()
+
+	ids := make([]domain.SubmissionId, 0)
+	for rows.Next() {
+		var id domain.SubmissionId
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("fail scan submission id: %w", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read submission ids: %w", err)
+	}
+
+	return ids, nil
+}
+
+func collectSubmissions(rows pgx.Rows) ([]*domain.Submission, error) {
+	defer rows.Close()
+
+	list := make([]*domain.Submission, 0)
+	for rows.Next() {
+		submission, err := scanSubmission(rows)
+		if err != nil {
+			return nil, fmt.Errorf("fail scan submission: %w", err)
+		}
+
+		list = append(list, submission)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read submissions: %w", err)
+	}
+
+	return list, nil
+}
+
+func scanSubmission(row pgx.Row) (*domain.Submission, error) {
+	var dto SubmissionDto
+	err := row.Scan(
+		&dto.Id,
+		&dto.ContestId,
+		&dto.ProblemLabel,
+		&dto.ProblemId,
+		&dto.ProblemRevision,
+		&dto.UserId,
+		&dto.TeamId,
+		&dto.LanguageId,
+		&dto.Source,
+		&dto.State,
+		&dto.SubmittedAt,
+		&dto.Verdict,
+		&dto.Score,
+		&dto.SubtaskScores,
+		&dto.FailedTest,
+		&dto.TimeMs,
+		&dto.MemoryKb,
+		&dto.JudgeLog,
+		&dto.JudgedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	submission := &domain.Submission{
+		Id:              domain.SubmissionId(dto.Id),
+		ContestId:       domain.ContestId(dto.ContestId),
+		ProblemLabel:    dto.ProblemLabel,
+		ProblemId:       domain.ProblemId(dto.ProblemId),
+		ProblemRevision: dto.ProblemRevision,
+		UserId:          domain.UserId(dto.UserId),
+		LanguageId:      dto.LanguageId,
+		Source:          dto.Source,
+		State:           domain.SubmissionState(dto.State),
+		SubmittedAt:     dto.SubmittedAt,
+	}
+
+	if dto.TeamId != nil {
+		teamId := domain.TeamId(*dto.TeamId)
+		submission.TeamId = &teamId
+	}
+
+	if dto.Verdict != nil {
+		submission.Result = &domain.SubmissionResult{
+			Verdict:       domain.Verdict(*dto.Verdict),
+			Score:         valueOrZero(dto.Score),
+			SubtaskScores: dto.SubtaskScores,
+			FailedTest:    valueOrZero(dto.FailedTest),
+			TimeUsed:      time.Duration(valueOrZero(dto.TimeMs)) * time.Millisecond,
+			MemoryUsedKb:  valueOrZero(dto.MemoryKb),
+			JudgeLog:      valueOrZero(dto.JudgeLog),
+			JudgedAt:      valueOrZero(dto.JudgedAt),
+		}
+	}
+
+	return submission, nil
+}
+
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+
+	return *value
+}
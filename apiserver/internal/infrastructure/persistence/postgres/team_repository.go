@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const teamColumns = "id, name, captain_id, invite_token, created_at"
+
+type teamRepository struct {
+	logger *slog.Logger
+	uow    *UnitOfWork
+}
+
+func newTeamRepository(uow *UnitOfWork, logger *slog.Logger) *teamRepository {
+	return &teamRepository{
+		logger: logger,
+		uow:    uow,
+	}
+}
+
+func (repo *teamRepository) AddTeam(ctx context.Context, team *domain.Team) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	var id domain.TeamId
+	err = tx.QueryRow(ctx,
+		"INSERT INTO public.teams (name, captain_id, invite_token) VALUES ($1, $2, $3) RETURNING id, created_at",
+		team.Name,
+		team.CaptainId,
+		team.InviteToken,
+	).Scan(&id, &team.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", mapTeamConstraintError(err))
+	}
+
+	team.Id = id
+
+	return nil
+}
+
+func (repo *teamRepository) GetTeamById(ctx context.Context, id domain.TeamId) (*domain.Team, error) {
+	return repo.getTeam(ctx, "SELECT "+teamColumns+" FROM public.teams WHERE id = $1", id)
+}
+
+func (repo *teamRepository) GetTeamByInviteToken(ctx context.Context, token string) (*domain.Team, error) {
+	return repo.getTeam(ctx, "SELECT "+teamColumns+" FROM public.teams WHERE invite_token = $1", token)
+}
+
+func (repo *teamRepository) GetTeamByIdForUpdate(ctx context.Context, id domain.TeamId) (*domain.Team, error) {
+	return repo.getTeam(ctx, "SELECT "+teamColumns+" FROM public.teams WHERE id = $1 FOR UPDATE", id)
+}
+
+func (repo *teamRepository) UpdateTeam(ctx context.Context, team *domain.Team) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx,
+		"UPDATE public.teams SET name = $2, captain_id = $3, invite_token = $4 WHERE id = $1",
+		team.Id,
+		team.Name,
+		team.CaptainId,
+		team.InviteToken,
+	)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", mapTeamConstraintError(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: no team with id %d", infrastructure.ErrTeamNotFound, team.Id)
+	}
+
+	return nil
+}
+
+func (repo *teamRepository) ListTeamsByMember(ctx context.Context, userId domain.UserId) ([]*domain.Team, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT t.id, t.name, t.captain_id, t.invite_token, t.created_at FROM public.teams t JOIN public.team_members m ON m.team_id = t.id WHERE m.user_id = $1 ORDER BY t.name",
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make([]*domain.Team, 0)
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, fmt.Errorf("fail scan team: %w", err)
+		}
+
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read teams: %w", err)
+	}
+
+	for _, team := range teams {
+		team.Members, err = repo.listMembers(ctx, tx, team.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return teams, nil
+}
+
+func (repo *teamRepository) AddTeamMember(ctx context.Context, teamId domain.TeamId, userId domain.UserId) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx,
+		"INSERT INTO public.team_members (team_id, user_id) VALUES ($1, $2) ON CONFLICT (team_id, user_id) DO NOTHING",
+		teamId,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: user %d in team %d", infrastructure.ErrDuplicateTeamMember, userId, teamId)
+	}
+
+	return nil
+}
+
+func (repo *teamRepository) RemoveTeamMember(ctx context.Context, teamId domain.TeamId, userId domain.UserId) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, "DELETE FROM public.team_members WHERE team_id = $1 AND user_id = $2", teamId, userId)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: user %d in team %d", infrastructure.ErrTeamMemberNotFound, userId, teamId)
+	}
+
+	return nil
+}
+
+func (repo *teamRepository) getTeam(ctx context.Context, query string, arg any) (*domain.Team, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	team, err := scanTeam(tx.QueryRow(ctx, query, arg))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no team matching %v", infrastructure.ErrTeamNotFound, arg)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get team: %w", err)
+	}
+
+	team.Members, err = repo.listMembers(ctx, tx, team.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (repo *teamRepository) listMembers(ctx context.Context, tx pgx.Tx, teamId domain.TeamId) ([]domain.TeamMember, error) {
+	rows, err := tx.Query(ctx,
+		"SELECT m.user_id, u.name, m.joined_at FROM public.team_members m JOIN public.users u ON u.id = m.user_id WHERE m.team_id = $1 ORDER BY m.joined_at, m.user_id",
+		teamId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fail perform sql query: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]domain.TeamMember, 0)
+	for rows.Next() {
+		var dto TeamMemberDto
+		if err := rows.Scan(&dto.UserId, &dto.Name, &dto.JoinedAt); err != nil {
+			return nil, fmt.Errorf("fail scan team member: %w", err)
+		}
+
+		members = append(members, domain.TeamMember{
+			UserId:   domain.UserId(dto.UserId),
+			Name:     dto.Name,
+			JoinedAt: dto.JoinedAt,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail read team members: %w", err)
+	}
+
+	return members, nil
+}
+
+func scanTeam(row pgx.Row) (*domain.Team, error) {
+	var dto TeamDto
+	err := row.Scan(&dto.Id, &dto.Name, &dto.CaptainId, &dto.InviteToken, &dto.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &domain.Team{
+		Id:          domain.TeamId(dto.Id),
+		Name:        dto.Name,
+		CaptainId:   domain.UserId(dto.CaptainId),
+		InviteToken: dto.InviteToken,
+		CreatedAt:   dto.CreatedAt,
+	}
+
+	return team, nil
+}
+
+func mapTeamConstraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == "c_unique_team_name" {
+		return fmt.Errorf("%w: %s", infrastructure.ErrDuplicateTeamName, err.Error())
+	}
+
+	return err
+}
@@ -19,7 +19,14 @@ type UnitOfWork struct {
 
 	logger *slog.Logger
 
-	userRepository *userRepository
+	userRepository        *userRepository
+	contestRepository     *contestRepository
+	problemRepository     *problemRepository
+	submissionRepository  *submissionRepository
+	teamRepository        *teamRepository
+	roleRepository        *roleRepository
+	identityRepository    *identityRepository
+	accessTokenRepository *accessTokenRepository
 }
 
 func newUnitOfWorkWithIsoLevel(conn *pgxpool.Conn, logger *slog.Logger, txIsoLevel pgx.TxIsoLevel) *UnitOfWork {
@@ -79,6 +86,62 @@ func (uow *UnitOfWork) UserRepository(context.Context) infrastructure.UserReposi
 	return uow.userRepository
 }
 
+func (uow *UnitOfWork) ContestRepository(context.Context) infrastructure.ContestRepository {
+	if uow.contestRepository == nil {
+		uow.contestRepository = newContestRepository(uow, uow.logger)
+	}
+
+	return uow.contestRepository
+}
+
+func (uow *UnitOfWork) ProblemRepository(context.Context) infrastructure.ProblemRepository {
+	if uow.problemRepository == nil {
+		uow.problemRepository = newProblemRepository(uow, uow.logger)
+	}
+
+	return uow.problemRepository
+}
+
+func (uow *UnitOfWork) SubmissionRepository(context.Context) infrastructure.SubmissionRepository {
+	if uow.submissionRepository == nil {
+		uow.submissionRepository = newSubmissionRepository(uow, uow.logger)
+	}
+
+	return uow.submissionRepository
+}
+
+func (uow *UnitOfWork) TeamRepository(context.Context) infrastructure.TeamRepository {
+	if uow.teamRepository == nil {
+		uow.teamRepository = newTeamRepository(uow, uow.logger)
+	}
+
+	return uow.teamRepository
+}
+
+func (uow *UnitOfWork) RoleRepository(context.Context) infrastructure.RoleRepository {
+	if uow.roleRepository == nil {
+		uow.roleRepository = newRoleRepository(uow, uow.logger)
+	}
+
+	return uow.roleRepository
+}
+
+func (uow *UnitOfWork) IdentityRepository(context.Context) infrastructure.IdentityRepository {
+	if uow.identityRepository == nil {
+		uow.identityRepository = newIdentityRepository(uow, uow.logger)
+	}
+
+	return uow.identityRepository
+}
+
+func (uow *UnitOfWork) AccessTokenRepository(context.Context) infrastructure.AccessTokenRepository {
+	if uow.accessTokenRepository == nil {
+		uow.accessTokenRepository = newAccessTokenRepository(uow, uow.logger)
+	}
+
+	return uow.accessTokenRepository
+}
+
 func (uow *UnitOfWork) Close(ctx context.Context) error {
 	defer func() {
 		uow.hasCurrTx = false
@@ -13,6 +13,8 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+const userColumns = "id, name, email, password_hash, salt, country, organization, bio, email_verified"
+
 type userRepository struct {
 	logger *slog.Logger
 	uow    *UnitOfWork
@@ -33,11 +35,12 @@ func (repo *userRepository) AddUser(ctx context.Context, user *domain.User) erro
 
 	var id domain.UserId
 	err = tx.QueryRow(ctx,
-		"INSERT INTO public.users (name, email, password_hash, salt) VALUES ($1, $2, $3, $4) RETURNING id",
+		"INSERT INTO public.users (name, email, password_hash, salt, email_verified) VALUES ($1, $2, $3, $4, $5) RETURNING id",
 		user.Name,
 		user.Email,
 		user.PasswordHash,
 		user.Salt,
+		user.EmailVerified,
 	).Scan(&id)
 
 	if err != nil {
@@ -63,8 +66,35 @@ func (repo *userRepository) DeleteUser(ctx context.Context, id domain.UserId) er
 		return fmt.Errorf("cannot fetch transaction: %w", err)
 	}
 
-	newCtx := context.WithoutCancel(ctx)
-	_, err = tx.Exec(newCtx, "DELETE FROM public.users WHERE id = $1", id)
+	// email is replaced by unique placeholder so address can be registered again
+	tag, err := tx.Exec(ctx,
+		"UPDATE public.users SET name = 'deleted user', email = 'deleted:' || id, country = '', organization = '', bio = '', password_hash = ''::bytea, salt = ''::bytea, deleted_at = now() WHERE id = $1 AND deleted_at IS NULL",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: no user with id %d", infrastructure.ErrUserNotFound, id)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM public.user_roles WHERE user_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM public.contest_roles WHERE user_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM public.user_identities WHERE user_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM public.access_tokens WHERE user_id = $1", id)
 	if err != nil {
 		return fmt.Errorf("fail perform sql query: %w", err)
 	}
@@ -72,17 +102,38 @@ func (repo *userRepository) DeleteUser(ctx context.Context, id domain.UserId) er
 	return nil
 }
 
+func (repo *userRepository) GetUserById(ctx context.Context, id domain.UserId) (*domain.User, error) {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	user, err := scanUser(tx.QueryRow(ctx,
+		"SELECT "+userColumns+" FROM public.users WHERE id = $1 AND deleted_at IS NULL",
+		id,
+	))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no user with id %d", infrastructure.ErrUserNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("fail get user by id: %w", err)
+	}
+
+	return user, nil
+}
+
 func (repo *userRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	tx, err := repo.uow.Tx(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
 	}
 
-	var userDto UserDto
-	err = tx.QueryRow(ctx,
-		"SELECT id, name, email, password_hash, salt FROM public.users WHERE email = $1",
+	user, err := scanUser(tx.QueryRow(ctx,
+		"SELECT "+userColumns+" FROM public.users WHERE email = $1 AND deleted_at IS NULL",
 		email,
-	).Scan(&userDto.Id, &userDto.Name, &userDto.Email, &userDto.PasswordHash, &userDto.Salt)
+	))
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, fmt.Errorf("%w: no user with such email", infrastructure.ErrUserNotFound)
@@ -92,12 +143,81 @@ func (repo *userRepository) GetUserByEmail(ctx context.Context, email string) (*
 		return nil, fmt.Errorf("fail get user by email: %w", err)
 	}
 
-	var user = &domain.User{
-		Id:           domain.UserId(userDto.Id),
-		Name:         userDto.Name,
-		Email:        userDto.Email,
-		Salt:         userDto.Salt,
-		PasswordHash: userDto.PasswordHash,
+	return user, nil
+}
+
+func (repo *userRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx,
+		"UPDATE public.users SET name = $2, email = $3, country = $4, organization = $5, bio = $6, email_verified = $7 WHERE id = $1 AND deleted_at IS NULL",
+		user.Id,
+		user.Name,
+		user.Email,
+		user.Country,
+		user.Organization,
+		user.Bio,
+		user.EmailVerified,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "c_unique_user_email" {
+			err = fmt.Errorf("%w: %s", infrastructure.ErrDuplicateEmail, err.Error())
+		}
+
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: no user with id %d", infrastructure.ErrUserNotFound, user.Id)
+	}
+
+	return nil
+}
+
+func (repo *userRepository) UpdatePassword(ctx context.Context, user *domain.User) error {
+	tx, err := repo.uow.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx,
+		"UPDATE public.users SET password_hash = $2, salt = $3 WHERE id = $1 AND deleted_at IS NULL",
+		user.Id,
+		user.PasswordHash,
+		user.Salt,
+	)
+	if err != nil {
+		return fmt.Errorf("fail perform sql query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: no user with id %d", infrastructure.ErrUserNotFound, user.Id)
+	}
+
+	return nil
+}
+
+func scanUser(row pgx.Row) (*domain.User, error) {
+	var dto UserDto
+	err := row.Scan(&dto.Id, &dto.Name, &dto.Email, &dto.PasswordHash, &dto.Salt, &dto.Country, &dto.Organization, &dto.Bio, &dto.EmailVerified)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &domain.User{
+		Id:            domain.UserId(dto.Id),
+		Name:          dto.Name,
+		Email:         dto.Email,
+		Salt:          dto.Salt,
+		PasswordHash:  dto.PasswordHash,
+		Country:       dto.Country,
+		Organization:  dto.Organization,
+		Bio:           dto.Bio,
+		EmailVerified: dto.EmailVerified,
 	}
 
 	return user, nil
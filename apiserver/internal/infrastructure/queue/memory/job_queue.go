@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// JobQueue keeps jobs in process memory; meant for tests and single process setups
+type JobQueue struct {
+	mux      sync.Mutex
+	pending  []*infrastructure.JudgeJob
+	inFlight map[string]*infrastructure.JudgeJob
+	nextId   int64
+	notify   chan struct{}
+}
+
+func NewJobQueue() *JobQueue {
+	return &JobQueue{
+		inFlight: make(map[string]*infrastructure.JudgeJob),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+func (q *JobQueue) Enqueue(_ context.Context, job *infrastructure.JudgeJob) error {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	q.nextId++
+	job.MessageId = strconv.FormatInt(q.nextId, 10)
+
+	copied := *job
+	q.pending = append(q.pending, &copied)
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (q *JobQueue) Dequeue(ctx context.Context) (*infrastructure.JudgeJob, error) {
+	for {
+		if job := q.pop(); job != nil {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.notify:
+		}
+	}
+}
+
+func (q *JobQueue) Ack(_ context.Context, job *infrastructure.JudgeJob) error {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if _, ok := q.inFlight[job.MessageId]; !ok {
+		return fmt.Errorf("job %s is not in flight", job.MessageId)
+	}
+
+	delete(q.inFlight, job.MessageId)
+
+	return nil
+}
+
+// Extend only checks job is in flight, as jobs are never redelivered by this queue
+func (q *JobQueue) Extend(_ context.Context, job *infrastructure.JudgeJob) error {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if _, ok := q.inFlight[job.MessageId]; !ok {
+		return fmt.Errorf("job %s is not in flight", job.MessageId)
+	}
+
+	return nil
+}
+
+// Len reports jobs waiting for consumer, acknowledged and in flight jobs are not counted
+func (q *JobQueue) Len() int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	return len(q.pending)
+}
+
+func (q *JobQueue) pop() *infrastructure.JudgeJob {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if len(q.pending) == 0 {
+		return nil
+	}
+
+	job := q.pending[0]
+	q.pending = q.pending[1:]
+	q.inFlight[job.MessageId] = job
+
+	// wake next waiter if more jobs are left
+	if len(q.pending) > 0 {
+		select {
+		case q.notify <- struct{}{}:
+		default:
+		}
+	}
+
+	copied := *job
+
+	return &copied
+}
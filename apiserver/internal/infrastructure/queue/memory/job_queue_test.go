@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJobQueueDeliversInOrder(t *testing.T) {
+	ctx := context.Background()
+	queue := NewJobQueue()
+
+	for _, id := range []domain.SubmissionId{3, 1, 2} {
+		if err := queue.Enqueue(ctx, &infrastructure.JudgeJob{SubmissionId: id}); err != nil {
+			t.Fatalf("Enqueue(%d): %v", id, err)
+		}
+	}
+
+	for _, want := range []domain.SubmissionId{3, 1, 2} {
+		job, err := queue.Dequeue(ctx)
+		if err != nil {
+			t.Fatalf("Dequeue: %v", err)
+		}
+
+		if job.SubmissionId != want {
+			t.Errorf("dequeued submission %d, want %d", job.SubmissionId, want)
+		}
+
+		if err := queue.Ack(ctx, job); err != nil {
+			t.Errorf("Ack(%s): %v", job.MessageId, err)
+		}
+	}
+
+	if got := queue.Len(); got != 0 {
+		t.Errorf("Len = %d, want 0", got)
+	}
+}
+
+func TestJobQueueInFlight(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		op      func(q *JobQueue, job *infrastructure.JudgeJob) error
+		wantErr bool
+	}{
+		{
+			name: "extend dequeued",
+			op:   func(q *JobQueue, job *infrastructure.JudgeJob) error { return q.Extend(ctx, job) },
+		},
+		{
+			name: "ack dequeued",
+			op:   func(q *JobQueue, job *infrastructure.JudgeJob) error { return q.Ack(ctx, job) },
+		},
+		{
+			name: "ack twice",
+			op: func(q *JobQueue, job *infrastructure.JudgeJob) error {
+				_ = q.Ack(ctx, job)
+				return q.Ack(ctx, job)
+			},
+			wantErr: true,
+		},
+		{
+			name: "extend acknowledged",
+			op: func(q *JobQueue, job *infrastructure.JudgeJob) error {
+				_ = q.Ack(ctx, job)
+				return q.Extend(ctx, job)
+			},
+			wantErr: true,
+		},
+		{
+			name: "ack unknown",
+			op: func(q *JobQueue, job *infrastructure.JudgeJob) error {
+				return q.Ack(ctx, &infrastructure.JudgeJob{MessageId: "unknown"})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := NewJobQueue()
+			if err := queue.Enqueue(ctx, &infrastructure.JudgeJob{SubmissionId: 1}); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+
+			job, err := queue.Dequeue(ctx)
+			if err != nil {
+				t.Fatalf("Dequeue: %v", err)
+			}
+
+			if err := tt.op(queue, job); (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJobQueueDequeueWaits(t *testing.T) {
+	queue := NewJobQueue()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := queue.Dequeue(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Dequeue on empty queue = %v, want deadline exceeded", err)
+	}
+
+	done := make(chan domain.SubmissionId)
+	go func() {
+		job, err := queue.Dequeue(context.Background())
+		if err != nil {
+			t.Errorf("Dequeue: %v", err)
+			close(done)
+
+			return
+		}
+
+		done <- job.SubmissionId
+	}()
+
+	if err := queue.Enqueue(context.Background(), &infrastructure.JudgeJob{SubmissionId: 7}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	select {
+	case id := <-done:
+		if id != 7 {
+			t.Errorf("waiting consumer got submission %d, want 7", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiting consumer was not woken by Enqueue")
+	}
+}
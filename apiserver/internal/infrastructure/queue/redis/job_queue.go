@@ -0,0 +1,205 @@
+package redis
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const jobsStream = "cplatform:judge:jobs"
+const jobsGroup = "judges"
+const submissionIdField = "submission_id"
+
+// redeliverAfter is how long job may stay unacknowledged without Extend before another consumer takes it over;
+// judges extend jobs every minute, so it covers few missed heartbeats rather than worst case judge time
+const redeliverAfter = 5 * time.Minute
+const readBlock = 5 * time.Second
+
+// JobQueue is Redis Streams backed queue where every judge is a consumer of the same group
+type JobQueue struct {
+	client   *redis.Client
+	consumer string
+	logger   *slog.Logger
+
+	groupMux     sync.Mutex
+	groupCreated bool
+}
+
+func NewJobQueue(client *redis.Client, logger *slog.Logger) *JobQueue {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &JobQueue{
+		client:   client,
+		consumer: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		logger:   logger,
+	}
+}
+
+func (q *JobQueue) Enqueue(ctx context.Context, job *infrastructure.JudgeJob) error {
+	id, err := q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: jobsStream,
+		Values: map[string]any{
+			submissionIdField: int64(job.SubmissionId),
+		},
+	}).Result()
+
+	if err != nil {
+		return fmt.Errorf("could not add job to stream: %w", err)
+	}
+
+	job.MessageId = id
+
+	return nil
+}
+
+func (q *JobQueue) Dequeue(ctx context.Context) (*infrastructure.JudgeJob, error) {
+	if err := q.ensureGroup(ctx); err != nil {
+		return nil, err
+	}
+
+	for {
+		job, err := q.claimStale(ctx)
+		if err != nil || job != nil {
+			return job, err
+		}
+
+		streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    jobsGroup,
+			Consumer: q.consumer,
+			Streams:  []string{jobsStream, ">"},
+			Count:    1,
+			Block:    readBlock,
+		}).Result()
+
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+
+			return nil, fmt.Errorf("could not read job from stream: %w", err)
+		}
+
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				return q.parseMessage(ctx, message)
+			}
+		}
+	}
+}
+
+func (q *JobQueue) Ack(ctx context.Context, job *infrastructure.JudgeJob) error {
+	_, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAck(ctx, jobsStream, jobsGroup, job.MessageId)
+		pipe.XDel(ctx, jobsStream, job.MessageId)
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("could not ack job: %w", err)
+	}
+
+	return nil
+}
+
+// Extend claims job by its own consumer again, which resets idle time XAUTOCLAIM compares with redeliverAfter
+func (q *JobQueue) Extend(ctx context.Context, job *infrastructure.JudgeJob) error {
+	claimed, err := q.client.XClaimJustID(ctx, &redis.XClaimArgs{
+		Stream:   jobsStream,
+		Group:    jobsGroup,
+		Consumer: q.consumer,
+		MinIdle:  0,
+		Messages: []string{job.MessageId},
+	}).Result()
+
+	if err != nil {
+		return fmt.Errorf("could not extend job: %w", err)
+	}
+
+	if len(claimed) == 0 {
+		return fmt.Errorf("could not extend job %s: it is no longer pending", job.MessageId)
+	}
+
+	return nil
+}
+
+// claimStale takes over job which consumer crashed without acknowledgement
+func (q *JobQueue) claimStale(ctx context.Context) (*infrastructure.JudgeJob, error) {
+	messages, _, err := q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   jobsStream,
+		Group:    jobsGroup,
+		Consumer: q.consumer,
+		MinIdle:  redeliverAfter,
+		Start:    "0-0",
+		Count:    1,
+	}).Result()
+
+	if err != nil {
+		return nil, fmt.Errorf("could not claim stale jobs: %w", err)
+	}
+
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	q.logger.Warn("redelivering stale judge job", "message_id", messages[0].ID)
+
+	return q.parseMessage(ctx, messages[0])
+}
+
+func (q *JobQueue) parseMessage(ctx context.Context, message redis.XMessage) (*infrastructure.JudgeJob, error) {
+	raw, _ := message.Values[submissionIdField].(string)
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		// poison message can never be processed, drop it so it is not redelivered forever
+		job := &infrastructure.JudgeJob{MessageId: message.ID}
+		if ackErr := q.Ack(ctx, job); ackErr != nil {
+			err = errors.Join(err, ackErr)
+		}
+
+		return nil, fmt.Errorf("malformed job %s: %w", message.ID, err)
+	}
+
+	job := &infrastructure.JudgeJob{
+		MessageId:    message.ID,
+		SubmissionId: domain.SubmissionId(id),
+	}
+
+	return job, nil
+}
+
+func (q *JobQueue) ensureGroup(ctx context.Context) error {
+	q.groupMux.Lock()
+	defer q.groupMux.Unlock()
+
+	if q.groupCreated {
+		return nil
+	}
+
+	err := q.client.XGroupCreateMkStream(ctx, jobsStream, jobsGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("could not create consumer group: %w", err)
+	}
+
+	q.groupCreated = true
+
+	return nil
+}
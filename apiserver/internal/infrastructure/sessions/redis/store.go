@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"context"
+	"cplatform/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type SessionDto struct {
+	UserId    int64     `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (d SessionDto) MarshalBinary() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *SessionDto) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+// SessionStore keeps every session in its own key expiring together with session and indexes
+// token hashes by user, so all sessions of user can be revoked at once
+type SessionStore struct {
+	client *redis.Client
+	logger *slog.Logger
+}
+
+func NewSessionStore(client *redis.Client, logger *slog.Logger) *SessionStore {
+	return &SessionStore{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *SessionStore) SaveSession(ctx context.Context, tokenHash string, session *domain.Session) error {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("session already expired at %s", session.ExpiresAt)
+	}
+
+	dto := SessionDto{
+		UserId:    int64(session.UserId),
+		CreatedAt: session.CreatedAt,
+		ExpiresAt: session.ExpiresAt,
+	}
+
+	userKey := userSessionsKey(session.UserId)
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(tokenHash), dto, ttl)
+		pipe.SAdd(ctx, userKey, tokenHash)
+		// index outlives every session it holds as long as sessions share ttl; stale members are harmless
+		pipe.ExpireGT(ctx, userKey, ttl)
+		pipe.ExpireNX(ctx, userKey, ttl)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not save session: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionStore) GetSession(ctx context.Context, tokenHash string) (*domain.Session, error) {
+	var dto SessionDto
+	err := s.client.Get(ctx, sessionKey(tokenHash)).Scan(&dto)
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not get session: %w", err)
+	}
+
+	if !time.Now().Before(dto.ExpiresAt) {
+		return nil, nil
+	}
+
+	session := &domain.Session{
+		UserId:    domain.UserId(dto.UserId),
+		CreatedAt: dto.CreatedAt,
+		ExpiresAt: dto.ExpiresAt,
+	}
+
+	return session, nil
+}
+
+func (s *SessionStore) DeleteSession(ctx context.Context, tokenHash string) error {
+	var dto SessionDto
+	err := s.client.Get(ctx, sessionKey(tokenHash)).Scan(&dto)
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not get session: %w", err)
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(tokenHash))
+		pipe.SRem(ctx, userSessionsKey(domain.UserId(dto.UserId)), tokenHash)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not delete session: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionStore) DeleteUserSessions(ctx context.Context, userId domain.UserId) error {
+	userKey := userSessionsKey(userId)
+
+	hashes, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return fmt.Errorf("could not list user sessions: %w", err)
+	}
+
+	keys := make([]string, 0, len(hashes)+1)
+	for _, hash := range hashes {
+		keys = append(keys, sessionKey(hash))
+	}
+
+	keys = append(keys, userKey)
+
+	err = s.client.Del(ctx, keys...).Err()
+	if err != nil {
+		return fmt.Errorf("could not delete user sessions: %w", err)
+	}
+
+	return nil
+}
+
+func sessionKey(tokenHash string) string {
+	return "cplatform:session:" + tokenHash
+}
+
+func userSessionsKey(userId domain.UserId) string {
+	return fmt.Sprintf("cplatform:user_sessions:%d", userId)
+}
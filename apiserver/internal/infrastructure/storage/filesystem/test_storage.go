@@ -0,0 +1,117 @@
+package filesystem
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"cplatform/pkg/slogext"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const dirPermissions = 0o750
+const filePermissions = 0o640
+
+// TestStorage lays tests out as <root>/testsets/<set id>/<ordinal>.<kind>
+type TestStorage struct {
+	root   string
+	logger *slog.Logger
+}
+
+func NewTestStorage(root string, logger *slog.Logger) (*TestStorage, error) {
+	if err := os.MkdirAll(filepath.Join(root, "testsets"), dirPermissions); err != nil {
+		return nil, fmt.Errorf("cannot create test storage root: %w", err)
+	}
+
+	return &TestStorage{
+		root:   root,
+		logger: logger,
+	}, nil
+}
+
+func (s *TestStorage) SaveTestFile(ctx context.Context, setId domain.TestSetId, ordinal int, kind infrastructure.TestFileKind, content io.Reader) (int64, error) {
+	dir := s.setDir(setId)
+	if err := os.MkdirAll(dir, dirPermissions); err != nil {
+		return 0, fmt.Errorf("cannot create test set dir: %w", err)
+	}
+
+	// write to temp file first so readers never observe partially written test
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("cannot create temp file: %w", err)
+	}
+
+	written, err := io.Copy(tmp, &contextReader{ctx: ctx, r: content})
+	closeErr := tmp.Close()
+
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chmod(tmp.Name(), filePermissions)
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.filePath(setId, ordinal, kind))
+	}
+
+	if err != nil {
+		if removeErr := os.Remove(tmp.Name()); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+			s.logger.Warn("fail remove temp test file", "path", tmp.Name(), slogext.Cause(removeErr))
+		}
+
+		return 0, fmt.Errorf("cannot save test file: %w", err)
+	}
+
+	return written, nil
+}
+
+func (s *TestStorage) OpenTestFile(_ context.Context, setId domain.TestSetId, ordinal int, kind infrastructure.TestFileKind) (io.ReadCloser, error) {
+	file, err := os.Open(s.filePath(setId, ordinal, kind))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: test %d of set %d", infrastructure.ErrTestFileNotFound, ordinal, setId)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot open test file: %w", err)
+	}
+
+	return file, nil
+}
+
+func (s *TestStorage) DeleteTestSet(_ context.Context, setId domain.TestSetId) error {
+	if err := os.RemoveAll(s.setDir(setId)); err != nil {
+		return fmt.Errorf("cannot delete test set: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TestStorage) setDir(setId domain.TestSetId) string {
+	return filepath.Join(s.root, "testsets", strconv.FormatInt(int64(setId), 10))
+}
+
+func (s *TestStorage) filePath(setId domain.TestSetId, ordinal int, kind infrastructure.TestFileKind) string {
+	return filepath.Join(s.setDir(setId), fmt.Sprintf("%03d.%s", ordinal, kind))
+}
+
+// contextReader stops long copies when request is cancelled
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.r.Read(p)
+}
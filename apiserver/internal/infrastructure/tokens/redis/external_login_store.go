@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"cplatform/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type ExternalLoginDto struct {
+	Provider     string    `json:"provider"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func (d ExternalLoginDto) MarshalBinary() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *ExternalLoginDto) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+type ExternalLoginStore struct {
+	client *redis.Client
+	logger *slog.Logger
+}
+
+func NewExternalLoginStore(client *redis.Client, logger *slog.Logger) *ExternalLoginStore {
+	return &ExternalLoginStore{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *ExternalLoginStore) SaveLogin(ctx context.Context, stateHash string, login *domain.ExternalLogin) error {
+	ttl := time.Until(login.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("login already expired at %s", login.ExpiresAt)
+	}
+
+	dto := ExternalLoginDto{
+		Provider:     login.Provider,
+		CodeVerifier: login.CodeVerifier,
+		Nonce:        login.Nonce,
+		ExpiresAt:    login.ExpiresAt,
+	}
+
+	err := s.client.Set(ctx, externalLoginKey(stateHash), dto, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("could not save external login: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ExternalLoginStore) ConsumeLogin(ctx context.Context, stateHash string) (*domain.ExternalLogin, error) {
+	var dto ExternalLoginDto
+	err := s.client.GetDel(ctx, externalLoginKey(stateHash)).Scan(&dto)
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not consume external login: %w", err)
+	}
+
+	if !time.Now().Before(dto.ExpiresAt) {
+		return nil, nil
+	}
+
+	login := &domain.ExternalLogin{
+		Provider:     dto.Provider,
+		CodeVerifier: dto.CodeVerifier,
+		Nonce:        dto.Nonce,
+		ExpiresAt:    dto.ExpiresAt,
+	}
+
+	return login, nil
+}
+
+func externalLoginKey(stateHash string) string {
+	return fmt.Sprintf("cplatform:external_login:%s", stateHash)
+}
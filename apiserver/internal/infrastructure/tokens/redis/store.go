@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"context"
+	"cplatform/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type TokenDto struct {
+	UserId    int64     `json:"user_id"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (d TokenDto) MarshalBinary() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *TokenDto) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+type TokenStore struct {
+	client *redis.Client
+	logger *slog.Logger
+}
+
+func NewTokenStore(client *redis.Client, logger *slog.Logger) *TokenStore {
+	return &TokenStore{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *TokenStore) SaveToken(ctx context.Context, tokenHash string, token *domain.OneTimeToken) error {
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("token already expired at %s", token.ExpiresAt)
+	}
+
+	dto := TokenDto{
+		UserId:    int64(token.UserId),
+		Email:     token.Email,
+		ExpiresAt: token.ExpiresAt,
+	}
+
+	err := s.client.Set(ctx, tokenKey(token.Purpose, tokenHash), dto, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("could not save token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TokenStore) ConsumeToken(ctx context.Context, purpose domain.TokenPurpose, tokenHash string) (*domain.OneTimeToken, error) {
+	var dto TokenDto
+	err := s.client.GetDel(ctx, tokenKey(purpose, tokenHash)).Scan(&dto)
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not consume token: %w", err)
+	}
+
+	if !time.Now().Before(dto.ExpiresAt) {
+		return nil, nil
+	}
+
+	token := &domain.OneTimeToken{
+		Purpose:   purpose,
+		UserId:    domain.UserId(dto.UserId),
+		Email:     dto.Email,
+		ExpiresAt: dto.ExpiresAt,
+	}
+
+	return token, nil
+}
+
+func tokenKey(purpose domain.TokenPurpose, tokenHash string) string {
+	return fmt.Sprintf("cplatform:token:%s:%s", purpose, tokenHash)
+}
@@ -0,0 +1,45 @@
+package checker
+
+import (
+	"context"
+	"cplatform/internal/domain"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrCheckerFailed means checker itself misbehaved, so no verdict can be given
+var ErrCheckerFailed = errors.New("checker failed")
+
+type Verdict struct {
+	Accepted bool
+	// Message explains rejection and is shown to contestant; builtin checkers never quote answer
+	Message string
+}
+
+type Checker interface {
+	// Check compares contestant output with answer; input is given for checkers whose answer depends on test
+	Check(ctx context.Context, input io.Reader, output io.Reader, answer io.Reader) (*Verdict, error)
+}
+
+// NewBuiltin returns checker of kind which needs no compilation; custom checkers are created with NewCustom
+func NewBuiltin(kind domain.CheckerKind, absEpsilon float64, relEpsilon float64) (Checker, error) {
+	switch kind {
+	case domain.CheckerExact:
+		return &Exact{}, nil
+	case domain.CheckerTokens:
+		return &Tokens{}, nil
+	case domain.CheckerFloat:
+		return &Float{AbsEpsilon: absEpsilon, RelEpsilon: relEpsilon}, nil
+	default:
+		return nil, fmt.Errorf("checker %q is not builtin", kind)
+	}
+}
+
+func accepted() *Verdict {
+	return &Verdict{Accepted: true}
+}
+
+func rejected(format string, args ...any) *Verdict {
+	return &Verdict{Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,124 @@
+package checker
+
+import (
+	"context"
+	"cplatform/internal/judge/sandbox"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const maxCheckerMessageBytes = 1024
+
+// testlib exit codes which mean rejected output rather than broken checker
+const (
+	testlibWrongAnswer       = 1
+	testlibPresentationError = 2
+)
+
+var customLimits = sandbox.Limits{
+	CpuTime:     10 * time.Second,
+	WallTime:    20 * time.Second,
+	MemoryBytes: 512 * 1024 * 1024,
+	Processes:   1,
+	OutputBytes: 1024 * 1024,
+	OpenFiles:   16,
+}
+
+// Custom runs compiled testlib style checker in sandbox as `checker input output answer`,
+// exit code 0 accepts output, 1 and 2 reject it, anything else is checker failure
+type Custom struct {
+	runner  sandbox.Runner
+	binary  string
+	workDir string
+}
+
+// NewCustom creates checker running binary; workDir receives temporary copies of checked files
+// and must be accessible by sandbox user
+func NewCustom(runner sandbox.Runner, binary string, workDir string) *Custom {
+	return &Custom{
+		runner:  runner,
+		binary:  binary,
+		workDir: workDir,
+	}
+}
+
+func (c *Custom) Check(ctx context.Context, input io.Reader, output io.Reader, answer io.Reader) (*Verdict, error) {
+	dir, err := os.MkdirTemp(c.workDir, "check-")
+	if err != nil {
+		return nil, fmt.Errorf("fail create checker dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// checker only reads files, so root owned directory open for reading is enough
+	if err := os.Chmod(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("fail chmod checker dir: %w", err)
+	}
+
+	files := []struct {
+		name    string
+		content io.Reader
+	}{
+		{"input.txt", input},
+		{"output.txt", output},
+		{"answer.txt", answer},
+	}
+
+	args := []string{c.binary}
+	for _, file := range files {
+		if err := writeFile(filepath.Join(dir, file.name), file.content); err != nil {
+			return nil, err
+		}
+
+		args = append(args, file.name)
+	}
+
+	message := sandbox.NewLimitedBuffer(maxCheckerMessageBytes)
+
+	run, err := c.runner.Run(ctx, &sandbox.Spec{
+		Args: args,
+		Dir:  dir,
+		// binary is shared between runs and lives outside of dir
+		ReadOnly: []string{filepath.Dir(c.binary)},
+		Stdout:   io.Discard,
+		Stderr:   message,
+		Limits:   customLimits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fail run checker: %w", err)
+	}
+
+	text := strings.TrimSpace(message.String())
+
+	if run.Status == sandbox.StatusOk {
+		return accepted(), nil
+	}
+
+	if run.Status == sandbox.StatusRuntimeError && run.Signal == "" &&
+		(run.ExitCode == testlibWrongAnswer || run.ExitCode == testlibPresentationError) {
+		return &Verdict{Message: text}, nil
+	}
+
+	return nil, fmt.Errorf("%w: status %s, exit code %d, signal %q: %s", ErrCheckerFailed, run.Status, run.ExitCode, run.Signal, text)
+}
+
+func writeFile(path string, content io.Reader) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("fail create %s: %w", filepath.Base(path), err)
+	}
+
+	_, err = io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("fail write %s: %w", filepath.Base(path), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+const exactChunkSize = 64 * 1024
+
+// Exact accepts output only if it is byte to byte equal to answer
+type Exact struct{}
+
+func (c *Exact) Check(_ context.Context, _ io.Reader, output io.Reader, answer io.Reader) (*Verdict, error) {
+	outputChunk := make([]byte, exactChunkSize)
+	answerChunk := make([]byte, exactChunkSize)
+
+	var offset int64
+	for {
+		outputRead, outputErr := io.ReadFull(output, outputChunk)
+		answerRead, answerErr := io.ReadFull(answer, answerChunk)
+
+		if err := unexpectedReadError(outputErr); err != nil {
+			return nil, err
+		}
+
+		if err := unexpectedReadError(answerErr); err != nil {
+			return nil, err
+		}
+
+		common := min(outputRead, answerRead)
+		if i := mismatchIndex(outputChunk[:common], answerChunk[:common]); i >= 0 {
+			return rejected("output differs from answer at byte %d", offset+int64(i)), nil
+		}
+
+		if outputRead != answerRead {
+			if outputRead < answerRead {
+				return rejected("output is shorter than answer"), nil
+			}
+
+			return rejected("output is longer than answer"), nil
+		}
+
+		// chunks have equal length, so short chunk means both streams ended
+		if outputErr != nil {
+			return accepted(), nil
+		}
+
+		offset += int64(outputRead)
+	}
+}
+
+func mismatchIndex(a []byte, b []byte) int {
+	if bytes.Equal(a, b) {
+		return -1
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unexpectedReadError(err error) error {
+	if err == nil || err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,92 @@
+package checker
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"math"
+	"strconv"
+)
+
+const maxTokenSize = 16 * 1024 * 1024
+
+// Tokens accepts output whose whitespace separated tokens equal tokens of answer
+type Tokens struct{}
+
+func (c *Tokens) Check(_ context.Context, _ io.Reader, output io.Reader, answer io.Reader) (*Verdict, error) {
+	return compareTokens(output, answer, func(out string, ans string) bool {
+		return out == ans
+	})
+}
+
+// Float is Tokens which treats numeric tokens as equal when absolute error does not exceed AbsEpsilon
+// or relative error does not exceed RelEpsilon; zero epsilon disables its comparison
+type Float struct {
+	AbsEpsilon float64
+	RelEpsilon float64
+}
+
+func (c *Float) Check(_ context.Context, _ io.Reader, output io.Reader, answer io.Reader) (*Verdict, error) {
+	return compareTokens(output, answer, func(out string, ans string) bool {
+		if out == ans {
+			return true
+		}
+
+		expected, err := strconv.ParseFloat(ans, 64)
+		if err != nil {
+			return false
+		}
+
+		actual, err := strconv.ParseFloat(out, 64)
+		if err != nil || math.IsNaN(actual) || math.IsInf(actual, 0) {
+			return false
+		}
+
+		diff := math.Abs(actual - expected)
+
+		return diff <= c.AbsEpsilon || diff <= c.RelEpsilon*math.Abs(expected)
+	})
+}
+
+func compareTokens(output io.Reader, answer io.Reader, equal func(out string, ans string) bool) (*Verdict, error) {
+	outputTokens := newTokenScanner(output)
+	answerTokens := newTokenScanner(answer)
+
+	for index := 1; ; index++ {
+		hasOutput := outputTokens.Scan()
+		hasAnswer := answerTokens.Scan()
+
+		if err := outputTokens.Err(); err != nil {
+			if err == bufio.ErrTooLong {
+				return rejected("token %d of output is too long", index), nil
+			}
+
+			return nil, err
+		}
+
+		if err := answerTokens.Err(); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case !hasOutput && !hasAnswer:
+			return accepted(), nil
+		case !hasOutput:
+			return rejected("output ended after %d tokens, more expected", index-1), nil
+		case !hasAnswer:
+			return rejected("output has extra tokens starting from token %d", index), nil
+		}
+
+		if !equal(outputTokens.Text(), answerTokens.Text()) {
+			return rejected("token %d differs from answer", index), nil
+		}
+	}
+}
+
+func newTokenScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxTokenSize)
+	scanner.Split(bufio.ScanWords)
+
+	return scanner
+}
@@ -0,0 +1,113 @@
+package checker
+
+import (
+	"context"
+	"cplatform/internal/domain"
+	"strings"
+	"testing"
+)
+
+type checkCase struct {
+	name         string
+	output       string
+	answer       string
+	wantAccepted bool
+	wantMessage  string
+}
+
+func runCheckCases(t *testing.T, checker Checker, tests []checkCase) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, err := checker.Check(context.Background(), strings.NewReader(""), strings.NewReader(tt.output), strings.NewReader(tt.answer))
+			if err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+
+			if verdict.Accepted != tt.wantAccepted {
+				t.Errorf("accepted = %v, want %v (message %q)", verdict.Accepted, tt.wantAccepted, verdict.Message)
+			}
+
+			if verdict.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", verdict.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestTokens(t *testing.T) {
+	runCheckCases(t, &Tokens{}, []checkCase{
+		{name: "equal", output: "1 2 3\n", answer: "1 2 3\n", wantAccepted: true},
+		{name: "whitespace ignored", output: "  1\t2\r\n\n3", answer: "1 2 3\n", wantAccepted: true},
+		{name: "both empty", output: "", answer: "\n", wantAccepted: true},
+		{name: "differs", output: "1 2 4", answer: "1 2 3", wantMessage: "token 3 differs from answer"},
+		{name: "numbers compared as text", output: "1.0", answer: "1", wantMessage: "token 1 differs from answer"},
+		{name: "case sensitive", output: "yes", answer: "YES", wantMessage: "token 1 differs from answer"},
+		{name: "short", output: "1 2", answer: "1 2 3", wantMessage: "output ended after 2 tokens, more expected"},
+		{name: "extra", output: "1 2 3 4", answer: "1 2 3", wantMessage: "output has extra tokens starting from token 4"},
+	})
+}
+
+func TestFloat(t *testing.T) {
+	tests := []struct {
+		name    string
+		checker *Float
+		cases   []checkCase
+	}{
+		{
+			name:    "defaults",
+			checker: &Float{AbsEpsilon: domain.DefaultCheckerAbsEpsilon, RelEpsilon: domain.DefaultCheckerRelEpsilon},
+			cases: []checkCase{
+				{name: "equal text", output: "abc 1.5", answer: "abc 1.5", wantAccepted: true},
+				{name: "within absolute", output: "0.1000005", answer: "0.1", wantAccepted: true},
+				{name: "within relative", output: "1000000.9", answer: "1000000", wantAccepted: true},
+				{name: "outside both", output: "1.00001", answer: "1", wantMessage: "token 1 differs from answer"},
+				{name: "exponent notation", output: "1e-3", answer: "0.001", wantAccepted: true},
+				{name: "word against number", output: "one", answer: "1", wantMessage: "token 1 differs from answer"},
+				{name: "word differs", output: "1 yes", answer: "1 no", wantMessage: "token 2 differs from answer"},
+				{name: "nan", output: "nan", answer: "1", wantMessage: "token 1 differs from answer"},
+				{name: "infinity", output: "inf", answer: "1e308", wantMessage: "token 1 differs from answer"},
+				{name: "short", output: "1", answer: "1 2", wantMessage: "output ended after 1 tokens, more expected"},
+			},
+		},
+		{
+			name:    "absolute only",
+			checker: &Float{AbsEpsilon: 1e-3},
+			cases: []checkCase{
+				{name: "within", output: "2.0009", answer: "2", wantAccepted: true},
+				{name: "large value needs absolute precision", output: "1000000.01", answer: "1000000", wantMessage: "token 1 differs from answer"},
+			},
+		},
+		{
+			name:    "relative only",
+			checker: &Float{RelEpsilon: 1e-3},
+			cases: []checkCase{
+				{name: "within", output: "1000.9", answer: "1000", wantAccepted: true},
+				{name: "zero answer needs exact output", output: "0.0000001", answer: "0", wantMessage: "token 1 differs from answer"},
+				{name: "negative", output: "-1000.9", answer: "-1000", wantAccepted: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCheckCases(t, tt.checker, tt.cases)
+		})
+	}
+}
+
+func TestNewBuiltin(t *testing.T) {
+	checker, err := NewBuiltin(domain.CheckerFloat, 0.5, 0.25)
+	if err != nil {
+		t.Fatalf("NewBuiltin: %v", err)
+	}
+
+	if float, ok := checker.(*Float); !ok || float.AbsEpsilon != 0.5 || float.RelEpsilon != 0.25 {
+		t.Errorf("NewBuiltin(float) = %#v", checker)
+	}
+
+	if _, err := NewBuiltin(domain.CheckerCustom, 0, 0); err == nil {
+		t.Error("NewBuiltin(custom) succeeded, custom checker needs compilation")
+	}
+}
@@ -0,0 +1,102 @@
+package judge
+
+import (
+	"context"
+	"cplatform/internal/domain"
+	"cplatform/internal/judge/checker"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+)
+
+const (
+	checkersDir      = "checkers"
+	checkerSource    = "checker.cpp"
+	checkerBinary    = "checker"
+	checkerBuildMode = 0o755
+)
+
+// checker sources include testlib.h, which judge image puts on default include path
+var checkerCompileCommand = []string{"g++", "-std=c++17", "-O2", "-pipe", "-static", "-o", checkerBinary, checkerSource}
+
+func (w *Worker) prepareChecker(ctx context.Context, t *task) (checker.Checker, error) {
+	if t.revision.Checker != domain.CheckerCustom {
+		return checker.NewBuiltin(t.revision.Checker, t.revision.CheckerAbsEpsilon, t.revision.CheckerRelEpsilon)
+	}
+
+	if t.tests.CheckerSource == "" {
+		return nil, fmt.Errorf("problem %d revision %d uses custom checker, but test set %d has none", t.revision.ProblemId, t.revision.Revision, t.tests.Id)
+	}
+
+	binary, err := w.compiledChecker(ctx, t.tests)
+	if err != nil {
+		return nil, err
+	}
+
+	return checker.NewCustom(w.runner, binary, t.dir), nil
+}
+
+// compiledChecker returns path of checker binary, compiling it on first use; test sets are immutable,
+// so binary is cached by test set id and shared between workers
+func (w *Worker) compiledChecker(ctx context.Context, set *domain.TestSet) (string, error) {
+	root := filepath.Join(w.config.WorkDir, checkersDir)
+	target := filepath.Join(root, strconv.FormatInt(int64(set.Id), 10))
+	binary := filepath.Join(target, checkerBinary)
+
+	if _, err := os.Stat(binary); err == nil {
+		return binary, nil
+	}
+
+	if err := os.MkdirAll(root, 0o711); err != nil {
+		return "", fmt.Errorf("fail create checkers dir: %w", err)
+	}
+
+	dir, err := os.MkdirTemp(root, "build-")
+	if err != nil {
+		return "", fmt.Errorf("fail create checker build dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	sourcePath := filepath.Join(dir, checkerSource)
+
+	err = os.WriteFile(sourcePath, []byte(set.CheckerSource), 0o644)
+	if err == nil {
+		err = os.Chown(dir, w.config.Uid, w.config.Gid)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("fail prepare checker build dir: %w", err)
+	}
+
+	compileLog, ok, err := w.compile(ctx, checkerCompileCommand, dir)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		return "", fmt.Errorf("%w: compilation of test set %d checker failed: %s", checker.ErrCheckerFailed, set.Id, compileLog)
+	}
+
+	// submissions run as the same sandbox user, so binary must not stay writable by it
+	for _, path := range []string{filepath.Join(dir, checkerBinary), dir} {
+		if err := os.Chown(path, 0, 0); err != nil {
+			return "", fmt.Errorf("fail take ownership of checker: %w", err)
+		}
+
+		if err := os.Chmod(path, checkerBuildMode); err != nil {
+			return "", fmt.Errorf("fail chmod checker: %w", err)
+		}
+	}
+
+	// concurrent worker may have finished the same build first, its binary is as good as ours
+	err = os.Rename(dir, target)
+	if err != nil && !errors.Is(err, fs.ErrExist) && !errors.Is(err, syscall.ENOTEMPTY) {
+		return "", fmt.Errorf("fail publish checker: %w", err)
+	}
+
+	return binary, nil
+}
@@ -0,0 +1,89 @@
+package judge
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/pkg/slogext"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// requeueStaleAfter is how long submission may stay queued before it is assumed lost, e.g. api server failed to enqueue it
+	requeueStaleAfter = 5 * time.Minute
+	requeueInterval   = time.Minute
+	requeueBatch      = 100
+)
+
+// Requeuer puts back to queue submissions left queued without job; duplicate job of submission still waiting in queue
+// is harmless, as only one worker can claim submission
+type Requeuer struct {
+	queue      infrastructure.JobQueue
+	uowFactory infrastructure.UnitOfWorkFactory
+	logger     *slog.Logger
+}
+
+func NewRequeuer(queue infrastructure.JobQueue, uowFactory infrastructure.UnitOfWorkFactory, logger *slog.Logger) *Requeuer {
+	return &Requeuer{
+		queue:      queue,
+		uowFactory: uowFactory,
+		logger:     logger,
+	}
+}
+
+// Run sweeps stale submissions at start and then periodically until ctx is done
+func (r *Requeuer) Run(ctx context.Context) {
+	ticker := time.NewTicker(requeueInterval)
+	defer ticker.Stop()
+
+	for {
+		count, err := r.requeue(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error("judge: fail requeue stale submissions", slogext.Cause(err))
+		}
+
+		if count > 0 {
+			r.logger.Warn("judge: stale submissions requeued", slog.Int("count", count))
+		}
+
+		// full batch means more stale submissions are likely left
+		if count == requeueBatch {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// requeue enqueues jobs before commit, so submission which failed to enqueue stays stale and is retried on next sweep
+func (r *Requeuer) requeue(ctx context.Context) (int, error) {
+	uow, err := r.uowFactory.TryCreate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer uow.Close(context.WithoutCancel(ctx))
+
+	ids, err := uow.SubmissionRepository(ctx).RequeueStaleSubmissions(ctx, time.Now().Add(-requeueStaleAfter), requeueBatch)
+	if err != nil {
+		return 0, fmt.Errorf("fail take stale submissions: %w", err)
+	}
+
+	for _, id := range ids {
+		err := r.queue.Enqueue(ctx, &infrastructure.JudgeJob{SubmissionId: id})
+		if err != nil {
+			return 0, fmt.Errorf("fail enqueue submission %d: %w", id, err)
+		}
+	}
+
+	err = uow.SaveChanges(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("fail commit requeued submissions: %w", err)
+	}
+
+	return len(ids), nil
+}
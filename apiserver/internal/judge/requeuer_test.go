@@ -0,0 +1,138 @@
+package judge
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"cplatform/internal/infrastructure/queue/memory"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeUnitOfWork serves only submission repository; other methods panic through nil embedded interface
+type fakeUnitOfWork struct {
+	infrastructure.UnitOfWork
+	submissions *fakeSubmissionRepository
+	committed   bool
+}
+
+func (u *fakeUnitOfWork) SubmissionRepository(context.Context) infrastructure.SubmissionRepository {
+	return u.submissions
+}
+
+func (u *fakeUnitOfWork) SaveChanges(context.Context) error {
+	u.committed = true
+	return nil
+}
+
+func (u *fakeUnitOfWork) Close(context.Context) error {
+	return nil
+}
+
+type fakeUnitOfWorkFactory struct {
+	uow *fakeUnitOfWork
+}
+
+func (f *fakeUnitOfWorkFactory) Create(context.Context) infrastructure.UnitOfWork {
+	return f.uow
+}
+
+func (f *fakeUnitOfWorkFactory) CreateWithIsolationLevel(context.Context, pgx.TxIsoLevel) infrastructure.UnitOfWork {
+	return f.uow
+}
+
+func (f *fakeUnitOfWorkFactory) TryCreate(context.Context) (infrastructure.UnitOfWork, error) {
+	return f.uow, nil
+}
+
+type fakeSubmissionRepository struct {
+	infrastructure.SubmissionRepository
+	stale  []domain.SubmissionId
+	err    error
+	before time.Time
+	limit  int
+}
+
+func (r *fakeSubmissionRepository) RequeueStaleSubmissions(_ context.Context, before time.Time, limit int) ([]domain.SubmissionId, error) {
+	r.before = before
+	r.limit = limit
+
+	return r.stale, r.err
+}
+
+func TestRequeue(t *testing.T) {
+	errDatabase := errors.New("database is down")
+
+	tests := []struct {
+		name          string
+		stale         []domain.SubmissionId
+		err           error
+		wantCount     int
+		wantEnqueued  []domain.SubmissionId
+		wantCommitted bool
+	}{
+		{
+			name:          "nothing stale",
+			wantCommitted: true,
+		},
+		{
+			name:          "stale submissions",
+			stale:         []domain.SubmissionId{4, 9},
+			wantCount:     2,
+			wantEnqueued:  []domain.SubmissionId{4, 9},
+			wantCommitted: true,
+		},
+		{
+			name: "repository failure",
+			err:  errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			queue := memory.NewJobQueue()
+			uow := &fakeUnitOfWork{submissions: &fakeSubmissionRepository{stale: tt.stale, err: tt.err}}
+			requeuer := NewRequeuer(queue, &fakeUnitOfWorkFactory{uow: uow}, slog.New(slog.DiscardHandler))
+
+			startedAt := time.Now()
+			count, err := requeuer.requeue(ctx)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("requeue error = %v, want %v", err, tt.err)
+			}
+
+			if count != tt.wantCount {
+				t.Errorf("count = %d, want %d", count, tt.wantCount)
+			}
+
+			if uow.committed != tt.wantCommitted {
+				t.Errorf("committed = %v, want %v", uow.committed, tt.wantCommitted)
+			}
+
+			if uow.submissions.limit != requeueBatch || uow.submissions.before.Before(startedAt.Add(-requeueStaleAfter)) || uow.submissions.before.After(time.Now().Add(-requeueStaleAfter)) {
+				t.Errorf("stale query before %v limit %d", uow.submissions.before, uow.submissions.limit)
+			}
+
+			var enqueued []domain.SubmissionId
+			for queue.Len() > 0 {
+				job, err := queue.Dequeue(ctx)
+				if err != nil {
+					t.Fatalf("Dequeue: %v", err)
+				}
+
+				enqueued = append(enqueued, job.SubmissionId)
+			}
+
+			if !slices.Equal(enqueued, tt.wantEnqueued) {
+				t.Errorf("enqueued = %v, want %v", enqueued, tt.wantEnqueued)
+			}
+		})
+	}
+}
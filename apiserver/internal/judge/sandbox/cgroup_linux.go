@@ -0,0 +1,232 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const cgroupControllers = "+memory +pids"
+
+// supervisorGroup receives processes living in delegated parent, cgroup v2 forbids
+// enabling controllers for children while parent has processes of its own
+const supervisorGroup = "supervisor"
+
+type cgroupManager struct {
+	root string
+}
+
+type cgroup struct {
+	path string
+	fd   int
+}
+
+type cgroupStats struct {
+	cpuTime     time.Duration
+	memoryPeak  int64
+	oomKills    int64
+	hasPeakInfo bool
+}
+
+func newCgroupManager(root string) (*cgroupManager, error) {
+	parent := filepath.Dir(root)
+
+	if err := enableControllers(parent); err != nil {
+		if !errors.Is(err, syscall.EBUSY) {
+			return nil, err
+		}
+
+		if err := moveProcesses(parent, filepath.Join(parent, supervisorGroup)); err != nil {
+			return nil, err
+		}
+
+		if err := enableControllers(parent); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create cgroup root: %w", err)
+	}
+
+	if err := enableControllers(root); err != nil {
+		return nil, err
+	}
+
+	return &cgroupManager{root: root}, nil
+}
+
+func (m *cgroupManager) create(name string, limits Limits) (*cgroup, error) {
+	path := filepath.Join(m.root, name)
+	if err := os.Mkdir(path, 0o755); err != nil {
+		return nil, fmt.Errorf("create cgroup: %w", err)
+	}
+
+	group := &cgroup{path: path, fd: -1}
+
+	settings := make(map[string]string)
+	if limits.MemoryBytes > 0 {
+		settings["memory.max"] = strconv.FormatInt(limits.MemoryBytes, 10)
+		settings["memory.swap.max"] = "0"
+	}
+
+	if limits.Processes > 0 {
+		settings["pids.max"] = strconv.FormatInt(limits.Processes, 10)
+	}
+
+	for file, value := range settings {
+		err := os.WriteFile(filepath.Join(path, file), []byte(value), 0)
+
+		// swap accounting may be disabled by kernel, memory.max alone still holds
+		if err != nil && !(file == "memory.swap.max" && errors.Is(err, fs.ErrNotExist)) {
+			group.destroy()
+			return nil, fmt.Errorf("write %s: %w", file, err)
+		}
+	}
+
+	fd, err := syscall.Open(path, syscall.O_DIRECTORY|syscall.O_RDONLY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		group.destroy()
+		return nil, fmt.Errorf("open cgroup: %w", err)
+	}
+
+	group.fd = fd
+
+	return group, nil
+}
+
+// kill terminates every process in group including ones that escaped process tree
+func (g *cgroup) kill() {
+	_ = os.WriteFile(filepath.Join(g.path, "cgroup.kill"), []byte("1"), 0)
+}
+
+func (g *cgroup) stats() (cgroupStats, error) {
+	var stats cgroupStats
+
+	cpu, err := readKeyedFile(filepath.Join(g.path, "cpu.stat"))
+	if err != nil {
+		return stats, err
+	}
+
+	stats.cpuTime = time.Duration(cpu["usage_usec"]) * time.Microsecond
+
+	events, err := readKeyedFile(filepath.Join(g.path, "memory.events"))
+	if err != nil {
+		return stats, err
+	}
+
+	stats.oomKills = events["oom_kill"]
+
+	// memory.peak appeared in linux 5.19
+	peak, err := os.ReadFile(filepath.Join(g.path, "memory.peak"))
+	if err == nil {
+		stats.memoryPeak, err = strconv.ParseInt(strings.TrimSpace(string(peak)), 10, 64)
+		stats.hasPeakInfo = err == nil
+	}
+
+	return stats, nil
+}
+
+func (g *cgroup) destroy() {
+	if g.fd >= 0 {
+		_ = syscall.Close(g.fd)
+		g.fd = -1
+	}
+
+	g.kill()
+
+	// killed processes leave group asynchronously, so removal is retried for a while
+	for attempt := 0; attempt < 100; attempt++ {
+		err := os.Remove(g.path)
+		if err == nil || errors.Is(err, fs.ErrNotExist) {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func enableControllers(path string) error {
+	current, err := os.ReadFile(filepath.Join(path, "cgroup.subtree_control"))
+	if err != nil {
+		return fmt.Errorf("read subtree controllers of %s: %w", path, err)
+	}
+
+	missing := make([]string, 0)
+	for _, controller := range strings.Fields(cgroupControllers) {
+		if !strings.Contains(string(current), strings.TrimPrefix(controller, "+")) {
+			missing = append(missing, controller)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	err = os.WriteFile(filepath.Join(path, "cgroup.subtree_control"), []byte(strings.Join(missing, " ")), 0)
+	if err != nil {
+		return fmt.Errorf("enable controllers in %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func moveProcesses(from string, to string) error {
+	if err := os.MkdirAll(to, 0o755); err != nil {
+		return fmt.Errorf("create supervisor cgroup: %w", err)
+	}
+
+	procs, err := os.ReadFile(filepath.Join(from, "cgroup.procs"))
+	if err != nil {
+		return fmt.Errorf("read cgroup processes: %w", err)
+	}
+
+	for _, pid := range strings.Fields(string(procs)) {
+		err := os.WriteFile(filepath.Join(to, "cgroup.procs"), []byte(pid), 0)
+
+		// process may exit between listing and moving
+		if err != nil && !errors.Is(err, syscall.ESRCH) {
+			return fmt.Errorf("move process %s to supervisor cgroup: %w", pid, err)
+		}
+	}
+
+	return nil
+}
+
+func readKeyedFile(path string) (map[string]int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	values := make(map[string]int64)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		value, err := strconv.ParseInt(fields[1], 10, 64)
+		if err == nil {
+			values[fields[0]] = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,109 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+const initEnv = "CPLATFORM_SANDBOX_INIT"
+
+// initErrorFd is write end of pipe which tells parent that init failed before exec
+const initErrorFd = 3
+
+type initConfig struct {
+	CpuSeconds uint64 `json:"cpu_seconds"`
+	FileSize   uint64 `json:"file_size"`
+	OpenFiles  uint64 `json:"open_files"`
+	Stack      uint64 `json:"stack"`
+	Uid        int    `json:"uid"`
+	Gid        int    `json:"gid"`
+	// RootDir is mount point of per-run root, Dir and ReadOnly are host paths bound into it at the same paths
+	RootDir  string   `json:"root_dir"`
+	Dir      string   `json:"dir"`
+	ReadOnly []string `json:"read_only"`
+}
+
+// RunInit prepares namespaces created by Sandbox.Run, applies rlimits, drops privileges
+// and replaces itself with sandboxed program; it returns only by exiting on failure
+func RunInit() {
+	err := initAndExec()
+
+	errPipe := os.NewFile(initErrorFd, "sandbox-init-error")
+	if errPipe != nil {
+		_, _ = fmt.Fprint(errPipe, err.Error())
+	}
+
+	os.Exit(127)
+}
+
+func initAndExec() error {
+	var config initConfig
+	if err := json.Unmarshal([]byte(os.Getenv(initEnv)), &config); err != nil {
+		return fmt.Errorf("invalid init config: %w", err)
+	}
+
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, initEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+
+	syscall.CloseOnExec(initErrorFd)
+
+	// private propagation keeps mounts of per-run root inside new mount namespace; pivot_root requires it as well
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("make mounts private: %w", err)
+	}
+
+	if err := enterRoot(&config); err != nil {
+		return err
+	}
+
+	limits := []struct {
+		resource int
+		value    uint64
+	}{
+		{syscall.RLIMIT_CPU, config.CpuSeconds},
+		{syscall.RLIMIT_FSIZE, config.FileSize},
+		{syscall.RLIMIT_NOFILE, config.OpenFiles},
+		{syscall.RLIMIT_STACK, config.Stack},
+		{syscall.RLIMIT_CORE, 0},
+	}
+
+	for _, limit := range limits {
+		if limit.value == 0 && limit.resource != syscall.RLIMIT_CORE {
+			continue
+		}
+
+		rlimit := &syscall.Rlimit{Cur: limit.value, Max: limit.value}
+		if err := syscall.Setrlimit(limit.resource, rlimit); err != nil {
+			return fmt.Errorf("set rlimit %d: %w", limit.resource, err)
+		}
+	}
+
+	if err := syscall.Setgroups([]int{}); err != nil {
+		return fmt.Errorf("drop supplementary groups: %w", err)
+	}
+
+	if err := syscall.Setgid(config.Gid); err != nil {
+		return fmt.Errorf("set gid: %w", err)
+	}
+
+	if err := syscall.Setuid(config.Uid); err != nil {
+		return fmt.Errorf("set uid: %w", err)
+	}
+
+	path, err := exec.LookPath(os.Args[1])
+	if err != nil {
+		return fmt.Errorf("look up program: %w", err)
+	}
+
+	return fmt.Errorf("exec program: %w", syscall.Exec(path, os.Args[1:], env))
+}
@@ -0,0 +1,222 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// hostSystemDirs are bound read-only into per-run root, so compilers and interpreters find their files;
+// missing ones are skipped and symlinks, like /bin on merged /usr systems, are recreated as symlinks
+var hostSystemDirs = []string{"/bin", "/sbin", "/usr", "/lib", "/lib32", "/lib64", "/libx32", "/etc", "/opt"}
+
+var hostDevices = []string{"null", "zero", "full", "random", "urandom"}
+
+// tmpfsOptions bound /tmp and /dev/shm; pages written there are charged to memory cgroup of run as well
+const tmpfsOptions = "mode=1777,size=256m"
+
+const oldRootDir = ".oldroot"
+
+const (
+	bindReadOnly = syscall.MS_RDONLY | syscall.MS_NOSUID | syscall.MS_NODEV
+	bindWritable = syscall.MS_NOSUID | syscall.MS_NODEV
+	// bindDevice keeps device semantics, devices are useless on nodev mount
+	bindDevice = syscall.MS_NOSUID | syscall.MS_NOEXEC
+)
+
+// enterRoot builds minimal root on fresh tmpfs mounted over config.RootDir and pivots into it;
+// host is then visible only through read-only system directories, read-only config.ReadOnly paths and writable config.Dir,
+// while /tmp and /dev/shm are empty tmpfs which disappear together with mount namespace
+func enterRoot(config *initConfig) error {
+	root := config.RootDir
+
+	if err := syscall.Mount("tmpfs", root, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "mode=0755,size=1m"); err != nil {
+		return fmt.Errorf("mount root tmpfs: %w", err)
+	}
+
+	for _, dir := range hostSystemDirs {
+		if err := bindSystemDir(root, dir); err != nil {
+			return err
+		}
+	}
+
+	if err := mountDev(root); err != nil {
+		return err
+	}
+
+	if err := mountFresh(root, "/tmp", "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, tmpfsOptions); err != nil {
+		return err
+	}
+
+	if err := mountFresh(root, "/proc", "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+		return err
+	}
+
+	for _, path := range config.ReadOnly {
+		if err := bind(path, filepath.Join(root, path), bindReadOnly); err != nil {
+			return err
+		}
+	}
+
+	if err := bind(config.Dir, filepath.Join(root, config.Dir), bindWritable); err != nil {
+		return err
+	}
+
+	if err := pivot(root); err != nil {
+		return err
+	}
+
+	// mount points are in place, nothing else may be created outside of writable mounts
+	if err := syscall.Mount("", "/", "", syscall.MS_REMOUNT|syscall.MS_RDONLY|syscall.MS_NOSUID|syscall.MS_NODEV, ""); err != nil {
+		return fmt.Errorf("remount root read-only: %w", err)
+	}
+
+	if err := syscall.Chdir(config.Dir); err != nil {
+		return fmt.Errorf("enter work dir: %w", err)
+	}
+
+	return nil
+}
+
+func bindSystemDir(root string, dir string) error {
+	info, err := os.Lstat(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", dir, err)
+	}
+
+	if info.Mode()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(dir)
+		if err != nil {
+			return fmt.Errorf("read link %s: %w", dir, err)
+		}
+
+		if err := os.Symlink(target, filepath.Join(root, dir)); err != nil {
+			return fmt.Errorf("recreate link %s: %w", dir, err)
+		}
+
+		return nil
+	}
+
+	return bind(dir, filepath.Join(root, dir), bindReadOnly)
+}
+
+func mountDev(root string) error {
+	dev := filepath.Join(root, "dev")
+
+	if err := mountFresh(root, "/dev", "tmpfs", syscall.MS_NOSUID|syscall.MS_NOEXEC, "mode=0755,size=64k"); err != nil {
+		return err
+	}
+
+	for _, device := range hostDevices {
+		if err := bind(filepath.Join("/dev", device), filepath.Join(dev, device), bindDevice); err != nil {
+			return err
+		}
+	}
+
+	links := map[string]string{
+		"fd":     "/proc/self/fd",
+		"stdin":  "/proc/self/fd/0",
+		"stdout": "/proc/self/fd/1",
+		"stderr": "/proc/self/fd/2",
+	}
+
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(dev, name)); err != nil {
+			return fmt.Errorf("create /dev/%s: %w", name, err)
+		}
+	}
+
+	return mountFresh(root, "/dev/shm", "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, tmpfsOptions)
+}
+
+// mountFresh mounts new filesystem of fstype at path inside root
+func mountFresh(root string, path string, fstype string, flags uintptr, options string) error {
+	target := filepath.Join(root, path)
+
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+
+	if err := syscall.Mount(fstype, target, fstype, flags, options); err != nil {
+		return fmt.Errorf("mount %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// bind mounts source at target with flags; bind mount ignores flags on creation, so they are applied by remount
+func bind(source string, target string, flags uintptr) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", source, err)
+	}
+
+	if info.IsDir() {
+		err = os.MkdirAll(target, 0o755)
+	} else {
+		err = createFile(target)
+	}
+
+	if err != nil {
+		return fmt.Errorf("create mount point for %s: %w", source, err)
+	}
+
+	if err := syscall.Mount(source, target, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("bind %s: %w", source, err)
+	}
+
+	if err := syscall.Mount("", target, "", syscall.MS_REMOUNT|syscall.MS_BIND|flags, ""); err != nil {
+		return fmt.Errorf("remount %s: %w", source, err)
+	}
+
+	return nil
+}
+
+func createFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// pivot makes root new / and detaches old one, so host filesystem is not reachable even through ..
+func pivot(root string) error {
+	oldRoot := filepath.Join(root, oldRootDir)
+
+	if err := os.Mkdir(oldRoot, 0o700); err != nil {
+		return fmt.Errorf("create old root dir: %w", err)
+	}
+
+	if err := syscall.PivotRoot(root, oldRoot); err != nil {
+		return fmt.Errorf("pivot root: %w", err)
+	}
+
+	if err := syscall.Chdir("/"); err != nil {
+		return fmt.Errorf("enter new root: %w", err)
+	}
+
+	if err := syscall.Unmount("/"+oldRootDir, syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("detach old root: %w", err)
+	}
+
+	if err := os.Remove("/" + oldRootDir); err != nil {
+		return fmt.Errorf("remove old root dir: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// initArg0 marks re-executed judge binary which must turn into sandbox init instead of judge
+const initArg0 = "cplatform-sandbox-init"
+
+var ErrUnsupported = errors.New("sandbox is not supported on this platform")
+
+type Limits struct {
+	CpuTime     time.Duration
+	WallTime    time.Duration
+	MemoryBytes int64
+	// Processes limits tasks, so threads are counted as well
+	Processes   int64
+	OutputBytes int64
+	StackBytes  int64
+	OpenFiles   int64
+}
+
+type Spec struct {
+	Args []string
+	Dir  string
+	Env  []string
+	// ReadOnly are host paths, besides system directories, made visible read-only inside sandbox; Dir is the only writable one
+	ReadOnly []string
+	// Stdin and Stdout should be *os.File, otherwise data is copied through pipe and output limit is not enforced
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	Limits Limits
+}
+
+type Status string
+
+const (
+	StatusOk           Status = "OK"
+	StatusTimeLimit    Status = "TL"
+	StatusMemoryLimit  Status = "ML"
+	StatusOutputLimit  Status = "OL"
+	StatusRuntimeError Status = "RE"
+)
+
+type Result struct {
+	Status      Status
+	ExitCode    int
+	Signal      string
+	CpuTime     time.Duration
+	WallTime    time.Duration
+	MemoryBytes int64
+}
+
+// Runner executes program in isolation, implemented by Sandbox
+type Runner interface {
+	Run(ctx context.Context, spec *Spec) (*Result, error)
+}
+
+type Config struct {
+	// CgroupRoot is cgroup v2 directory delegated to judge, every run gets child group in it
+	CgroupRoot string
+	// Uid and Gid are credentials of sandboxed process, should belong to nobody-like user
+	Uid int
+	Gid int
+	// RootDir is empty directory per-run roots are mounted over; mounts live in private namespace, so it stays empty on host
+	RootDir string
+}
+
+// IsInitProcess reports whether current process was started by Sandbox.Run and must call RunInit
+func IsInitProcess() bool {
+	return len(os.Args) > 1 && os.Args[0] == initArg0
+}
+
+// LimitedBuffer keeps first limit bytes written and silently drops the rest, so noisy program cannot bloat logs
+type LimitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func NewLimitedBuffer(limit int) *LimitedBuffer {
+	return &LimitedBuffer{limit: limit}
+}
+
+func (b *LimitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(room, len(p))])
+	}
+
+	return len(p), nil
+}
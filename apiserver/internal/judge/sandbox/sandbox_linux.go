@@ -0,0 +1,210 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"context"
+	"cplatform/pkg/slogext"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+const defaultOpenFiles = 64
+
+// Sandbox runs untrusted programs in fresh mount, pid, network, ipc and uts namespaces, chrooted by pivot_root
+// into per-run read-only root, inside per-run cgroup v2 group and under rlimits applied by re-executed init
+type Sandbox struct {
+	config  Config
+	cgroups *cgroupManager
+	logger  *slog.Logger
+
+	runCounter atomic.Int64
+}
+
+func New(config Config, logger *slog.Logger) (*Sandbox, error) {
+	cgroups, err := newCgroupManager(config.CgroupRoot)
+	if err != nil {
+		return nil, fmt.Errorf("cannot prepare cgroups: %w", err)
+	}
+
+	if err := os.MkdirAll(config.RootDir, 0o755); err != nil {
+		return nil, fmt.Errorf("cannot create sandbox root dir: %w", err)
+	}
+
+	return &Sandbox{
+		config:  config,
+		cgroups: cgroups,
+		logger:  logger,
+	}, nil
+}
+
+func (s *Sandbox) Run(ctx context.Context, spec *Spec) (*Result, error) {
+	if len(spec.Args) == 0 {
+		return nil, errors.New("sandbox: empty command")
+	}
+
+	if !filepath.IsAbs(spec.Dir) {
+		return nil, fmt.Errorf("sandbox: work dir %q must be absolute", spec.Dir)
+	}
+
+	group, err := s.cgroups.create(fmt.Sprintf("run-%d-%d", os.Getpid(), s.runCounter.Add(1)), spec.Limits)
+	if err != nil {
+		return nil, err
+	}
+	defer group.destroy()
+
+	encodedConfig, err := json.Marshal(s.initConfig(spec))
+	if err != nil {
+		return nil, fmt.Errorf("encode init config: %w", err)
+	}
+
+	errRead, errWrite, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("create init error pipe: %w", err)
+	}
+	defer errRead.Close()
+
+	cmd := exec.Command("/proc/self/exe", spec.Args...)
+	cmd.Args[0] = initArg0
+	cmd.Dir = spec.Dir
+	cmd.Env = append(append([]string{}, spec.Env...), initEnv+"="+string(encodedConfig))
+	cmd.Stdin = spec.Stdin
+	cmd.Stdout = spec.Stdout
+	cmd.Stderr = spec.Stderr
+	cmd.ExtraFiles = []*os.File{errWrite}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWNS |
+			syscall.CLONE_NEWPID |
+			syscall.CLONE_NEWNET |
+			syscall.CLONE_NEWIPC |
+			syscall.CLONE_NEWUTS,
+		UseCgroupFD: true,
+		CgroupFD:    group.fd,
+		Pdeathsig:   syscall.SIGKILL,
+	}
+
+	start := time.Now()
+	err = cmd.Start()
+	_ = errWrite.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("start sandboxed process: %w", err)
+	}
+
+	waitChan := make(chan error, 1)
+	go func() {
+		waitChan <- cmd.Wait()
+	}()
+
+	timer := time.NewTimer(spec.Limits.WallTime)
+	defer timer.Stop()
+
+	wallExceeded := false
+
+	select {
+	case <-waitChan:
+	case <-timer.C:
+		wallExceeded = true
+		s.kill(cmd, group)
+		<-waitChan
+	case <-ctx.Done():
+		s.kill(cmd, group)
+		<-waitChan
+
+		return nil, ctx.Err()
+	}
+
+	wallTime := time.Since(start)
+
+	initErr, _ := io.ReadAll(errRead)
+	if len(initErr) > 0 {
+		return nil, fmt.Errorf("sandbox init failed: %s", initErr)
+	}
+
+	stats, err := group.stats()
+	if err != nil {
+		return nil, fmt.Errorf("read cgroup stats: %w", err)
+	}
+
+	result := &Result{
+		CpuTime:     stats.cpuTime,
+		WallTime:    wallTime,
+		MemoryBytes: stats.memoryPeak,
+		ExitCode:    cmd.ProcessState.ExitCode(),
+	}
+
+	status, _ := cmd.ProcessState.Sys().(syscall.WaitStatus)
+	if status.Signaled() {
+		result.Signal = status.Signal().String()
+	}
+
+	result.Status = classify(&spec.Limits, stats, status, wallExceeded)
+
+	return result, nil
+}
+
+func (s *Sandbox) initConfig(spec *Spec) initConfig {
+	limits := &spec.Limits
+
+	config := initConfig{
+		FileSize:  uint64(max(limits.OutputBytes, 0)),
+		OpenFiles: defaultOpenFiles,
+		Stack:     uint64(max(limits.StackBytes, 0)),
+		Uid:       s.config.Uid,
+		Gid:       s.config.Gid,
+		RootDir:   s.config.RootDir,
+		Dir:       spec.Dir,
+		ReadOnly:  spec.ReadOnly,
+	}
+
+	if limits.OpenFiles > 0 {
+		config.OpenFiles = uint64(limits.OpenFiles)
+	}
+
+	// RLIMIT_CPU has second granularity, precise check is done against cgroup usage
+	if limits.CpuTime > 0 {
+		config.CpuSeconds = uint64((limits.CpuTime+time.Second-1)/time.Second) + 1
+	}
+
+	return config
+}
+
+func (s *Sandbox) kill(cmd *exec.Cmd, group *cgroup) {
+	group.kill()
+
+	if err := cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		s.logger.Warn("fail kill sandboxed process", "pid", cmd.Process.Pid, slogext.Cause(err))
+	}
+}
+
+func classify(limits *Limits, stats cgroupStats, status syscall.WaitStatus, wallExceeded bool) Status {
+	failed := status.Signaled() || status.ExitStatus() != 0
+
+	switch {
+	case stats.oomKills > 0:
+		return StatusMemoryLimit
+	case failed && limits.MemoryBytes > 0 && stats.hasPeakInfo && stats.memoryPeak >= limits.MemoryBytes:
+		return StatusMemoryLimit
+	case wallExceeded:
+		return StatusTimeLimit
+	case limits.CpuTime > 0 && stats.cpuTime > limits.CpuTime:
+		return StatusTimeLimit
+	case status.Signaled() && status.Signal() == syscall.SIGXCPU:
+		return StatusTimeLimit
+	case status.Signaled() && status.Signal() == syscall.SIGXFSZ:
+		return StatusOutputLimit
+	case failed:
+		return StatusRuntimeError
+	default:
+		return StatusOk
+	}
+}
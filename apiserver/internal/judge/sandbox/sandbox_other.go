@@ -0,0 +1,23 @@
+//go:build !linux
+
+package sandbox
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+type Sandbox struct{}
+
+func New(Config, *slog.Logger) (*Sandbox, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *Sandbox) Run(context.Context, *Spec) (*Result, error) {
+	return nil, ErrUnsupported
+}
+
+func RunInit() {
+	os.Exit(127)
+}
@@ -0,0 +1,526 @@
+package judge
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/infrastructure"
+	"cplatform/internal/domain"
+	"cplatform/internal/judge/checker"
+	"cplatform/internal/judge/sandbox"
+	"cplatform/pkg/slogext"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	maxJudgeLogBytes  = 64 * 1024
+	maxOutputBytes    = 64 * 1024 * 1024
+	runProcesses      = 32
+	dequeueRetryDelay = time.Second
+	// heartbeatInterval is how often job and submission claim are renewed while judging
+	heartbeatInterval = time.Minute
+	// claimStaleAfter lets another judge take submission over once its judge missed several heartbeats
+	claimStaleAfter = 5 * heartbeatInterval
+)
+
+var compileLimits = sandbox.Limits{
+	CpuTime:     30 * time.Second,
+	WallTime:    60 * time.Second,
+	MemoryBytes: 1024 * 1024 * 1024,
+	Processes:   64,
+	OutputBytes: 256 * 1024 * 1024,
+	OpenFiles:   256,
+}
+
+var sandboxEnv = []string{
+	"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+	"LANG=C.UTF-8",
+}
+
+type WorkerConfig struct {
+	// WorkDir holds temporary per-submission directories
+	WorkDir string
+	// Uid and Gid own per-submission directories and must match sandbox credentials
+	Uid int
+	Gid int
+}
+
+type Worker struct {
+	config     WorkerConfig
+	queue      infrastructure.JobQueue
+	uowFactory infrastructure.UnitOfWorkFactory
+	storage    infrastructure.TestStorage
+	languages  infrastructure.LanguageRegistry
+	cache      infrastructure.Cache
+	runner     sandbox.Runner
+	logger     *slog.Logger
+}
+
+type task struct {
+	submission *domain.Submission
+	revision   *domain.ProblemRevision
+	tests      *domain.TestSet
+	language   *domain.Language
+	checker    checker.Checker
+	dir        string
+}
+
+type testOutcome struct {
+	verdict domain.Verdict
+	// message comes from checker and explains wrong answer
+	message string
+	run     *sandbox.Result
+}
+
+func NewWorker(config WorkerConfig, queue infrastructure.JobQueue, uowFactory infrastructure.UnitOfWorkFactory, storage infrastructure.TestStorage, languages infrastructure.LanguageRegistry, cache infrastructure.Cache, runner sandbox.Runner, logger *slog.Logger) *Worker {
+	return &Worker{
+		config:     config,
+		queue:      queue,
+		uowFactory: uowFactory,
+		storage:    storage,
+		languages:  languages,
+		cache:      cache,
+		runner:     runner,
+		logger:     logger,
+	}
+}
+
+// Run judges jobs until ctx is done; job interrupted by cancellation is left unacknowledged and gets redelivered
+func (w *Worker) Run(ctx context.Context) {
+	for {
+		job, err := w.queue.Dequeue(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			w.logger.Error("judge: fail dequeue job", slogext.Cause(err))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(dequeueRetryDelay):
+			}
+
+			continue
+		}
+
+		stopHeartbeat := w.startHeartbeat(ctx, job)
+		err = w.judge(ctx, job.SubmissionId)
+		stopHeartbeat()
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			w.logger.Error("judge: fail judge submission", slog.Int64("submission_id", int64(job.SubmissionId)), slogext.Cause(err))
+
+			err = w.updateState(ctx, job.SubmissionId, domain.SubmissionStateFailed)
+			if err != nil {
+				// job is left unacknowledged, so it is redelivered once database is reachable again
+				w.logger.Error("judge: fail mark submission failed", slog.Int64("submission_id", int64(job.SubmissionId)), slogext.Cause(err))
+				continue
+			}
+		}
+
+		err = w.queue.Ack(ctx, job)
+		if err != nil {
+			w.logger.Error("judge: fail ack job", slog.Int64("submission_id", int64(job.SubmissionId)), slogext.Cause(err))
+		}
+	}
+}
+
+func (w *Worker) judge(ctx context.Context, id domain.SubmissionId) error {
+	t, err := w.loadTask(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	var result *domain.SubmissionResult
+	if t.language == nil {
+		result = &domain.SubmissionResult{
+			Verdict:  domain.VerdictCompilationError,
+			JudgeLog: fmt.Sprintf("language %q is not supported by judge", t.submission.LanguageId),
+		}
+	} else {
+		result, err = w.evaluate(ctx, t)
+		if err != nil {
+			return err
+		}
+	}
+
+	result.JudgedAt = time.Now()
+
+	uow, err := w.uowFactory.TryCreate(ctx)
+	if err != nil {
+		return err
+	}
+	defer uow.Close(context.WithoutCancel(ctx))
+
+	err = uow.SubmissionRepository(ctx).SaveSubmissionResult(ctx, id, result)
+	if err != nil {
+		return fmt.Errorf("fail save result: %w", err)
+	}
+
+	err = uow.SaveChanges(ctx)
+	if err != nil {
+		return fmt.Errorf("fail commit result: %w", err)
+	}
+
+	// standings are rebuilt on next read; stale cache only delays new verdicts until ttl expires
+	err = w.cache.InvalidateStandings(ctx, t.submission.ContestId)
+	if err != nil {
+		w.logger.Warn("judge: fail invalidate standings", slog.Int64("contest_id", int64(t.submission.ContestId)), slogext.Cause(err))
+	}
+
+	w.logger.Info("judge: submission judged",
+		slog.Int64("submission_id", int64(id)),
+		slog.String("verdict", string(result.Verdict)),
+		slog.Int("score", result.Score),
+	)
+
+	return nil
+}
+
+// loadTask moves submission to judging state and reads everything needed to judge it; nil task means nothing to judge
+func (w *Worker) loadTask(ctx context.Context, id domain.SubmissionId) (*task, error) {
+	uow, err := w.uowFactory.TryCreate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer uow.Close(context.WithoutCancel(ctx))
+
+	submission, err := uow.SubmissionRepository(ctx).GetSubmissionById(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrSubmissionNotFound) {
+			w.logger.Warn("judge: skip job of missing submission", slog.Int64("submission_id", int64(id)))
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("fail get submission: %w", err)
+	}
+
+	// redelivered or requeued job of submission already judged or being judged by live judge
+	claimed, err := uow.SubmissionRepository(ctx).ClaimSubmission(ctx, id, time.Now().Add(-claimStaleAfter))
+	if err != nil {
+		return nil, fmt.Errorf("fail claim submission: %w", err)
+	}
+
+	if !claimed {
+		w.logger.Info("judge: skip job of submission claimed elsewhere", slog.Int64("submission_id", int64(id)), slog.String("state", string(submission.State)))
+		return nil, nil
+	}
+
+	err = uow.SaveChanges(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fail commit submission state: %w", err)
+	}
+
+	revision, err := uow.ProblemRepository(ctx).GetProblemRevision(ctx, submission.ProblemId, submission.ProblemRevision)
+	if err != nil {
+		return nil, fmt.Errorf("fail get problem revision: %w", err)
+	}
+
+	if revision.TestSetId == nil {
+		return nil, fmt.Errorf("problem %d revision %d has no tests", revision.ProblemId, revision.Revision)
+	}
+
+	tests, err := uow.ProblemRepository(ctx).GetTestSet(ctx, *revision.TestSetId)
+	if err != nil {
+		return nil, fmt.Errorf("fail get test set: %w", err)
+	}
+
+	language, _ := w.languages.GetLanguage(submission.LanguageId)
+
+	return &task{
+		submission: submission,
+		revision:   revision,
+		tests:      tests,
+		language:   language,
+	}, nil
+}
+
+// startHeartbeat renews job and submission claim until returned stop is called, so long judging is not taken over
+func (w *Worker) startHeartbeat(ctx context.Context, job *infrastructure.JudgeJob) func() {
+	heartbeatCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-heartbeatCtx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := w.queue.Extend(heartbeatCtx, job); err != nil && heartbeatCtx.Err() == nil {
+				w.logger.Warn("judge: fail extend job", slog.Int64("submission_id", int64(job.SubmissionId)), slogext.Cause(err))
+			}
+
+			if err := w.renewClaim(heartbeatCtx, job.SubmissionId); err != nil && heartbeatCtx.Err() == nil {
+				w.logger.Warn("judge: fail renew submission claim", slog.Int64("submission_id", int64(job.SubmissionId)), slogext.Cause(err))
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (w *Worker) renewClaim(ctx context.Context, id domain.SubmissionId) error {
+	uow, err := w.uowFactory.TryCreate(ctx)
+	if err != nil {
+		return err
+	}
+	defer uow.Close(context.WithoutCancel(ctx))
+
+	err = uow.SubmissionRepository(ctx).RenewSubmissionClaim(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return uow.SaveChanges(ctx)
+}
+
+func (w *Worker) updateState(ctx context.Context, id domain.SubmissionId, state domain.SubmissionState) error {
+	uow, err := w.uowFactory.TryCreate(ctx)
+	if err != nil {
+		return err
+	}
+	defer uow.Close(context.WithoutCancel(ctx))
+
+	err = uow.SubmissionRepository(ctx).UpdateSubmissionState(ctx, id, state)
+	if err != nil {
+		return err
+	}
+
+	return uow.SaveChanges(ctx)
+}
+
+func (w *Worker) evaluate(ctx context.Context, t *task) (*domain.SubmissionResult, error) {
+	dir, err := w.prepareDir(t)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	t.dir = dir
+
+	if len(t.language.CompileCommand) > 0 {
+		compileLog, ok, err := w.compile(ctx, t.language.CompileCommand, t.dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return &domain.SubmissionResult{
+				Verdict:  domain.VerdictCompilationError,
+				JudgeLog: compileLog,
+			}, nil
+		}
+	}
+
+	t.checker, err = w.prepareChecker(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.SubmissionResult{Verdict: domain.VerdictAccepted}
+	failedSubtasks := make(map[int]bool)
+
+	for _, test := range t.tests.Tests {
+		// subtask is scored all or nothing, so its remaining tests are pointless after failure
+		if test.Subtask != 0 && failedSubtasks[test.Subtask] {
+			continue
+		}
+
+		outcome, err := w.runTest(ctx, t, &test)
+		if err != nil {
+			return nil, fmt.Errorf("fail run test %d: %w", test.Ordinal, err)
+		}
+
+		result.TimeUsed = max(result.TimeUsed, outcome.run.CpuTime)
+		result.MemoryUsedKb = max(result.MemoryUsedKb, outcome.run.MemoryBytes/1024)
+
+		if outcome.verdict == domain.VerdictAccepted {
+			continue
+		}
+
+		if result.FailedTest == 0 {
+			result.Verdict = outcome.verdict
+			result.FailedTest = test.Ordinal
+
+			if outcome.message != "" {
+				result.JudgeLog = fmt.Sprintf("test %d: %s", test.Ordinal, outcome.message)
+			}
+		}
+
+		if len(t.tests.Subtasks) == 0 {
+			break
+		}
+
+		failedSubtasks[test.Subtask] = true
+	}
+
+	if len(t.tests.Subtasks) == 0 {
+		if result.FailedTest == 0 {
+			result.Score = 100
+		}
+
+		return result, nil
+	}
+
+	result.SubtaskScores = make([]int, len(t.tests.Subtasks))
+	for i, subtask := range t.tests.Subtasks {
+		if !failedSubtasks[subtask.Index] {
+			result.SubtaskScores[i] = subtask.Score
+			result.Score += subtask.Score
+		}
+	}
+
+	return result, nil
+}
+
+func (w *Worker) prepareDir(t *task) (string, error) {
+	dir, err := os.MkdirTemp(w.config.WorkDir, fmt.Sprintf("submission-%d-", t.submission.Id))
+	if err != nil {
+		return "", fmt.Errorf("fail create work dir: %w", err)
+	}
+
+	sourcePath := filepath.Join(dir, t.language.SourceFile)
+
+	err = os.WriteFile(sourcePath, []byte(t.submission.Source), 0o644)
+	if err == nil {
+		err = os.Chown(sourcePath, w.config.Uid, w.config.Gid)
+	}
+
+	// compiler running as sandbox user writes binaries next to source
+	if err == nil {
+		err = os.Chown(dir, w.config.Uid, w.config.Gid)
+	}
+
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", fmt.Errorf("fail prepare work dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+// compile runs compiler in dir which must be writable by sandbox user and reports log and success
+func (w *Worker) compile(ctx context.Context, command []string, dir string) (string, bool, error) {
+	compileLog := sandbox.NewLimitedBuffer(maxJudgeLogBytes)
+
+	run, err := w.runner.Run(ctx, &sandbox.Spec{
+		Args:   command,
+		Dir:    dir,
+		Env:    append(append([]string{}, sandboxEnv...), "TMPDIR="+dir, "HOME="+dir),
+		Stdout: compileLog,
+		Stderr: compileLog,
+		Limits: compileLimits,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("fail run compiler: %w", err)
+	}
+
+	switch run.Status {
+	case sandbox.StatusOk:
+		return compileLog.String(), true, nil
+	case sandbox.StatusTimeLimit:
+		compileLog.WriteString("\ncompilation time limit exceeded")
+	case sandbox.StatusMemoryLimit:
+		compileLog.WriteString("\ncompilation memory limit exceeded")
+	}
+
+	return compileLog.String(), false, nil
+}
+
+func (w *Worker) runTest(ctx context.Context, t *task, test *domain.ProblemTest) (*testOutcome, error) {
+	input, err := w.storage.OpenTestFile(ctx, t.tests.Id, test.Ordinal, infrastructure.TestFileInput)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+
+	// output is kept in root owned work dir rather than in t.dir, where submission could plant symlink to host file in its place
+	output, err := os.CreateTemp(w.config.WorkDir, fmt.Sprintf("output-%d-", t.submission.Id))
+	if err != nil {
+		return nil, fmt.Errorf("fail create output file: %w", err)
+	}
+	defer os.Remove(output.Name())
+	defer output.Close()
+
+	memory := t.revision.MemoryLimitKb * 1024
+	timeLimit := time.Duration(float64(t.revision.TimeLimit) * t.language.TimeMultiplier)
+
+	run, err := w.runner.Run(ctx, &sandbox.Spec{
+		Args:   t.language.RunCommand,
+		Dir:    t.dir,
+		Env:    append(append([]string{}, sandboxEnv...), "HOME="+t.dir),
+		Stdin:  input,
+		Stdout: output,
+		Limits: sandbox.Limits{
+			CpuTime:     timeLimit,
+			WallTime:    2*timeLimit + time.Second,
+			MemoryBytes: memory,
+			Processes:   runProcesses,
+			OutputBytes: maxOutputBytes,
+			StackBytes:  memory,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch run.Status {
+	case sandbox.StatusTimeLimit:
+		return &testOutcome{verdict: domain.VerdictTimeLimitExceeded, run: run}, nil
+	case sandbox.StatusMemoryLimit:
+		return &testOutcome{verdict: domain.VerdictMemoryLimitExceeded, run: run}, nil
+	case sandbox.StatusOutputLimit, sandbox.StatusRuntimeError:
+		return &testOutcome{verdict: domain.VerdictRuntimeError, run: run}, nil
+	}
+
+	if _, err := output.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("fail rewind output file: %w", err)
+	}
+
+	checkInput, err := w.storage.OpenTestFile(ctx, t.tests.Id, test.Ordinal, infrastructure.TestFileInput)
+	if err != nil {
+		return nil, err
+	}
+	defer checkInput.Close()
+
+	answer, err := w.storage.OpenTestFile(ctx, t.tests.Id, test.Ordinal, infrastructure.TestFileAnswer)
+	if err != nil {
+		return nil, err
+	}
+	defer answer.Close()
+
+	verdict, err := t.checker.Check(ctx, checkInput, output, answer)
+	if err != nil {
+		return nil, fmt.Errorf("fail check output: %w", err)
+	}
+
+	if !verdict.Accepted {
+		return &testOutcome{verdict: domain.VerdictWrongAnswer, message: verdict.Message, run: run}, nil
+	}
+
+	return &testOutcome{verdict: domain.VerdictAccepted, run: run}, nil
+}
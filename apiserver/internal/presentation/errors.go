@@ -10,12 +10,51 @@ func (e *ApiError) Error() string {
 }
 
 var (
-	ErrInvalidJsonSchema = &ApiError{Code: 000, Message: "invalid Json Schema"}
-	ErrInvalidEmail      = &ApiError{Code: 001, Message: "invalid email"}
-	ErrDuplicateEmail    = &ApiError{Code: 002, Message: "duplicate email"}
-	ErrInvalidPassword   = &ApiError{Code: 003, Message: "invalid password"}
-	ErrInvalidName       = &ApiError{Code: 004, Message: "invalid name"}
-	ErrCancelled         = &ApiError{Code: 900, Message: "cancelled"}
-	ErrDeadlineExceeded  = &ApiError{Code: 901, Message: "deadline exceeded"}
-	ErrUnknown           = &ApiError{Code: 999, Message: "unknown error"}
+	ErrInvalidJsonSchema      = &ApiError{Code: 000, Message: "invalid Json Schema"}
+	ErrInvalidEmail           = &ApiError{Code: 001, Message: "invalid email"}
+	ErrDuplicateEmail         = &ApiError{Code: 002, Message: "duplicate email"}
+	ErrInvalidPassword        = &ApiError{Code: 003, Message: "invalid password"}
+	ErrInvalidName            = &ApiError{Code: 004, Message: "invalid name"}
+	ErrInvalidQueryParameter  = &ApiError{Code: 005, Message: "invalid query parameter"}
+	ErrWrongCredentials       = &ApiError{Code: 006, Message: "wrong credentials"}
+	ErrNoSessionToken         = &ApiError{Code: 007, Message: "request is not authenticated with session token"}
+	ErrUserNotFound           = &ApiError{Code: 8, Message: "user not found"}
+	ErrInvalidRole            = &ApiError{Code: 9, Message: "invalid role"}
+	ErrRoleGrantNotFound      = &ApiError{Code: 10, Message: "role grant not found"}
+	ErrInvalidProfile         = &ApiError{Code: 11, Message: "invalid profile"}
+	ErrInvalidToken           = &ApiError{Code: 12, Message: "invalid or expired token"}
+	ErrEmailNotVerified       = &ApiError{Code: 13, Message: "email is not verified"}
+	ErrTooManyAttempts        = &ApiError{Code: 14, Message: "too many attempts"}
+	ErrProviderNotFound       = &ApiError{Code: 15, Message: "identity provider not found"}
+	ErrExternalLoginFailed    = &ApiError{Code: 16, Message: "external login failed"}
+	ErrInvalidAccessToken     = &ApiError{Code: 17, Message: "invalid access token"}
+	ErrAccessTokenNotFound    = &ApiError{Code: 18, Message: "access token not found"}
+	ErrDuplicateAccessToken   = &ApiError{Code: 19, Message: "access token name already exists"}
+	ErrInvalidSession         = &ApiError{Code: 20, Message: "invalid or expired session"}
+	ErrInvalidContest         = &ApiError{Code: 100, Message: "invalid contest"}
+	ErrContestNotFound        = &ApiError{Code: 101, Message: "contest not found"}
+	ErrInvalidContestProblem  = &ApiError{Code: 102, Message: "invalid contest problem"}
+	ErrContestProblemNotFound = &ApiError{Code: 103, Message: "contest problem not found"}
+	ErrContestNotRunning      = &ApiError{Code: 104, Message: "contest is not running"}
+	ErrRegistrationClosed     = &ApiError{Code: 105, Message: "registration is closed"}
+	ErrContestFull            = &ApiError{Code: 106, Message: "contest is full"}
+	ErrInvalidInviteCode      = &ApiError{Code: 107, Message: "invalid invite code"}
+	ErrAlreadyRegistered      = &ApiError{Code: 108, Message: "already registered"}
+	ErrNotRegistered          = &ApiError{Code: 109, Message: "not registered"}
+	ErrInvalidProblem         = &ApiError{Code: 200, Message: "invalid problem"}
+	ErrProblemNotFound        = &ApiError{Code: 201, Message: "problem not found"}
+	ErrInvalidTests           = &ApiError{Code: 202, Message: "invalid tests"}
+	ErrTestsNotFound          = &ApiError{Code: 203, Message: "tests not found"}
+	ErrInvalidSubmission      = &ApiError{Code: 300, Message: "invalid submission"}
+	ErrSubmissionNotFound     = &ApiError{Code: 301, Message: "submission not found"}
+	ErrLanguageNotFound       = &ApiError{Code: 302, Message: "language not found"}
+	ErrInvalidTeam            = &ApiError{Code: 400, Message: "invalid team"}
+	ErrTeamNotFound           = &ApiError{Code: 401, Message: "team not found"}
+	ErrDuplicateTeamName      = &ApiError{Code: 402, Message: "duplicate team name"}
+	ErrTeamFull               = &ApiError{Code: 403, Message: "team is full"}
+	ErrAlreadyTeamMember      = &ApiError{Code: 404, Message: "already a team member"}
+	ErrAccessDenied           = &ApiError{Code: 800, Message: "access denied"}
+	ErrCancelled              = &ApiError{Code: 900, Message: "cancelled"}
+	ErrDeadlineExceeded       = &ApiError{Code: 901, Message: "deadline exceeded"}
+	ErrUnknown                = &ApiError{Code: 999, Message: "unknown error"}
 )
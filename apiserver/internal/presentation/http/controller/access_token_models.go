@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const minAccessTokenNameLength = 1
+const maxAccessTokenNameLength = 64
+
+type CreateAccessTokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is optional; token without it is valid until revoked
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type AccessTokenResponse struct {
+	Id        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CreateAccessTokenResponse is the only response carrying token secret
+type CreateAccessTokenResponse struct {
+	AccessTokenResponse
+	Token string `json:"token"`
+}
+
+type ListAccessTokensResponse struct {
+	Tokens []AccessTokenResponse `json:"tokens"`
+}
+
+func newAccessTokenResponse(token *domain.AccessToken) AccessTokenResponse {
+	res := AccessTokenResponse{
+		Id:        int64(token.Id),
+		Name:      token.Name,
+		Scopes:    make([]string, 0, len(token.Scopes)),
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+
+	for _, scope := range token.Scopes {
+		res.Scopes = append(res.Scopes, string(scope))
+	}
+
+	return res
+}
+
+func newListAccessTokensResponse(tokens []*domain.AccessToken) ListAccessTokensResponse {
+	res := ListAccessTokensResponse{
+		Tokens: make([]AccessTokenResponse, 0, len(tokens)),
+	}
+
+	for _, token := range tokens {
+		res.Tokens = append(res.Tokens, newAccessTokenResponse(token))
+	}
+
+	return res
+}
+
+func validateCreateAccessTokenRequest(req *CreateAccessTokenRequest, now time.Time) []error {
+	var errs []error
+
+	nameLength := utf8.RuneCountInString(req.Name)
+	if nameLength < minAccessTokenNameLength {
+		errs = append(errs, fmt.Errorf("%w: name too short", presentation.ErrInvalidAccessToken))
+	} else if nameLength > maxAccessTokenNameLength {
+		errs = append(errs, fmt.Errorf("%w: name too long", presentation.ErrInvalidAccessToken))
+	}
+
+	if strings.TrimSpace(req.Name) != req.Name {
+		errs = append(errs, fmt.Errorf("%w: name must not start or end with whitespace", presentation.ErrInvalidAccessToken))
+	}
+
+	if len(req.Scopes) == 0 {
+		errs = append(errs, fmt.Errorf("%w: at least one scope is required", presentation.ErrInvalidAccessToken))
+	}
+
+	for i, scope := range req.Scopes {
+		if !domain.TokenScope(scope).IsValid() {
+			errs = append(errs, fmt.Errorf("%w: unknown scope %q", presentation.ErrInvalidAccessToken, scope))
+		} else if slices.Contains(req.Scopes[:i], scope) {
+			errs = append(errs, fmt.Errorf("%w: duplicate scope %q", presentation.ErrInvalidAccessToken, scope))
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		errs = append(errs, fmt.Errorf("%w: expires_at must be in future", presentation.ErrInvalidAccessToken))
+	}
+
+	return errs
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"cplatform/internal/application/authentication"
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/pkg/slogext"
+	"net/http"
+)
+
+func (c *Controller) ChangePasswordHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req ChangePasswordRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validatePassword(req.NewPassword); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	service := scope.UserService(r.Context())
+
+	changed, err := service.ChangePassword(r.Context(), user.Id, req.OldPassword, req.NewPassword, authentication.ClientIp(r))
+	if err != nil {
+		c.writeServiceError(w, err, "fail change password")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	// every session ends, including current one, since password may have been changed because of leak
+	if err := service.RevokeUserAccess(context.WithoutCancel(r.Context()), changed); err != nil {
+		c.logger.Error("fail revoke access after password change", "user_id", user.Id, slogext.Cause(err))
+	}
+
+	c.logger.Info("password changed", "user_id", user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"cplatform/internal/presentation"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// CompleteExternalLoginHandler is redirect target of provider; it responds with session like CreateSessionHandler
+func (c *Controller) CompleteExternalLoginHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// provider reports denied consent and its own failures through query instead of code
+	if providerErr := query.Get("error"); providerErr != "" {
+		c.logger.Info("external login rejected by provider", "error", providerErr)
+		c.writeErrors(w, http.StatusUnauthorized, fmt.Errorf("%w: %s", presentation.ErrExternalLoginFailed, providerErr))
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	provider := mux.Vars(r)["provider"]
+
+	user, err := scope.IdentityService(r.Context()).CompleteLogin(r.Context(), provider, query.Get("state"), query.Get("code"))
+	if err != nil {
+		c.writeServiceError(w, err, "fail complete external login")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	token, session, err := scope.SessionService(r.Context()).StartSession(r.Context(), user)
+	if err != nil {
+		c.writeServiceError(w, err, "fail create session")
+		return
+	}
+
+	c.logger.Info("session created by external login", "user_id", session.UserId, "provider", provider)
+
+	c.writeJson(w, http.StatusOK, newSessionResponse(token, session))
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"context"
+	"cplatform/pkg/slogext"
+	"net/http"
+)
+
+func (c *Controller) ConfirmEmailChangeHandler(w http.ResponseWriter, r *http.Request) {
+	var req TokenRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	service := scope.UserService(r.Context())
+
+	previous, err := service.ConfirmEmailChange(r.Context(), req.Token)
+	if err != nil {
+		c.writeServiceError(w, err, "fail confirm email change")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	// old email must stop working for basic auth right away
+	if err := service.InvalidateUserCache(context.WithoutCancel(r.Context()), previous.Email); err != nil {
+		c.logger.Error("fail invalidate user cache", "user_id", previous.Id, slogext.Cause(err))
+	}
+
+	c.logger.Info("email changed", "user_id", previous.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+	"cplatform/pkg/slogext"
+	"net/http"
+)
+
+func (c *Controller) ConfirmPasswordResetHandler(w http.ResponseWriter, r *http.Request) {
+	var req ConfirmPasswordResetRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validatePassword(req.Password); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	service := scope.UserService(r.Context())
+
+	user, err := service.ResetPassword(r.Context(), req.Token, req.Password)
+	if err != nil {
+		c.writeServiceError(w, err, "fail reset password")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	if err := service.RevokeUserAccess(context.WithoutCancel(r.Context()), user); err != nil {
+		c.logger.Error("fail revoke access after password reset", "user_id", user.Id, slogext.Cause(err))
+	}
+
+	c.logger.Info("password reset", "user_id", user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+const minContestTitleLength = 1
+const maxContestTitleLength = 200
+const maxContestDescriptionLength = 20000
+const maxContestDuration = 30 * 24 * time.Hour
+const maxContestCapacity = 100000
+const maxInviteCodeLength = 64
+
+var scoringModes = map[string]domain.ScoringMode{
+	"":     domain.ScoringModeIcpc,
+	"icpc": domain.ScoringModeIcpc,
+	"ioi":  domain.ScoringModeIoi,
+}
+
+type ContestRequest struct {
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	StartTime       time.Time  `json:"start_time"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	FreezeTime      *time.Time `json:"freeze_time"`
+	Visibility      string     `json:"visibility"`
+	// ScoringMode is icpc or ioi; icpc by default
+	ScoringMode          string     `json:"scoring_mode"`
+	RegistrationDeadline *time.Time `json:"registration_deadline"`
+	// Capacity is 0 for unlimited contests
+	Capacity   int    `json:"capacity"`
+	InviteCode string `json:"invite_code"`
+	// RequireVerifiedEmail accepts registrations and submissions only from users with verified email
+	RequireVerifiedEmail bool `json:"require_verified_email"`
+}
+
+type ContestResponse struct {
+	Id                   int64      `json:"id"`
+	Title                string     `json:"title"`
+	Description          string     `json:"description"`
+	StartTime            time.Time  `json:"start_time"`
+	DurationSeconds      int64      `json:"duration_seconds"`
+	FreezeTime           *time.Time `json:"freeze_time"`
+	Visibility           string     `json:"visibility"`
+	ScoringMode          string     `json:"scoring_mode"`
+	RegistrationDeadline *time.Time `json:"registration_deadline"`
+	Capacity             int        `json:"capacity"`
+	// InviteCode is shown only to contest owner
+	InviteCode           string `json:"invite_code,omitempty"`
+	RequireVerifiedEmail bool   `json:"require_verified_email"`
+	OwnerId              int64  `json:"owner_id"`
+}
+
+type RegistrationRequest struct {
+	InviteCode string `json:"invite_code"`
+	// TeamId registers whole team instead of requesting user
+	TeamId *int64 `json:"team_id"`
+}
+
+type ParticipantResponse struct {
+	UserId       int64     `json:"user_id"`
+	Name         string    `json:"name"`
+	TeamId       *int64    `json:"team_id,omitempty"`
+	TeamName     string    `json:"team_name,omitempty"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+type ListParticipantsResponse struct {
+	Participants []ParticipantResponse `json:"participants"`
+}
+
+func (req *ContestRequest) toDomain() *domain.Contest {
+	return &domain.Contest{
+		Title:                req.Title,
+		Description:          req.Description,
+		StartTime:            req.StartTime,
+		Duration:             time.Duration(req.DurationSeconds) * time.Second,
+		FreezeTime:           req.FreezeTime,
+		Visibility:           domain.ContestVisibility(req.Visibility),
+		ScoringMode:          scoringModes[req.ScoringMode],
+		RegistrationDeadline: req.RegistrationDeadline,
+		Capacity:             req.Capacity,
+		InviteCode:           req.InviteCode,
+		RequireVerifiedEmail: req.RequireVerifiedEmail,
+	}
+}
+
+func newContestResponse(contest *domain.Contest, viewerId domain.UserId) ContestResponse {
+	inviteCode := ""
+	if contest.OwnerId == viewerId {
+		inviteCode = contest.InviteCode
+	}
+
+	return ContestResponse{
+		Id:                   int64(contest.Id),
+		Title:                contest.Title,
+		Description:          contest.Description,
+		StartTime:            contest.StartTime,
+		DurationSeconds:      int64(contest.Duration / time.Second),
+		FreezeTime:           contest.FreezeTime,
+		Visibility:           string(contest.Visibility),
+		ScoringMode:          string(contest.ScoringMode),
+		RegistrationDeadline: contest.RegistrationDeadline,
+		Capacity:             contest.Capacity,
+		InviteCode:           inviteCode,
+		RequireVerifiedEmail: contest.RequireVerifiedEmail,
+		OwnerId:              int64(contest.OwnerId),
+	}
+}
+
+func newParticipantResponse(participant *domain.ContestParticipant) ParticipantResponse {
+	return ParticipantResponse{
+		UserId:       int64(participant.UserId),
+		Name:         participant.UserName,
+		TeamId:       (*int64)(participant.TeamId),
+		TeamName:     participant.TeamName,
+		RegisteredAt: participant.RegisteredAt,
+	}
+}
+
+func validateContestRequest(req *ContestRequest) []error {
+	var errs []error
+
+	titleLength := utf8.RuneCountInString(req.Title)
+	if titleLength < minContestTitleLength {
+		errs = append(errs, fmt.Errorf("%w: title too short", presentation.ErrInvalidContest))
+	} else if titleLength > maxContestTitleLength {
+		errs = append(errs, fmt.Errorf("%w: title too long", presentation.ErrInvalidContest))
+	}
+
+	if utf8.RuneCountInString(req.Description) > maxContestDescriptionLength {
+		errs = append(errs, fmt.Errorf("%w: description too long", presentation.ErrInvalidContest))
+	}
+
+	if req.StartTime.IsZero() {
+		errs = append(errs, fmt.Errorf("%w: start time required", presentation.ErrInvalidContest))
+	}
+
+	duration := time.Duration(req.DurationSeconds) * time.Second
+	if duration <= 0 {
+		errs = append(errs, fmt.Errorf("%w: duration must be positive", presentation.ErrInvalidContest))
+	} else if duration > maxContestDuration {
+		errs = append(errs, fmt.Errorf("%w: duration too long", presentation.ErrInvalidContest))
+	}
+
+	if req.FreezeTime != nil {
+		end := req.StartTime.Add(duration)
+		if req.FreezeTime.Before(req.StartTime) || req.FreezeTime.After(end) {
+			errs = append(errs, fmt.Errorf("%w: freeze time must be within contest", presentation.ErrInvalidContest))
+		}
+	}
+
+	switch domain.ContestVisibility(req.Visibility) {
+	case domain.ContestVisibilityPublic, domain.ContestVisibilityPrivate:
+	default:
+		errs = append(errs, fmt.Errorf("%w: visibility must be public or private", presentation.ErrInvalidContest))
+	}
+
+	if _, ok := scoringModes[req.ScoringMode]; !ok {
+		errs = append(errs, fmt.Errorf("%w: scoring mode must be icpc or ioi", presentation.ErrInvalidContest))
+	}
+
+	if req.RegistrationDeadline != nil && req.RegistrationDeadline.After(req.StartTime.Add(duration)) {
+		errs = append(errs, fmt.Errorf("%w: registration deadline must not be after contest end", presentation.ErrInvalidContest))
+	}
+
+	if req.Capacity < 0 || req.Capacity > maxContestCapacity {
+		errs = append(errs, fmt.Errorf("%w: capacity must be between 0 and %d", presentation.ErrInvalidContest, maxContestCapacity))
+	}
+
+	if len(req.InviteCode) > maxInviteCodeLength {
+		errs = append(errs, fmt.Errorf("%w: invite code too long", presentation.ErrInvalidContest))
+	}
+
+	return errs
+}
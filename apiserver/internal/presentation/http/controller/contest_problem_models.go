@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+const maxContestProblemLabelLength = 10
+
+type SetContestProblemRequest struct {
+	ProblemId int64 `json:"problem_id"`
+	Revision  int   `json:"revision"`
+}
+
+type ContestProblemResponse struct {
+	Label     string `json:"label"`
+	ProblemId int64  `json:"problem_id"`
+	Revision  int    `json:"revision"`
+	Title     string `json:"title"`
+}
+
+type ContestProblemStatementResponse struct {
+	ContestProblemResponse
+	Statement     string   `json:"statement"`
+	InputSpec     string   `json:"input_spec"`
+	OutputSpec    string   `json:"output_spec"`
+	TimeLimitMs   int64    `json:"time_limit_ms"`
+	MemoryLimitKb int64    `json:"memory_limit_kb"`
+	Tags          []string `json:"tags"`
+}
+
+func newContestProblemResponse(problem *domain.ContestProblem) ContestProblemResponse {
+	return ContestProblemResponse{
+		Label:     problem.Label,
+		ProblemId: int64(problem.ProblemId),
+		Revision:  problem.Revision,
+		Title:     problem.Title,
+	}
+}
+
+func parseLabelVar(r *http.Request) (string, error) {
+	label := mux.Vars(r)["label"]
+
+	if len(label) == 0 || len(label) > maxContestProblemLabelLength || !allRunesInAlphabet(label, nameAlphabet) {
+		return "", fmt.Errorf("%w: label must be 1 to %d latin letters or digits", presentation.ErrInvalidContestProblem, maxContestProblemLabelLength)
+	}
+
+	return label, nil
+}
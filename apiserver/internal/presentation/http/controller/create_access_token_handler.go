@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+	"time"
+)
+
+func (c *Controller) CreateAccessTokenHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateAccessTokenRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validateCreateAccessTokenRequest(&req, time.Now()); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	token := &domain.AccessToken{
+		UserId:    user.Id,
+		Name:      req.Name,
+		Scopes:    make([]domain.TokenScope, 0, len(req.Scopes)),
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	for _, raw := range req.Scopes {
+		token.Scopes = append(token.Scopes, domain.TokenScope(raw))
+	}
+
+	secret, err := scope.AccessTokenService(r.Context()).CreateToken(r.Context(), token)
+	if err != nil {
+		c.writeServiceError(w, err, "fail create access token")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("access token created", "token_id", token.Id, "user_id", user.Id)
+
+	c.writeJson(w, http.StatusCreated, CreateAccessTokenResponse{
+		AccessTokenResponse: newAccessTokenResponse(token),
+		Token:               secret,
+	})
+}
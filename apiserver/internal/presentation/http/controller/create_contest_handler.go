@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"net/http"
+)
+
+func (c *Controller) CreateContestHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req ContestRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validateContestRequest(&req); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	contest := req.toDomain()
+	contest.OwnerId = user.Id
+
+	err := scope.ContestService(r.Context()).CreateContest(r.Context(), contest)
+	if err != nil {
+		c.writeServiceError(w, err, "fail create contest")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("contest created", "contest_id", contest.Id, "owner_id", contest.OwnerId)
+
+	c.writeJson(w, http.StatusCreated, newContestResponse(contest, user.Id))
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"net/http"
+)
+
+func (c *Controller) CreateProblemHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req ProblemRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validateProblemRequest(&req); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	revision := req.toDomain()
+	revision.AuthorId = user.Id
+
+	problem, err := scope.ProblemService(r.Context()).CreateProblem(r.Context(), revision)
+	if err != nil {
+		c.writeServiceError(w, err, "fail create problem")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("problem created", "problem_id", problem.Id, "author_id", problem.AuthorId)
+
+	c.writeJson(w, http.StatusCreated, newProblemResponse(problem, revision))
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication"
+	"net/http"
+)
+
+func (c *Controller) CreateSessionHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateSessionRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	token, session, err := scope.SessionService(r.Context()).CreateSession(r.Context(), req.Email, req.Password, authentication.ClientIp(r))
+	if err != nil {
+		c.writeServiceError(w, err, "fail create session")
+		return
+	}
+
+	c.logger.Info("session created", "user_id", session.UserId)
+
+	c.writeJson(w, http.StatusCreated, newSessionResponse(token, session))
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) CreateTeamHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req TeamRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validateTeamRequest(&req); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	team := &domain.Team{
+		Name:      req.Name,
+		CaptainId: user.Id,
+	}
+
+	err := scope.TeamService(r.Context()).CreateTeam(r.Context(), team)
+	if err != nil {
+		c.writeServiceError(w, err, "fail create team")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("team created", "team_id", team.Id, "captain_id", team.CaptainId)
+
+	c.writeJson(w, http.StatusCreated, newTeamResponse(team, user.Id))
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) DeleteContestHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	err = scope.ContestService(r.Context()).DeleteContest(r.Context(), domain.ContestId(id), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail delete contest")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("contest deleted", "contest_id", id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/application/authentication/bearer"
+	"cplatform/internal/presentation"
+	"net/http"
+)
+
+// DeleteSessionHandler revokes session token used to authenticate request
+func (c *Controller) DeleteSessionHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	token := bearer.GetToken(r.Context())
+	if token == "" {
+		c.writeErrors(w, http.StatusBadRequest, presentation.ErrNoSessionToken)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	err := scope.SessionService(r.Context()).RevokeSession(r.Context(), token)
+	if err != nil {
+		c.writeServiceError(w, err, "fail revoke session")
+		return
+	}
+
+	c.logger.Info("session revoked", "user_id", user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAllSessionsHandler revokes every session of user, e.g. after credentials leak
+func (c *Controller) DeleteAllSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	err := scope.SessionService(r.Context()).RevokeUserSessions(r.Context(), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail revoke user sessions")
+		return
+	}
+
+	c.logger.Info("all sessions revoked", "user_id", user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,21 +1,52 @@
 package controller
 
 import (
+	"context"
+	"cplatform/internal/application/authentication"
 	"cplatform/internal/application/authentication/basic"
+	"cplatform/pkg/slogext"
 	"net/http"
 )
 
-func (c *Controller) DeleteSelfUserHandler(w http.ResponseWriter, r *http.Request) {
-	panic("ABOBA")
+type DeleteUserRequest struct {
+	Password string `json:"password"`
+}
 
+func (c *Controller) DeleteSelfUserHandler(w http.ResponseWriter, r *http.Request) {
 	user := basic.GetUser(r.Context())
 	if user == nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(user.Email))
+	var req DeleteUserRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	service := scope.UserService(r.Context())
+
+	err := service.DeleteUser(r.Context(), user.Id, req.Password, authentication.ClientIp(r))
+	if err != nil {
+		c.writeServiceError(w, err, "fail delete user")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	// account is already anonymized, so revocation must not be skipped when client goes away
+	if err := service.RevokeUserAccess(context.WithoutCancel(r.Context()), user); err != nil {
+		c.logger.Error("fail revoke access of deleted user", "user_id", user.Id, slogext.Cause(err))
+	}
+
+	c.logger.Info("user deleted", "user_id", user.Id)
 
-	// TODO: complete implementation
+	w.WriteHeader(http.StatusNoContent)
 }
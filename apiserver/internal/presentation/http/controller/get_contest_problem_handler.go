@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) GetContestProblemHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	label, err := parseLabelVar(r)
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	problem, revision, err := scope.ContestService(r.Context()).GetContestProblemStatement(r.Context(), domain.ContestId(id), label, user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail get contest problem")
+		return
+	}
+
+	statement := newProblemRevisionResponse(revision)
+	res := ContestProblemStatementResponse{
+		ContestProblemResponse: newContestProblemResponse(problem),
+		Statement:              statement.Statement,
+		InputSpec:              statement.InputSpec,
+		OutputSpec:             statement.OutputSpec,
+		TimeLimitMs:            statement.TimeLimitMs,
+		MemoryLimitKb:          statement.MemoryLimitKb,
+		Tags:                   statement.Tags,
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) GetProblemHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	problem, revision, err := scope.ProblemService(r.Context()).GetProblem(r.Context(), domain.ProblemId(id), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail get problem")
+		return
+	}
+
+	c.writeJson(w, http.StatusOK, newProblemResponse(problem, revision))
+}
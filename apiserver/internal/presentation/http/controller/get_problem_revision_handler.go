@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) GetProblemRevisionHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	revisionNumber, err := parseIdVar(r, "revision")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	revision, err := scope.ProblemService(r.Context()).GetProblemRevision(r.Context(), domain.ProblemId(id), int(revisionNumber), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail get problem revision")
+		return
+	}
+
+	c.writeJson(w, http.StatusOK, newProblemRevisionResponse(revision))
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"net/http"
+)
+
+func (c *Controller) GetSelfUserHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	// authenticated user may come from cache which holds credentials only
+	self, err := scope.UserService(r.Context()).GetUser(r.Context(), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail get user")
+		return
+	}
+
+	c.writeJson(w, http.StatusOK, newSelfUserResponse(self))
+}
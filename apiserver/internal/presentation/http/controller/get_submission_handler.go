@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"net/http"
+)
+
+func (c *Controller) GetSubmissionHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	contestId, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := parseIdVar(r, "submission")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	submission, err := scope.SubmissionService(r.Context()).GetSubmission(r.Context(), domain.SubmissionId(id), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail get submission")
+		return
+	}
+
+	if submission.ContestId != domain.ContestId(contestId) {
+		c.writeErrors(w, http.StatusNotFound, presentation.ErrSubmissionNotFound)
+		return
+	}
+
+	c.writeJson(w, http.StatusOK, newSubmissionResponse(submission))
+}
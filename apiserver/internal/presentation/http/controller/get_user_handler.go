@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) GetUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	user, err := scope.UserService(r.Context()).GetUser(r.Context(), domain.UserId(id))
+	if err != nil {
+		c.writeServiceError(w, err, "fail get user")
+		return
+	}
+
+	c.writeJson(w, http.StatusOK, newUserResponse(user))
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (c *Controller) GrantUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	grant := &domain.RoleGrant{
+		UserId:    domain.UserId(id),
+		Role:      domain.Role(mux.Vars(r)["role"]),
+		GrantedBy: user.Id,
+	}
+
+	err = scope.RoleService(r.Context()).GrantRole(r.Context(), grant)
+	if err != nil {
+		c.writeServiceError(w, err, "fail grant user role")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("user role granted", "user_id", id, "role", grant.Role, "granted_by", user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/di/middleware"
+	"cplatform/internal/di/scope"
+	"cplatform/internal/presentation"
+	presentation_http "cplatform/internal/presentation/http"
+	"cplatform/pkg/slogext"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+const defaultPageLimit = 50
+const maxPageLimit = 200
+
+type serviceErrorMapping struct {
+	err    error
+	status int
+	apiErr *presentation.ApiError
+	// detailed mappings forward cause message to client, used for user input validation errors
+	detailed bool
+}
+
+// serviceErrorMappings translates application errors to coded api errors; first match wins
+var serviceErrorMappings = []serviceErrorMapping{
+	{context.Canceled, http.StatusRequestTimeout, presentation.ErrCancelled, false},
+	{context.DeadlineExceeded, http.StatusRequestTimeout, presentation.ErrDeadlineExceeded, false},
+	{application.ErrTooManyAttempts, http.StatusTooManyRequests, presentation.ErrTooManyAttempts, true},
+	{application.ErrWrongCredentials, http.StatusUnauthorized, presentation.ErrWrongCredentials, false},
+	{application.ErrInvalidSession, http.StatusUnauthorized, presentation.ErrInvalidSession, false},
+	{application.ErrUserNotFound, http.StatusNotFound, presentation.ErrUserNotFound, false},
+	{application.ErrDuplicateEmail, http.StatusConflict, presentation.ErrDuplicateEmail, false},
+	{application.ErrInvalidToken, http.StatusBadRequest, presentation.ErrInvalidToken, false},
+	{application.ErrEmailNotVerified, http.StatusForbidden, presentation.ErrEmailNotVerified, false},
+	{application.ErrIdentityProviderNotFound, http.StatusNotFound, presentation.ErrProviderNotFound, false},
+	{application.ErrExternalLoginFailed, http.StatusUnauthorized, presentation.ErrExternalLoginFailed, true},
+	{application.ErrAccessTokenNotFound, http.StatusNotFound, presentation.ErrAccessTokenNotFound, false},
+	{application.ErrDuplicateAccessToken, http.StatusConflict, presentation.ErrDuplicateAccessToken, false},
+	{application.ErrInvalidRole, http.StatusBadRequest, presentation.ErrInvalidRole, true},
+	{application.ErrRoleGrantNotFound, http.StatusNotFound, presentation.ErrRoleGrantNotFound, false},
+	{application.ErrContestNotFound, http.StatusNotFound, presentation.ErrContestNotFound, false},
+	{application.ErrContestProblemNotFound, http.StatusNotFound, presentation.ErrContestProblemNotFound, false},
+	{application.ErrProblemNotFound, http.StatusNotFound, presentation.ErrProblemNotFound, false},
+	{application.ErrInvalidProblem, http.StatusBadRequest, presentation.ErrInvalidProblem, true},
+	{application.ErrInvalidTests, http.StatusBadRequest, presentation.ErrInvalidTests, true},
+	{application.ErrTestsNotFound, http.StatusNotFound, presentation.ErrTestsNotFound, false},
+	{application.ErrContestNotRunning, http.StatusForbidden, presentation.ErrContestNotRunning, false},
+	{application.ErrRegistrationClosed, http.StatusForbidden, presentation.ErrRegistrationClosed, false},
+	{application.ErrContestFull, http.StatusConflict, presentation.ErrContestFull, false},
+	{application.ErrInvalidInviteCode, http.StatusForbidden, presentation.ErrInvalidInviteCode, false},
+	{application.ErrAlreadyRegistered, http.StatusConflict, presentation.ErrAlreadyRegistered, false},
+	{application.ErrNotRegistered, http.StatusForbidden, presentation.ErrNotRegistered, false},
+	{application.ErrSubmissionNotFound, http.StatusNotFound, presentation.ErrSubmissionNotFound, false},
+	{application.ErrLanguageNotFound, http.StatusBadRequest, presentation.ErrLanguageNotFound, true},
+	{application.ErrTeamNotFound, http.StatusNotFound, presentation.ErrTeamNotFound, false},
+	{application.ErrDuplicateTeamName, http.StatusConflict, presentation.ErrDuplicateTeamName, false},
+	{application.ErrTeamFull, http.StatusConflict, presentation.ErrTeamFull, false},
+	{application.ErrAlreadyTeamMember, http.StatusConflict, presentation.ErrAlreadyTeamMember, false},
+	{application.ErrAccessDenied, http.StatusForbidden, presentation.ErrAccessDenied, false},
+}
+
+func (c *Controller) writeErrors(w http.ResponseWriter, status int, errs ...error) {
+	if err := presentation_http.WriteErrors(w, status, errs...); err != nil {
+		c.logger.Error("fail write errors", slogext.Cause(err))
+	}
+}
+
+// WriteServiceError lets middlewares outside controller answer with the same coded api errors as handlers
+func (c *Controller) WriteServiceError(w http.ResponseWriter, err error, msg string) {
+	c.writeServiceError(w, err, msg)
+}
+
+func (c *Controller) writeServiceError(w http.ResponseWriter, err error, msg string) {
+	for _, mapping := range serviceErrorMappings {
+		if errors.Is(err, mapping.err) {
+			c.logger.Error(msg, slogext.Cause(err))
+
+			var sendError error = mapping.apiErr
+			if mapping.detailed {
+				sendError = fmt.Errorf("%w: %s", mapping.apiErr, err)
+			}
+
+			c.writeErrors(w, mapping.status, sendError)
+
+			return
+		}
+	}
+
+	c.logger.Error(msg+" with unexpected error", slogext.Cause(err))
+	c.writeErrors(w, http.StatusInternalServerError, err)
+}
+
+func (c *Controller) writeJson(w http.ResponseWriter, status int, body any) {
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		c.logger.Error("fail marshal response", slogext.Cause(err))
+		c.writeErrors(w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if _, err := w.Write(jsonBytes); err != nil {
+		c.logger.Error("fail write response", slogext.Cause(err))
+	}
+}
+
+func (c *Controller) decodeJson(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		c.writeErrors(w, http.StatusBadRequest, fmt.Errorf("%w: %s", presentation.ErrInvalidJsonSchema, err))
+
+		return false
+	}
+
+	return true
+}
+
+func (c *Controller) commit(w http.ResponseWriter, r *http.Request, s *scope.Scope) bool {
+	err := s.UnitOfWork(r.Context()).SaveChanges(r.Context())
+	if err != nil {
+		c.logger.Error("fail save changes", slogext.Cause(err))
+		c.writeErrors(w, http.StatusInternalServerError, err)
+
+		return false
+	}
+
+	return true
+}
+
+func (c *Controller) requestScope(w http.ResponseWriter, r *http.Request) *scope.Scope {
+	s := middleware.GetScope(r.Context())
+	if s == nil {
+		// TODO: migrate to coded api errors
+		c.logger.Error("fail to get request scope", "path", r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	return s
+}
+
+func parseIdVar(r *http.Request, name string) (int64, error) {
+	raw := mux.Vars(r)[name]
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: %s must be positive integer", presentation.ErrInvalidQueryParameter, name)
+	}
+
+	return id, nil
+}
+
+func parsePagination(r *http.Request) (offset int, limit int, errs []error) {
+	query := r.URL.Query()
+	limit = defaultPageLimit
+
+	if raw := query.Get("offset"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			errs = append(errs, fmt.Errorf("%w: offset must be non-negative integer", presentation.ErrInvalidQueryParameter))
+		} else {
+			offset = value
+		}
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 || value > maxPageLimit {
+			errs = append(errs, fmt.Errorf("%w: limit must be integer in [1, %d]", presentation.ErrInvalidQueryParameter, maxPageLimit))
+		} else {
+			limit = value
+		}
+	}
+
+	return offset, limit, errs
+}
+
+// valueOrDefault resolves optional request field, where nil means field was omitted
+func valueOrDefault[T any](value *T, defaultValue T) T {
+	if value == nil {
+		return defaultValue
+	}
+
+	return *value
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/presentation"
+	"fmt"
+	"net/http"
+)
+
+func (c *Controller) JoinTeamHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req JoinTeamRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if req.InviteToken == "" {
+		c.writeErrors(w, http.StatusBadRequest, fmt.Errorf("%w: invite token required", presentation.ErrInvalidTeam))
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	team, err := scope.TeamService(r.Context()).JoinTeam(r.Context(), req.InviteToken, user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail join team")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("team member joined", "team_id", team.Id, "user_id", user.Id)
+
+	c.writeJson(w, http.StatusOK, newTeamResponse(team, user.Id))
+}
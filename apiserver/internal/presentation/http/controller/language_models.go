@@ -0,0 +1,32 @@
+package controller
+
+import "cplatform/internal/domain"
+
+type LanguageResponse struct {
+	Id             string   `json:"id"`
+	Name           string   `json:"name"`
+	SourceFile     string   `json:"source_file"`
+	CompileCommand []string `json:"compile"`
+	RunCommand     []string `json:"run"`
+	TimeMultiplier float64  `json:"time_multiplier"`
+}
+
+type ListLanguagesResponse struct {
+	Languages []LanguageResponse `json:"languages"`
+}
+
+func newLanguageResponse(language *domain.Language) LanguageResponse {
+	compile := language.CompileCommand
+	if compile == nil {
+		compile = []string{}
+	}
+
+	return LanguageResponse{
+		Id:             language.Id,
+		Name:           language.Name,
+		SourceFile:     language.SourceFile,
+		CompileCommand: compile,
+		RunCommand:     language.RunCommand,
+		TimeMultiplier: language.TimeMultiplier,
+	}
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"net/http"
+)
+
+func (c *Controller) ListAccessTokensHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	tokens, err := scope.AccessTokenService(r.Context()).ListTokens(r.Context(), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail list access tokens")
+		return
+	}
+
+	c.writeJson(w, http.StatusOK, newListAccessTokensResponse(tokens))
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+type ListContestProblemsResponse struct {
+	Problems []ContestProblemResponse `json:"problems"`
+}
+
+func (c *Controller) ListContestProblemsHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	problems, err := scope.ContestService(r.Context()).ListContestProblems(r.Context(), domain.ContestId(id), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail list contest problems")
+		return
+	}
+
+	res := ListContestProblemsResponse{
+		Problems: make([]ContestProblemResponse, 0, len(problems)),
+	}
+	for _, problem := range problems {
+		res.Problems = append(res.Problems, newContestProblemResponse(problem))
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
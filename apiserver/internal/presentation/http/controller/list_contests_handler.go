@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"net/http"
+)
+
+type ListContestsResponse struct {
+	Contests []ContestResponse `json:"contests"`
+}
+
+func (c *Controller) ListContestsHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	offset, limit, errs := parsePagination(r)
+	if len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	contests, err := scope.ContestService(r.Context()).ListContests(r.Context(), user.Id, offset, limit)
+	if err != nil {
+		c.writeServiceError(w, err, "fail list contests")
+		return
+	}
+
+	res := ListContestsResponse{
+		Contests: make([]ContestResponse, 0, len(contests)),
+	}
+	for _, contest := range contests {
+		res.Contests = append(res.Contests, newContestResponse(contest, user.Id))
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
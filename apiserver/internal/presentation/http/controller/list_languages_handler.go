@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"net/http"
+)
+
+func (c *Controller) ListLanguagesHandler(w http.ResponseWriter, r *http.Request) {
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	languages, err := scope.LanguageService(r.Context()).ListLanguages(r.Context())
+	if err != nil {
+		c.writeServiceError(w, err, "fail list languages")
+		return
+	}
+
+	res := ListLanguagesResponse{
+		Languages: make([]LanguageResponse, 0, len(languages)),
+	}
+	for _, language := range languages {
+		res.Languages = append(res.Languages, newLanguageResponse(language))
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) ListParticipantsHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	offset, limit, errs := parsePagination(r)
+	if len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	participants, err := scope.ContestService(r.Context()).ListParticipants(r.Context(), domain.ContestId(id), user.Id, offset, limit)
+	if err != nil {
+		c.writeServiceError(w, err, "fail list participants")
+		return
+	}
+
+	res := ListParticipantsResponse{
+		Participants: make([]ParticipantResponse, 0, len(participants)),
+	}
+	for _, participant := range participants {
+		res.Participants = append(res.Participants, newParticipantResponse(participant))
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
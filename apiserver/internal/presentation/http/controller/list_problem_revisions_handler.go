@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+type ListProblemRevisionsResponse struct {
+	Revisions []ProblemRevisionResponse `json:"revisions"`
+}
+
+func (c *Controller) ListProblemRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	revisions, err := scope.ProblemService(r.Context()).ListProblemRevisions(r.Context(), domain.ProblemId(id), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail list problem revisions")
+		return
+	}
+
+	res := ListProblemRevisionsResponse{
+		Revisions: make([]ProblemRevisionResponse, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		res.Revisions = append(res.Revisions, newProblemRevisionResponse(revision))
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
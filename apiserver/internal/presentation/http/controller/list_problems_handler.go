@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"net/http"
+)
+
+type ListProblemsResponse struct {
+	Problems []ProblemRevisionResponse `json:"problems"`
+}
+
+func (c *Controller) ListProblemsHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	offset, limit, errs := parsePagination(r)
+	if len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	revisions, err := scope.ProblemService(r.Context()).ListProblems(r.Context(), user.Id, offset, limit)
+	if err != nil {
+		c.writeServiceError(w, err, "fail list problems")
+		return
+	}
+
+	res := ListProblemsResponse{
+		Problems: make([]ProblemRevisionResponse, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		res.Problems = append(res.Problems, newProblemRevisionResponse(revision))
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
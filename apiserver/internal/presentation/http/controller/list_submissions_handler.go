@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+type ListSubmissionsResponse struct {
+	Submissions []SubmissionResponse `json:"submissions"`
+}
+
+func (c *Controller) ListSubmissionsHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	offset, limit, errs := parsePagination(r)
+	if len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	list, err := scope.SubmissionService(r.Context()).ListOwnSubmissions(r.Context(), domain.ContestId(id), user.Id, offset, limit)
+	if err != nil {
+		c.writeServiceError(w, err, "fail list submissions")
+		return
+	}
+
+	res := ListSubmissionsResponse{
+		Submissions: make([]SubmissionResponse, 0, len(list)),
+	}
+	for _, submission := range list {
+		res.Submissions = append(res.Submissions, newSubmissionResponse(submission))
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"net/http"
+)
+
+func (c *Controller) ListTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	teams, err := scope.TeamService(r.Context()).ListTeams(r.Context(), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail list teams")
+		return
+	}
+
+	res := ListTeamsResponse{
+		Teams: make([]TeamResponse, 0, len(teams)),
+	}
+	for _, team := range teams {
+		res.Teams = append(res.Teams, newTeamResponse(team, user.Id))
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+const minProblemTitleLength = 1
+const maxProblemTitleLength = 200
+const maxProblemStatementLength = 200000
+const maxProblemSpecLength = 20000
+const minTimeLimitMs = 100
+const maxTimeLimitMs = 20000
+const minMemoryLimitKb = 16 * 1024
+const maxMemoryLimitKb = 2 * 1024 * 1024
+const maxProblemTags = 20
+const maxProblemTagLength = 50
+const maxCheckerEpsilon = 1
+
+var checkerKinds = map[string]domain.CheckerKind{
+	"":       domain.CheckerTokens,
+	"exact":  domain.CheckerExact,
+	"tokens": domain.CheckerTokens,
+	"float":  domain.CheckerFloat,
+	"custom": domain.CheckerCustom,
+}
+
+type ProblemRequest struct {
+	Title         string   `json:"title"`
+	Statement     string   `json:"statement"`
+	InputSpec     string   `json:"input_spec"`
+	OutputSpec    string   `json:"output_spec"`
+	TimeLimitMs   int64    `json:"time_limit_ms"`
+	MemoryLimitKb int64    `json:"memory_limit_kb"`
+	Tags          []string `json:"tags"`
+	// Checker is one of exact, tokens, float, custom; tokens by default
+	Checker string `json:"checker"`
+	// CheckerAbsEpsilon and CheckerRelEpsilon are only for float checker, omitted ones take domain defaults and 0 disables comparison
+	CheckerAbsEpsilon *float64 `json:"checker_abs_epsilon"`
+	CheckerRelEpsilon *float64 `json:"checker_rel_epsilon"`
+}
+
+type ProblemRevisionResponse struct {
+	ProblemId         int64     `json:"problem_id"`
+	Revision          int       `json:"revision"`
+	Title             string    `json:"title"`
+	Statement         string    `json:"statement"`
+	InputSpec         string    `json:"input_spec"`
+	OutputSpec        string    `json:"output_spec"`
+	TimeLimitMs       int64     `json:"time_limit_ms"`
+	MemoryLimitKb     int64     `json:"memory_limit_kb"`
+	Tags              []string  `json:"tags"`
+	Checker           string    `json:"checker"`
+	CheckerAbsEpsilon float64   `json:"checker_abs_epsilon"`
+	CheckerRelEpsilon float64   `json:"checker_rel_epsilon"`
+	AuthorId          int64     `json:"author_id"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type ProblemResponse struct {
+	Id             int64                   `json:"id"`
+	AuthorId       int64                   `json:"author_id"`
+	LatestRevision int                     `json:"latest_revision"`
+	CreatedAt      time.Time               `json:"created_at"`
+	Current        ProblemRevisionResponse `json:"current"`
+}
+
+func (req *ProblemRequest) toDomain() *domain.ProblemRevision {
+	revision := &domain.ProblemRevision{
+		Title:         req.Title,
+		Statement:     req.Statement,
+		InputSpec:     req.InputSpec,
+		OutputSpec:    req.OutputSpec,
+		TimeLimit:     time.Duration(req.TimeLimitMs) * time.Millisecond,
+		MemoryLimitKb: req.MemoryLimitKb,
+		Tags:          req.Tags,
+		Checker:       checkerKinds[req.Checker],
+	}
+
+	if revision.Checker == domain.CheckerFloat {
+		revision.CheckerAbsEpsilon = valueOrDefault(req.CheckerAbsEpsilon, domain.DefaultCheckerAbsEpsilon)
+		revision.CheckerRelEpsilon = valueOrDefault(req.CheckerRelEpsilon, domain.DefaultCheckerRelEpsilon)
+	}
+
+	return revision
+}
+
+func newProblemRevisionResponse(revision *domain.ProblemRevision) ProblemRevisionResponse {
+	tags := revision.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return ProblemRevisionResponse{
+		ProblemId:         int64(revision.ProblemId),
+		Revision:          revision.Revision,
+		Title:             revision.Title,
+		Statement:         revision.Statement,
+		InputSpec:         revision.InputSpec,
+		OutputSpec:        revision.OutputSpec,
+		TimeLimitMs:       revision.TimeLimit.Milliseconds(),
+		MemoryLimitKb:     revision.MemoryLimitKb,
+		Tags:              tags,
+		Checker:           string(revision.Checker),
+		CheckerAbsEpsilon: revision.CheckerAbsEpsilon,
+		CheckerRelEpsilon: revision.CheckerRelEpsilon,
+		AuthorId:          int64(revision.AuthorId),
+		CreatedAt:         revision.CreatedAt,
+	}
+}
+
+func newProblemResponse(problem *domain.Problem, revision *domain.ProblemRevision) ProblemResponse {
+	return ProblemResponse{
+		Id:             int64(problem.Id),
+		AuthorId:       int64(problem.AuthorId),
+		LatestRevision: problem.LatestRevision,
+		CreatedAt:      problem.CreatedAt,
+		Current:        newProblemRevisionResponse(revision),
+	}
+}
+
+func validateProblemRequest(req *ProblemRequest) []error {
+	var errs []error
+
+	titleLength := utf8.RuneCountInString(req.Title)
+	if titleLength < minProblemTitleLength {
+		errs = append(errs, fmt.Errorf("%w: title too short", presentation.ErrInvalidProblem))
+	} else if titleLength > maxProblemTitleLength {
+		errs = append(errs, fmt.Errorf("%w: title too long", presentation.ErrInvalidProblem))
+	}
+
+	if utf8.RuneCountInString(req.Statement) > maxProblemStatementLength {
+		errs = append(errs, fmt.Errorf("%w: statement too long", presentation.ErrInvalidProblem))
+	}
+
+	if utf8.RuneCountInString(req.InputSpec) > maxProblemSpecLength {
+		errs = append(errs, fmt.Errorf("%w: input specification too long", presentation.ErrInvalidProblem))
+	}
+
+	if utf8.RuneCountInString(req.OutputSpec) > maxProblemSpecLength {
+		errs = append(errs, fmt.Errorf("%w: output specification too long", presentation.ErrInvalidProblem))
+	}
+
+	if req.TimeLimitMs < minTimeLimitMs || req.TimeLimitMs > maxTimeLimitMs {
+		errs = append(errs, fmt.Errorf("%w: time limit must be in [%d, %d] ms", presentation.ErrInvalidProblem, minTimeLimitMs, maxTimeLimitMs))
+	}
+
+	if req.MemoryLimitKb < minMemoryLimitKb || req.MemoryLimitKb > maxMemoryLimitKb {
+		errs = append(errs, fmt.Errorf("%w: memory limit must be in [%d, %d] KiB", presentation.ErrInvalidProblem, minMemoryLimitKb, maxMemoryLimitKb))
+	}
+
+	if len(req.Tags) > maxProblemTags {
+		errs = append(errs, fmt.Errorf("%w: too many tags", presentation.ErrInvalidProblem))
+	}
+
+	for _, tag := range req.Tags {
+		length := utf8.RuneCountInString(tag)
+		if length == 0 || length > maxProblemTagLength {
+			errs = append(errs, fmt.Errorf("%w: tag %q has invalid length", presentation.ErrInvalidProblem, tag))
+		}
+	}
+
+	checker, ok := checkerKinds[req.Checker]
+	if !ok {
+		errs = append(errs, fmt.Errorf("%w: checker %q is unknown; valid are: exact, tokens, float, custom", presentation.ErrInvalidProblem, req.Checker))
+	}
+
+	if checker == domain.CheckerFloat {
+		absEpsilon := valueOrDefault(req.CheckerAbsEpsilon, domain.DefaultCheckerAbsEpsilon)
+		relEpsilon := valueOrDefault(req.CheckerRelEpsilon, domain.DefaultCheckerRelEpsilon)
+
+		for _, epsilon := range []struct {
+			name  string
+			value float64
+		}{{"absolute", absEpsilon}, {"relative", relEpsilon}} {
+			if !(epsilon.value >= 0 && epsilon.value < maxCheckerEpsilon) {
+				errs = append(errs, fmt.Errorf("%w: checker %s epsilon must be in [0, %d)", presentation.ErrInvalidProblem, epsilon.name, maxCheckerEpsilon))
+			}
+		}
+
+		if absEpsilon == 0 && relEpsilon == 0 {
+			errs = append(errs, fmt.Errorf("%w: float checker needs positive absolute or relative epsilon", presentation.ErrInvalidProblem))
+		}
+	} else if req.CheckerAbsEpsilon != nil || req.CheckerRelEpsilon != nil {
+		errs = append(errs, fmt.Errorf("%w: checker epsilon is allowed only for float checker", presentation.ErrInvalidProblem))
+	}
+
+	return errs
+}
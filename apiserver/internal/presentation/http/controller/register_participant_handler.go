@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) RegisterParticipantHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// body is optional, contests without invite code accept empty request
+	var req RegistrationRequest
+	if r.Body != http.NoBody && !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	participant, err := scope.ContestService(r.Context()).Register(r.Context(), domain.ContestId(id), user.Id, (*domain.TeamId)(req.TeamId), req.InviteCode)
+	if err != nil {
+		c.writeServiceError(w, err, "fail register participant")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("participant registered", "contest_id", id, "user_id", user.Id, "team_id", req.TeamId)
+
+	participant.UserName = user.Name
+
+	c.writeJson(w, http.StatusCreated, newParticipantResponse(participant))
+}
@@ -74,7 +74,9 @@ func (c *Controller) RegisterUserHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := scope.UserService(r.Context()).RegisterUser(r.Context(), req.Name, req.Email, req.Password)
+	service := scope.UserService(r.Context())
+
+	user, err := service.RegisterUser(r.Context(), req.Name, req.Email, req.Password)
 
 	if err != nil {
 		var status int
@@ -120,6 +122,12 @@ func (c *Controller) RegisterUserHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// user is saved already, so failed mail is not reported as failed registration; token can be requested again
+	err = service.ResendVerification(context.WithoutCancel(r.Context()), user.Id)
+	if err != nil {
+		c.logger.Error("fail send verification", "user_id", user.Id, slogext.Cause(err))
+	}
+
 	c.logger.Info("user created", "email", req.Email)
 
 	w.WriteHeader(http.StatusCreated)
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) RemoveContestProblemHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	label, err := parseLabelVar(r)
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	err = scope.ContestService(r.Context()).RemoveContestProblem(r.Context(), domain.ContestId(id), label, user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail unpin contest problem")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("contest problem unpinned", "contest_id", id, "label", label)
+
+	w.WriteHeader(http.StatusNoContent)
+}
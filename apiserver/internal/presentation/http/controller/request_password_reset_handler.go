@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+)
+
+func (c *Controller) RequestPasswordResetHandler(w http.ResponseWriter, r *http.Request) {
+	var req PasswordResetRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validateEmail(req.Email); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	err := scope.UserService(r.Context()).RequestPasswordReset(r.Context(), req.Email)
+	if err != nil {
+		c.writeServiceError(w, err, "fail request password reset")
+		return
+	}
+
+	// response is the same whether email is registered or not
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"net/http"
+)
+
+func (c *Controller) ResendVerificationHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	err := scope.UserService(r.Context()).ResendVerification(r.Context(), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail resend verification")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
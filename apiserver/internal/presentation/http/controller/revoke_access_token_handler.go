@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) RevokeAccessTokenHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	err = scope.AccessTokenService(r.Context()).RevokeToken(r.Context(), user.Id, domain.AccessTokenId(id))
+	if err != nil {
+		c.writeServiceError(w, err, "fail revoke access token")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("access token revoked", "token_id", id, "user_id", user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (c *Controller) RevokeContestRoleHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userId, err := parseIdVar(r, "user")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	contestId := domain.ContestId(id)
+	grant := &domain.RoleGrant{
+		UserId:    domain.UserId(userId),
+		Role:      domain.Role(mux.Vars(r)["role"]),
+		ContestId: &contestId,
+	}
+
+	err = scope.RoleService(r.Context()).RevokeRole(r.Context(), grant, user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail revoke contest role")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("contest role revoked", "contest_id", id, "user_id", userId, "role", grant.Role, "revoked_by", user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (c *Controller) RevokeUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	grant := &domain.RoleGrant{
+		UserId: domain.UserId(id),
+		Role:   domain.Role(mux.Vars(r)["role"]),
+	}
+
+	err = scope.RoleService(r.Context()).RevokeRole(r.Context(), grant, user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail revoke user role")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("user role revoked", "user_id", id, "role", grant.Role, "revoked_by", user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"time"
+)
+
+type RoleGrantResponse struct {
+	UserId    int64     `json:"user_id"`
+	Role      string    `json:"role"`
+	ContestId *int64    `json:"contest_id,omitempty"`
+	GrantedBy int64     `json:"granted_by"`
+	GrantedAt time.Time `json:"granted_at"`
+}
+
+type ListRolesResponse struct {
+	Roles []RoleGrantResponse `json:"roles"`
+}
+
+func newListRolesResponse(grants []*domain.RoleGrant) ListRolesResponse {
+	res := ListRolesResponse{
+		Roles: make([]RoleGrantResponse, 0, len(grants)),
+	}
+
+	for _, grant := range grants {
+		res.Roles = append(res.Roles, RoleGrantResponse{
+			UserId:    int64(grant.UserId),
+			Role:      string(grant.Role),
+			ContestId: (*int64)(grant.ContestId),
+			GrantedBy: int64(grant.GrantedBy),
+			GrantedAt: grant.GrantedAt,
+		})
+	}
+
+	return res
+}
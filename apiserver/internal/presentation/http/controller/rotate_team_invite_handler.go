@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) RotateTeamInviteHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	team, err := scope.TeamService(r.Context()).RotateInviteToken(r.Context(), domain.TeamId(id), user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail rotate team invite")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("team invite rotated", "team_id", id)
+
+	c.writeJson(w, http.StatusOK, newTeamResponse(team, user.Id))
+}
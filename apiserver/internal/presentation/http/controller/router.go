@@ -1,8 +1,12 @@
 package controller
 
 import (
+	"cplatform/internal/application/authentication/accesstoken"
 	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/application/authentication/bearer"
+	"cplatform/internal/application/authorization/rbac"
 	"cplatform/internal/di/middleware"
+	"cplatform/internal/domain"
 	"log/slog"
 	"net/http"
 
@@ -12,11 +16,14 @@ import (
 )
 
 type Router struct {
-	controller   *Controller
-	useIsoLevel  *middleware.IsoLevelMiddleware
-	useScope     *middleware.ScopeMiddleware
-	useBasicAuth *basic.BasicAuthMiddleware
-	logger       *slog.Logger
+	controller    *Controller
+	useIsoLevel   *middleware.IsoLevelMiddleware
+	useScope      *middleware.ScopeMiddleware
+	useBasicAuth  *basic.BasicAuthMiddleware
+	useBearerAuth *bearer.BearerAuthMiddleware
+	useTokenAuth  *accesstoken.AccessTokenAuthMiddleware
+	usePermission *rbac.PermissionMiddleware
+	logger        *slog.Logger
 }
 
 func NewRouter(
@@ -24,14 +31,20 @@ func NewRouter(
 	useIsoLevel *middleware.IsoLevelMiddleware,
 	useScope *middleware.ScopeMiddleware,
 	useBasicAuth *basic.BasicAuthMiddleware,
+	useBearerAuth *bearer.BearerAuthMiddleware,
+	useTokenAuth *accesstoken.AccessTokenAuthMiddleware,
+	usePermission *rbac.PermissionMiddleware,
 	logger *slog.Logger,
 ) *Router {
 	return &Router{
-		controller:   controller,
-		useIsoLevel:  useIsoLevel,
-		useScope:     useScope,
-		useBasicAuth: useBasicAuth,
-		logger:       logger,
+		controller:    controller,
+		useIsoLevel:   useIsoLevel,
+		useScope:      useScope,
+		useBasicAuth:  useBasicAuth,
+		useBearerAuth: useBearerAuth,
+		useTokenAuth:  useTokenAuth,
+		usePermission: usePermission,
+		logger:        logger,
 	}
 }
 
@@ -42,18 +55,219 @@ func (r *Router) CreateHandler() http.Handler {
 
 	v1 := api.PathPrefix("/v1").Subrouter()
 
-	v1.Handle("/users",
-		r.useIsoLevel.Middleware(pgx.ReadCommitted,
-			r.useScope.Middleware(
-				http.HandlerFunc(r.controller.RegisterUserHandler)))).
+	v1.Handle("/users", r.anonymous(pgx.ReadCommitted, r.controller.RegisterUserHandler)).
 		Methods(http.MethodPost)
 
-	v1.Handle("/users",
-		r.useIsoLevel.Middleware(pgx.ReadCommitted,
-			r.useScope.Middleware(
-				r.useBasicAuth.Middleware(
-					http.HandlerFunc(r.controller.DeleteSelfUserHandler))))).
+	v1.Handle("/users", r.authenticated(pgx.ReadCommitted, r.controller.DeleteSelfUserHandler)).
 		Methods(http.MethodDelete)
 
+	v1.Handle("/users/me", r.authenticated(pgx.ReadCommitted, r.controller.GetSelfUserHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/users/me", r.authenticated(pgx.ReadCommitted, r.controller.UpdateSelfUserHandler)).
+		Methods(http.MethodPatch)
+
+	v1.Handle("/users/email/confirmation", r.anonymous(pgx.ReadCommitted, r.controller.ConfirmEmailChangeHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/users/me/password", r.authenticated(pgx.ReadCommitted, r.controller.ChangePasswordHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/users/password-reset", r.anonymous(pgx.ReadCommitted, r.controller.RequestPasswordResetHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/users/password-reset/confirmation", r.anonymous(pgx.ReadCommitted, r.controller.ConfirmPasswordResetHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/users/verify", r.anonymous(pgx.ReadCommitted, r.controller.VerifyEmailHandler)).
+		Methods(http.MethodGet, http.MethodPost)
+
+	v1.Handle("/users/me/verification", r.authenticated(pgx.ReadCommitted, r.controller.ResendVerificationHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/users/{id:[0-9]+}", r.authenticated(pgx.ReadCommitted, r.controller.GetUserHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/users/me/tokens", r.authenticated(pgx.ReadCommitted, r.controller.CreateAccessTokenHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/users/me/tokens", r.authenticated(pgx.ReadCommitted, r.controller.ListAccessTokensHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/users/me/tokens/{id:[0-9]+}", r.authenticated(pgx.ReadCommitted, r.controller.RevokeAccessTokenHandler)).
+		Methods(http.MethodDelete)
+
+	v1.Handle("/sessions", r.anonymous(pgx.ReadCommitted, r.controller.CreateSessionHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/sessions", r.authenticated(pgx.ReadCommitted, r.controller.DeleteAllSessionsHandler)).
+		Methods(http.MethodDelete)
+
+	v1.Handle("/sessions/current", r.authenticated(pgx.ReadCommitted, r.controller.DeleteSessionHandler)).
+		Methods(http.MethodDelete)
+
+	v1.Handle("/oidc/{provider}/login", r.anonymous(pgx.ReadCommitted, r.controller.StartExternalLoginHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/oidc/{provider}/callback", r.anonymous(pgx.ReadCommitted, r.controller.CompleteExternalLoginHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/users/{id:[0-9]+}/roles", r.authenticated(pgx.ReadCommitted, r.controller.ListUserRolesHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/users/{id:[0-9]+}/roles/{role}", r.authorized(pgx.ReadCommitted, domain.PermissionManageRoles, r.controller.GrantUserRoleHandler)).
+		Methods(http.MethodPut)
+
+	v1.Handle("/users/{id:[0-9]+}/roles/{role}", r.authorized(pgx.ReadCommitted, domain.PermissionManageRoles, r.controller.RevokeUserRoleHandler)).
+		Methods(http.MethodDelete)
+
+	v1.Handle("/languages", r.anonymous(pgx.ReadCommitted, r.controller.ListLanguagesHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/contests", r.authenticated(pgx.ReadCommitted, r.controller.CreateContestHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/contests", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.ListContestsHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/contests/{id:[0-9]+}", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.GetContestHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/contests/{id:[0-9]+}", r.authenticated(pgx.ReadCommitted, r.controller.UpdateContestHandler)).
+		Methods(http.MethodPut)
+
+	v1.Handle("/contests/{id:[0-9]+}", r.authenticated(pgx.ReadCommitted, r.controller.DeleteContestHandler)).
+		Methods(http.MethodDelete)
+
+	v1.Handle("/contests/{id:[0-9]+}/problems", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.ListContestProblemsHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/contests/{id:[0-9]+}/problems/{label}", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.GetContestProblemHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/contests/{id:[0-9]+}/problems/{label}", r.authenticated(pgx.ReadCommitted, r.controller.SetContestProblemHandler)).
+		Methods(http.MethodPut)
+
+	v1.Handle("/contests/{id:[0-9]+}/problems/{label}", r.authenticated(pgx.ReadCommitted, r.controller.RemoveContestProblemHandler)).
+		Methods(http.MethodDelete)
+
+	v1.Handle("/contests/{id:[0-9]+}/registration", r.authenticated(pgx.ReadCommitted, r.controller.RegisterParticipantHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/contests/{id:[0-9]+}/registration", r.authenticated(pgx.ReadCommitted, r.controller.UnregisterParticipantHandler)).
+		Methods(http.MethodDelete)
+
+	v1.Handle("/contests/{id:[0-9]+}/participants", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.ListParticipantsHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/contests/{id:[0-9]+}/roles", r.authenticated(pgx.ReadCommitted, r.controller.ListContestRolesHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/contests/{id:[0-9]+}/roles/{role}/{user:[0-9]+}", r.authenticated(pgx.ReadCommitted, r.controller.GrantContestRoleHandler)).
+		Methods(http.MethodPut)
+
+	v1.Handle("/contests/{id:[0-9]+}/roles/{role}/{user:[0-9]+}", r.authenticated(pgx.ReadCommitted, r.controller.RevokeContestRoleHandler)).
+		Methods(http.MethodDelete)
+
+	v1.Handle("/contests/{id:[0-9]+}/submissions", r.tokenScope(domain.TokenScopeSubmit, r.authenticated(pgx.ReadCommitted, r.controller.SubmitHandler))).
+		Methods(http.MethodPost)
+
+	v1.Handle("/contests/{id:[0-9]+}/submissions", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.ListSubmissionsHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/contests/{id:[0-9]+}/submissions/{submission:[0-9]+}", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.GetSubmissionHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/contests/{id:[0-9]+}/standings", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.RepeatableRead, r.controller.GetStandingsHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/teams", r.authenticated(pgx.ReadCommitted, r.controller.CreateTeamHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/teams", r.authenticated(pgx.ReadCommitted, r.controller.ListTeamsHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/teams/join", r.authenticated(pgx.ReadCommitted, r.controller.JoinTeamHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/teams/{id:[0-9]+}", r.authenticated(pgx.ReadCommitted, r.controller.GetTeamHandler)).
+		Methods(http.MethodGet)
+
+	v1.Handle("/teams/{id:[0-9]+}/membership", r.authenticated(pgx.ReadCommitted, r.controller.LeaveTeamHandler)).
+		Methods(http.MethodDelete)
+
+	v1.Handle("/teams/{id:[0-9]+}/invite", r.authenticated(pgx.ReadCommitted, r.controller.RotateTeamInviteHandler)).
+		Methods(http.MethodPost)
+
+	v1.Handle("/problems", r.tokenScope(domain.TokenScopeProblems, r.authorized(pgx.ReadCommitted, domain.PermissionCreateProblem, r.controller.CreateProblemHandler))).
+		Methods(http.MethodPost)
+
+	v1.Handle("/problems", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.ListProblemsHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/problems/{id:[0-9]+}", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.GetProblemHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/problems/{id:[0-9]+}", r.tokenScope(domain.TokenScopeProblems, r.authenticated(pgx.ReadCommitted, r.controller.UpdateProblemHandler))).
+		Methods(http.MethodPut)
+
+	v1.Handle("/problems/{id:[0-9]+}/revisions", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.ListProblemRevisionsHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/problems/{id:[0-9]+}/revisions/{revision:[0-9]+}", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.GetProblemRevisionHandler))).
+		Methods(http.MethodGet)
+
+	v1.Handle("/problems/{id:[0-9]+}/tests", r.tokenScope(domain.TokenScopeProblems, r.authenticated(pgx.ReadCommitted, r.controller.UploadTestsHandler))).
+		Methods(http.MethodPost)
+
+	v1.Handle("/problems/{id:[0-9]+}/revisions/{revision:[0-9]+}/tests", r.tokenScope(domain.TokenScopeRead, r.authenticated(pgx.ReadCommitted, r.controller.GetTestsHandler))).
+		Methods(http.MethodGet)
+
 	return m
 }
+
+func (r *Router) anonymous(level pgx.TxIsoLevel, handler http.HandlerFunc) http.Handler {
+	return r.useIsoLevel.Middleware(level,
+		r.useScope.Middleware(
+			handler))
+}
+
+func (r *Router) authenticated(level pgx.TxIsoLevel, handler http.HandlerFunc) http.Handler {
+	return r.useIsoLevel.Middleware(level,
+		r.useScope.Middleware(
+			r.authenticate(
+				handler)))
+}
+
+// authorized additionally requires global permission; per resource checks stay in services
+func (r *Router) authorized(level pgx.TxIsoLevel, permission domain.Permission, handler http.HandlerFunc) http.Handler {
+	return r.useIsoLevel.Middleware(level,
+		r.useScope.Middleware(
+			r.authenticate(
+				r.usePermission.Middleware(permission,
+					handler))))
+}
+
+// authenticate accepts personal access tokens and bearer session tokens and falls back to basic auth for other schemes
+func (r *Router) authenticate(next http.Handler) http.Handler {
+	withToken := r.useTokenAuth.Middleware(next)
+	withBearer := r.useBearerAuth.Middleware(next)
+	withBasic := r.useBasicAuth.Middleware(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if accesstoken.HasToken(req) {
+			withToken.ServeHTTP(w, req)
+		} else if bearer.HasToken(req) {
+			withBearer.ServeHTTP(w, req)
+		} else {
+			withBasic.ServeHTTP(w, req)
+		}
+	})
+}
+
+// tokenScope opens route to personal access tokens holding scope; other routes accept only sessions and passwords
+func (r *Router) tokenScope(scope domain.TokenScope, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(w, req.WithContext(accesstoken.WithScope(req.Context(), scope)))
+	})
+}
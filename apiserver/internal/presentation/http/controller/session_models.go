@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"time"
+)
+
+type CreateSessionRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type SessionResponse struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"token_type"`
+	UserId    int64     `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func newSessionResponse(token string, session *domain.Session) SessionResponse {
+	return SessionResponse{
+		Token:     token,
+		TokenType: "Bearer",
+		UserId:    int64(session.UserId),
+		ExpiresAt: session.ExpiresAt,
+	}
+}
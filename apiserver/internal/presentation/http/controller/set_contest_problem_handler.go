@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"fmt"
+	"net/http"
+)
+
+func (c *Controller) SetContestProblemHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	label, err := parseLabelVar(r)
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req SetContestProblemRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if req.ProblemId <= 0 || req.Revision <= 0 {
+		c.writeErrors(w, http.StatusBadRequest, fmt.Errorf("%w: problem id and revision must be positive", presentation.ErrInvalidContestProblem))
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	problem := &domain.ContestProblem{
+		ContestId: domain.ContestId(id),
+		Label:     label,
+		ProblemId: domain.ProblemId(req.ProblemId),
+		Revision:  req.Revision,
+	}
+
+	err = scope.ContestService(r.Context()).SetContestProblem(r.Context(), problem, user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail pin contest problem")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("contest problem pinned", "contest_id", id, "label", label, "problem_id", req.ProblemId, "revision", req.Revision)
+
+	c.writeJson(w, http.StatusOK, newContestProblemResponse(problem))
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"time"
+)
+
+type StandingsCellResponse struct {
+	Label    string `json:"label"`
+	Solved   bool   `json:"solved"`
+	Attempts int    `json:"attempts"`
+	// SolvedAtMinutes is minutes since contest start, only meaningful when problem is solved
+	SolvedAtMinutes int `json:"solved_at_minutes"`
+	Score           int `json:"score"`
+	Pending         int `json:"pending"`
+}
+
+type StandingsRowResponse struct {
+	Rank           int                     `json:"rank"`
+	UserId         int64                   `json:"user_id"`
+	TeamId         *int64                  `json:"team_id,omitempty"`
+	Name           string                  `json:"name"`
+	Solved         int                     `json:"solved"`
+	PenaltyMinutes int                     `json:"penalty_minutes"`
+	Score          int                     `json:"score"`
+	Cells          []StandingsCellResponse `json:"cells"`
+}
+
+type StandingsResponse struct {
+	ContestId   int64                  `json:"contest_id"`
+	ScoringMode string                 `json:"scoring_mode"`
+	Problems    []string               `json:"problems"`
+	Frozen      bool                   `json:"frozen"`
+	Rows        []StandingsRowResponse `json:"rows"`
+	GeneratedAt time.Time              `json:"generated_at"`
+}
+
+func newStandingsResponse(standings *domain.Standings) StandingsResponse {
+	problems := standings.Problems
+	if problems == nil {
+		problems = []string{}
+	}
+
+	response := StandingsResponse{
+		ContestId:   int64(standings.ContestId),
+		ScoringMode: string(standings.Mode),
+		Problems:    problems,
+		Frozen:      standings.Frozen,
+		Rows:        make([]StandingsRowResponse, 0, len(standings.Rows)),
+		GeneratedAt: standings.GeneratedAt,
+	}
+
+	for _, row := range standings.Rows {
+		rowResponse := StandingsRowResponse{
+			Rank:           row.Rank,
+			UserId:         int64(row.UserId),
+			TeamId:         (*int64)(row.TeamId),
+			Name:           row.Name,
+			Solved:         row.Solved,
+			PenaltyMinutes: row.PenaltyMinutes,
+			Score:          row.Score,
+			Cells:          make([]StandingsCellResponse, 0, len(row.Cells)),
+		}
+
+		for _, cell := range row.Cells {
+			rowResponse.Cells = append(rowResponse.Cells, StandingsCellResponse{
+				Label:           cell.Label,
+				Solved:          cell.Solved,
+				Attempts:        cell.Attempts,
+				SolvedAtMinutes: int(cell.SolvedAt / time.Minute),
+				Score:           cell.Score,
+				Pending:         cell.Pending,
+			})
+		}
+
+		response.Rows = append(response.Rows, rowResponse)
+	}
+
+	return response
+}
@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (c *Controller) StartExternalLoginHandler(w http.ResponseWriter, r *http.Request) {
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	provider := mux.Vars(r)["provider"]
+
+	authorizationUrl, err := scope.IdentityService(r.Context()).StartLogin(r.Context(), provider)
+	if err != nil {
+		c.writeServiceError(w, err, "fail start external login")
+		return
+	}
+
+	http.Redirect(w, r, authorizationUrl, http.StatusFound)
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const maxSourceLength = 64 * 1024
+
+var languageIdPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+_.-]{0,31}$`)
+
+type SubmitRequest struct {
+	Problem  string `json:"problem"`
+	Language string `json:"language"`
+	Source   string `json:"source"`
+}
+
+type SubmissionResponse struct {
+	Id              int64                     `json:"id"`
+	ContestId       int64                     `json:"contest_id"`
+	Problem         string                    `json:"problem"`
+	ProblemId       int64                     `json:"problem_id"`
+	ProblemRevision int                       `json:"problem_revision"`
+	UserId          int64                     `json:"user_id"`
+	TeamId          *int64                    `json:"team_id,omitempty"`
+	Language        string                    `json:"language"`
+	State           string                    `json:"state"`
+	SubmittedAt     time.Time                 `json:"submitted_at"`
+	Result          *SubmissionResultResponse `json:"result,omitempty"`
+}
+
+type SubmissionResultResponse struct {
+	Verdict       string    `json:"verdict"`
+	Score         int       `json:"score"`
+	SubtaskScores []int     `json:"subtask_scores"`
+	FailedTest    int       `json:"failed_test,omitempty"`
+	TimeMs        int64     `json:"time_ms"`
+	MemoryKb      int64     `json:"memory_kb"`
+	JudgeLog      string    `json:"judge_log,omitempty"`
+	JudgedAt      time.Time `json:"judged_at"`
+}
+
+func newSubmissionResponse(submission *domain.Submission) SubmissionResponse {
+	res := SubmissionResponse{
+		Id:              int64(submission.Id),
+		ContestId:       int64(submission.ContestId),
+		Problem:         submission.ProblemLabel,
+		ProblemId:       int64(submission.ProblemId),
+		ProblemRevision: submission.ProblemRevision,
+		UserId:          int64(submission.UserId),
+		TeamId:          (*int64)(submission.TeamId),
+		Language:        submission.LanguageId,
+		State:           string(submission.State),
+		SubmittedAt:     submission.SubmittedAt,
+	}
+
+	if result := submission.Result; result != nil {
+		subtaskScores := result.SubtaskScores
+		if subtaskScores == nil {
+			subtaskScores = []int{}
+		}
+
+		res.Result = &SubmissionResultResponse{
+			Verdict:       string(result.Verdict),
+			Score:         result.Score,
+			SubtaskScores: subtaskScores,
+			FailedTest:    result.FailedTest,
+			TimeMs:        result.TimeUsed.Milliseconds(),
+			MemoryKb:      result.MemoryUsedKb,
+			JudgeLog:      result.JudgeLog,
+			JudgedAt:      result.JudgedAt,
+		}
+	}
+
+	return res
+}
+
+func validateSubmitRequest(req *SubmitRequest) []error {
+	var errs []error
+
+	if len(req.Problem) == 0 || len(req.Problem) > maxContestProblemLabelLength || !allRunesInAlphabet(req.Problem, nameAlphabet) {
+		errs = append(errs, fmt.Errorf("%w: problem label is invalid", presentation.ErrInvalidSubmission))
+	}
+
+	if !languageIdPattern.MatchString(req.Language) {
+		errs = append(errs, fmt.Errorf("%w: language id is invalid", presentation.ErrInvalidSubmission))
+	}
+
+	if len(req.Source) == 0 {
+		errs = append(errs, fmt.Errorf("%w: source is empty", presentation.ErrInvalidSubmission))
+	} else if len(req.Source) > maxSourceLength {
+		errs = append(errs, fmt.Errorf("%w: source exceeds %d bytes", presentation.ErrInvalidSubmission, maxSourceLength))
+	}
+
+	return errs
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"cplatform/pkg/slogext"
+	"net/http"
+)
+
+// maxSubmitBodySize leaves room for json escaping of source
+const maxSubmitBodySize = 4 * maxSourceLength
+
+func (c *Controller) SubmitHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxSubmitBodySize)
+
+	var req SubmitRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validateSubmitRequest(&req); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	submission := &domain.Submission{
+		ContestId:    domain.ContestId(id),
+		ProblemLabel: req.Problem,
+		UserId:       user.Id,
+		LanguageId:   req.Language,
+		Source:       req.Source,
+	}
+
+	service := scope.SubmissionService(r.Context())
+
+	err = service.Submit(r.Context(), submission)
+	if err != nil {
+		c.writeServiceError(w, err, "fail submit")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	// submission is already persisted as queued; if enqueue fails, judge requeuer picks it up once it is stale
+	if err := service.EnqueueSubmission(context.WithoutCancel(r.Context()), submission.Id); err != nil {
+		c.logger.Error("fail enqueue submission", "submission_id", submission.Id, slogext.Cause(err))
+	}
+
+	c.logger.Info("submission accepted", "submission_id", submission.Id, "contest_id", submission.ContestId, "user_id", submission.UserId)
+
+	c.writeJson(w, http.StatusAccepted, newSubmissionResponse(submission))
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const minTeamNameLength = 1
+const maxTeamNameLength = 64
+
+type TeamRequest struct {
+	Name string `json:"name"`
+}
+
+type JoinTeamRequest struct {
+	InviteToken string `json:"invite_token"`
+}
+
+type TeamMemberResponse struct {
+	UserId   int64     `json:"user_id"`
+	Name     string    `json:"name"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type TeamResponse struct {
+	Id        int64                `json:"id"`
+	Name      string               `json:"name"`
+	CaptainId int64                `json:"captain_id"`
+	Members   []TeamMemberResponse `json:"members"`
+	// InviteToken is shown only to team captain
+	InviteToken string    `json:"invite_token,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ListTeamsResponse struct {
+	Teams []TeamResponse `json:"teams"`
+}
+
+func newTeamResponse(team *domain.Team, viewerId domain.UserId) TeamResponse {
+	res := TeamResponse{
+		Id:        int64(team.Id),
+		Name:      team.Name,
+		CaptainId: int64(team.CaptainId),
+		Members:   make([]TeamMemberResponse, 0, len(team.Members)),
+		CreatedAt: team.CreatedAt,
+	}
+
+	if team.CaptainId == viewerId {
+		res.InviteToken = team.InviteToken
+	}
+
+	for _, member := range team.Members {
+		res.Members = append(res.Members, TeamMemberResponse{
+			UserId:   int64(member.UserId),
+			Name:     member.Name,
+			JoinedAt: member.JoinedAt,
+		})
+	}
+
+	return res
+}
+
+func validateTeamRequest(req *TeamRequest) []error {
+	var errs []error
+
+	nameLength := utf8.RuneCountInString(req.Name)
+	if nameLength < minTeamNameLength {
+		errs = append(errs, fmt.Errorf("%w: name too short", presentation.ErrInvalidTeam))
+	} else if nameLength > maxTeamNameLength {
+		errs = append(errs, fmt.Errorf("%w: name too long", presentation.ErrInvalidTeam))
+	}
+
+	if strings.TrimSpace(req.Name) != req.Name {
+		errs = append(errs, fmt.Errorf("%w: name must not start or end with whitespace", presentation.ErrInvalidTeam))
+	}
+
+	return errs
+}
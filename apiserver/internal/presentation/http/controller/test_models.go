@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"time"
+)
+
+type TestResponse struct {
+	Ordinal    int   `json:"ordinal"`
+	Subtask    int   `json:"subtask"`
+	IsSample   bool  `json:"is_sample"`
+	InputSize  int64 `json:"input_size"`
+	AnswerSize int64 `json:"answer_size"`
+}
+
+type SubtaskResponse struct {
+	Index int `json:"index"`
+	Score int `json:"score"`
+}
+
+type TestSetResponse struct {
+	Id         int64             `json:"id"`
+	ProblemId  int64             `json:"problem_id"`
+	Tests      []TestResponse    `json:"tests"`
+	Subtasks   []SubtaskResponse `json:"subtasks"`
+	HasChecker bool              `json:"has_checker"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+func newTestSetResponse(set *domain.TestSet) TestSetResponse {
+	res := TestSetResponse{
+		Id:         int64(set.Id),
+		ProblemId:  int64(set.ProblemId),
+		Tests:      make([]TestResponse, 0, len(set.Tests)),
+		Subtasks:   make([]SubtaskResponse, 0, len(set.Subtasks)),
+		HasChecker: set.CheckerSource != "",
+		CreatedAt:  set.CreatedAt,
+	}
+
+	for _, test := range set.Tests {
+		res.Tests = append(res.Tests, TestResponse{
+			Ordinal:    test.Ordinal,
+			Subtask:    test.Subtask,
+			IsSample:   test.IsSample,
+			InputSize:  test.InputSize,
+			AnswerSize: test.AnswerSize,
+		})
+	}
+
+	for _, subtask := range set.Subtasks {
+		res.Subtasks = append(res.Subtasks, SubtaskResponse{Index: subtask.Index, Score: subtask.Score})
+	}
+
+	return res
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) UpdateContestHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req ContestRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validateContestRequest(&req); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	contest := req.toDomain()
+	contest.Id = domain.ContestId(id)
+
+	err = scope.ContestService(r.Context()).UpdateContest(r.Context(), contest, user.Id)
+	if err != nil {
+		c.writeServiceError(w, err, "fail update contest")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("contest updated", "contest_id", contest.Id)
+
+	c.writeJson(w, http.StatusOK, newContestResponse(contest, user.Id))
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/domain"
+	"net/http"
+)
+
+func (c *Controller) UpdateProblemHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req ProblemRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validateProblemRequest(&req); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	revision := req.toDomain()
+	revision.ProblemId = domain.ProblemId(id)
+	revision.AuthorId = user.Id
+
+	err = scope.ProblemService(r.Context()).UpdateProblem(r.Context(), revision)
+	if err != nil {
+		c.writeServiceError(w, err, "fail update problem")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("problem revision created", "problem_id", revision.ProblemId, "revision", revision.Revision)
+
+	c.writeJson(w, http.StatusCreated, newProblemRevisionResponse(revision))
+}
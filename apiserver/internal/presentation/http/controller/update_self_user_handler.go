@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/pkg/slogext"
+	"net/http"
+)
+
+func (c *Controller) UpdateSelfUserHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req UpdateProfileRequest
+	if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	if errs := validateUpdateProfileRequest(&req); len(errs) > 0 {
+		c.writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	service := scope.UserService(r.Context())
+
+	updated, err := service.UpdateProfile(r.Context(), &application.ProfileUpdate{
+		UserId:       user.Id,
+		Name:         req.Name,
+		Email:        req.Email,
+		Country:      req.Country,
+		Organization: req.Organization,
+		Bio:          req.Bio,
+	})
+	if err != nil {
+		c.writeServiceError(w, err, "fail update profile")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	// cached credentials hold name, so they are dropped on any change
+	if err := service.InvalidateUserCache(context.WithoutCancel(r.Context()), updated.Email); err != nil {
+		c.logger.Error("fail invalidate user cache", "user_id", user.Id, slogext.Cause(err))
+	}
+
+	c.logger.Info("profile updated", "user_id", user.Id)
+
+	res := newSelfUserResponse(updated)
+	if req.Email != nil && *req.Email != updated.Email {
+		// profile is saved already, so failed mail is reported by leaving pending_email out; change can be requested again
+		err = service.RequestEmailChange(context.WithoutCancel(r.Context()), user.Id, *req.Email)
+		if err != nil {
+			c.logger.Error("fail request email change", "user_id", user.Id, slogext.Cause(err))
+		} else {
+			res.PendingEmail = *req.Email
+		}
+	}
+
+	c.writeJson(w, http.StatusOK, res)
+}
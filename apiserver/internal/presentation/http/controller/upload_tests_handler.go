@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"archive/zip"
+	"bytes"
+	"cplatform/internal/application/authentication/basic"
+	"cplatform/internal/application/contracts/application"
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"cplatform/pkg/slogext"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"unicode/utf8"
+)
+
+const maxTestsUploadSize = 512 << 20
+const maxTestFileSize = 64 << 20
+const maxArchiveUncompressedSize = 2 << 30
+const testsMultipartMemory = 32 << 20
+const testsManifestFileName = "tests.json"
+const checkerFileName = "checker.cpp"
+const maxCheckerSize = 1 << 20
+
+type UploadTestsResponse struct {
+	Revision ProblemRevisionResponse `json:"revision"`
+	TestSet  TestSetResponse         `json:"test_set"`
+}
+
+// UploadTestsHandler accepts multipart form with either zip "archive" or several "tests" files
+// named like 01.in and 01.ans; optional "manifest" field or tests.json inside archive describes
+// samples and subtasks, optional "checker" file or checker.cpp inside archive is custom checker
+func (c *Controller) UploadTestsHandler(w http.ResponseWriter, r *http.Request) {
+	user := basic.GetUser(r.Context())
+	if user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseIdVar(r, "id")
+	if err != nil {
+		c.writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxTestsUploadSize)
+	if err := r.ParseMultipartForm(testsMultipartMemory); err != nil {
+		c.writeErrors(w, http.StatusBadRequest, fmt.Errorf("%w: %s", presentation.ErrInvalidTests, err))
+		return
+	}
+	defer func() {
+		if err := r.MultipartForm.RemoveAll(); err != nil {
+			c.logger.Warn("fail remove multipart temp files", slogext.Cause(err))
+		}
+	}()
+
+	upload := &application.TestUpload{
+		ProblemId: domain.ProblemId(id),
+		EditorId:  user.Id,
+	}
+
+	if raw := r.FormValue("manifest"); raw != "" {
+		upload.Manifest = &application.TestManifest{}
+		if err := json.Unmarshal([]byte(raw), upload.Manifest); err != nil {
+			c.writeErrors(w, http.StatusBadRequest, fmt.Errorf("%w: manifest: %s", presentation.ErrInvalidJsonSchema, err))
+			return
+		}
+	}
+
+	archives := r.MultipartForm.File["archive"]
+	if len(archives) > 1 {
+		c.writeErrors(w, http.StatusBadRequest, fmt.Errorf("%w: only one archive allowed", presentation.ErrInvalidTests))
+		return
+	}
+
+	if len(archives) == 1 {
+		archive, err := archives[0].Open()
+		if err != nil {
+			c.writeErrors(w, http.StatusInternalServerError, err)
+			return
+		}
+		defer archive.Close()
+
+		if err := addArchiveFiles(upload, archive, archives[0].Size); err != nil {
+			c.writeErrors(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	checkers := r.MultipartForm.File["checker"]
+	if len(checkers) > 1 || (len(checkers) == 1 && upload.CheckerSource != "") {
+		c.writeErrors(w, http.StatusBadRequest, fmt.Errorf("%w: only one checker allowed", presentation.ErrInvalidTests))
+		return
+	}
+
+	if len(checkers) == 1 {
+		checker, err := checkers[0].Open()
+		if err != nil {
+			c.writeErrors(w, http.StatusInternalServerError, err)
+			return
+		}
+		defer checker.Close()
+
+		if err := readChecker(upload, checker); err != nil {
+			c.writeErrors(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	for _, header := range r.MultipartForm.File["tests"] {
+		upload.Files = append(upload.Files, application.TestFile{
+			Name: header.Filename,
+			Size: header.Size,
+			Open: func() (io.ReadCloser, error) { return header.Open() },
+		})
+	}
+
+	for _, file := range upload.Files {
+		if file.Size > maxTestFileSize {
+			c.writeErrors(w, http.StatusBadRequest, fmt.Errorf("%w: file %q exceeds %d bytes", presentation.ErrInvalidTests, file.Name, maxTestFileSize))
+			return
+		}
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	revision, set, err := scope.ProblemService(r.Context()).UploadTests(r.Context(), upload)
+	if err != nil {
+		c.writeServiceError(w, err, "fail upload tests")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("tests uploaded", "problem_id", id, "revision", revision.Revision, "test_set_id", set.Id, "tests", len(set.Tests))
+
+	res := UploadTestsResponse{
+		Revision: newProblemRevisionResponse(revision),
+		TestSet:  newTestSetResponse(set),
+	}
+
+	c.writeJson(w, http.StatusCreated, res)
+}
+
+func addArchiveFiles(upload *application.TestUpload, archive multipart.File, size int64) error {
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return fmt.Errorf("%w: archive: %s", presentation.ErrInvalidTests, err)
+	}
+
+	// zip reader fails entries inflating past declared size, so declared sizes bound what is actually stored
+	var total uint64
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		total += min(file.UncompressedSize64, maxArchiveUncompressedSize+1)
+		if total > maxArchiveUncompressedSize {
+			return fmt.Errorf("%w: archive content exceeds %d bytes", presentation.ErrInvalidTests, maxArchiveUncompressedSize)
+		}
+
+		if path.Base(file.Name) == testsManifestFileName {
+			if upload.Manifest != nil {
+				return fmt.Errorf("%w: manifest given both in form and archive", presentation.ErrInvalidTests)
+			}
+
+			if err := decodeArchiveManifest(upload, file); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if path.Base(file.Name) == checkerFileName {
+			if err := readArchiveChecker(upload, file); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		upload.Files = append(upload.Files, application.TestFile{
+			Name: file.Name,
+			Size: int64(file.UncompressedSize64),
+			Open: file.Open,
+		})
+	}
+
+	return nil
+}
+
+func decodeArchiveManifest(upload *application.TestUpload, file *zip.File) error {
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: manifest: %s", presentation.ErrInvalidTests, err)
+	}
+	defer content.Close()
+
+	upload.Manifest = &application.TestManifest{}
+	if err := json.NewDecoder(io.LimitReader(content, maxTestFileSize)).Decode(upload.Manifest); err != nil {
+		return fmt.Errorf("%w: manifest: %s", presentation.ErrInvalidJsonSchema, err)
+	}
+
+	return nil
+}
+
+func readArchiveChecker(upload *application.TestUpload, file *zip.File) error {
+	if upload.CheckerSource != "" {
+		return fmt.Errorf("%w: only one checker allowed", presentation.ErrInvalidTests)
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: checker: %s", presentation.ErrInvalidTests, err)
+	}
+	defer content.Close()
+
+	return readChecker(upload, content)
+}
+
+func readChecker(upload *application.TestUpload, content io.Reader) error {
+	source, err := io.ReadAll(io.LimitReader(content, maxCheckerSize+1))
+	if err != nil {
+		return fmt.Errorf("%w: checker: %s", presentation.ErrInvalidTests, err)
+	}
+
+	if len(source) > maxCheckerSize {
+		return fmt.Errorf("%w: checker exceeds %d bytes", presentation.ErrInvalidTests, maxCheckerSize)
+	}
+
+	if len(bytes.TrimSpace(source)) == 0 || !utf8.Valid(source) {
+		return fmt.Errorf("%w: checker must be non-empty utf-8 source", presentation.ErrInvalidTests)
+	}
+
+	upload.CheckerSource = string(source)
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"cplatform/internal/domain"
+	"cplatform/internal/presentation"
+	"fmt"
+	"unicode/utf8"
+)
+
+const maxOrganizationLength = 128
+const maxBioLength = 1024
+
+type UpdateProfileRequest struct {
+	Name         *string `json:"name"`
+	Email        *string `json:"email"`
+	Country      *string `json:"country"`
+	Organization *string `json:"organization"`
+	Bio          *string `json:"bio"`
+}
+
+// TokenRequest carries one-time token mailed to user
+type TokenRequest struct {
+	Token string `json:"token"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email"`
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// UserResponse is public profile, email is never shown to other users
+type UserResponse struct {
+	Id           int64  `json:"id"`
+	Name         string `json:"name"`
+	Country      string `json:"country"`
+	Organization string `json:"organization"`
+	Bio          string `json:"bio"`
+}
+
+type SelfUserResponse struct {
+	UserResponse
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	// PendingEmail is set when email change waits for confirmation
+	PendingEmail string `json:"pending_email,omitempty"`
+}
+
+func newUserResponse(user *domain.User) UserResponse {
+	return UserResponse{
+		Id:           int64(user.Id),
+		Name:         user.Name,
+		Country:      user.Country,
+		Organization: user.Organization,
+		Bio:          user.Bio,
+	}
+}
+
+func newSelfUserResponse(user *domain.User) SelfUserResponse {
+	return SelfUserResponse{
+		UserResponse:  newUserResponse(user),
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+	}
+}
+
+func validateUpdateProfileRequest(req *UpdateProfileRequest) []error {
+	var errs []error
+
+	if req.Name != nil {
+		errs = append(errs, validateName(*req.Name)...)
+	}
+
+	if req.Email != nil {
+		errs = append(errs, validateEmail(*req.Email)...)
+	}
+
+	if req.Country != nil && !isCountryCode(*req.Country) {
+		errs = append(errs, fmt.Errorf("%w: country must be ISO 3166-1 alpha-2 code or empty", presentation.ErrInvalidProfile))
+	}
+
+	if req.Organization != nil && utf8.RuneCountInString(*req.Organization) > maxOrganizationLength {
+		errs = append(errs, fmt.Errorf("%w: organization too long", presentation.ErrInvalidProfile))
+	}
+
+	if req.Bio != nil && utf8.RuneCountInString(*req.Bio) > maxBioLength {
+		errs = append(errs, fmt.Errorf("%w: bio too long", presentation.ErrInvalidProfile))
+	}
+
+	return errs
+}
+
+func isCountryCode(country string) bool {
+	if country == "" {
+		return true
+	}
+
+	if len(country) != 2 {
+		return false
+	}
+
+	for _, r := range country {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"net/http"
+)
+
+// VerifyEmailHandler takes token from query on GET, so link from mail works as is, and from json body on POST
+func (c *Controller) VerifyEmailHandler(w http.ResponseWriter, r *http.Request) {
+	var req TokenRequest
+	if r.Method == http.MethodGet {
+		req.Token = r.URL.Query().Get("token")
+	} else if !c.decodeJson(w, r, &req) {
+		return
+	}
+
+	scope := c.requestScope(w, r)
+	if scope == nil {
+		return
+	}
+
+	user, err := scope.UserService(r.Context()).VerifyEmail(r.Context(), req.Token)
+	if err != nil {
+		c.writeServiceError(w, err, "fail verify email")
+		return
+	}
+
+	if !c.commit(w, r, scope) {
+		return
+	}
+
+	c.logger.Info("email verified", "user_id", user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -12,21 +12,28 @@ type Pipeline struct {
 	router     *controller.Router
 	useRecover *RecoverMiddleware
 	useCors    *cors.Cors
+	probes     *Probes
 	logger     *slog.Logger
 }
 
-func NewPipeline(router *controller.Router, useRecover *RecoverMiddleware, useCors *cors.Cors, logger *slog.Logger) *Pipeline {
+func NewPipeline(router *controller.Router, useRecover *RecoverMiddleware, useCors *cors.Cors, probes *Probes, logger *slog.Logger) *Pipeline {
 	return &Pipeline{
 		router:     router,
 		useRecover: useRecover,
 		useCors:    useCors,
+		probes:     probes,
 		logger:     logger,
 	}
 }
 
 func (p *Pipeline) CreateHandler() http.Handler {
-	handler := p.router.CreateHandler()
-	handler = p.useCors.Handler(handler)
+	// probes are outside of /api and cors, they are meant for orchestrator rather than browsers
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", p.probes.LivenessHandler)
+	mux.HandleFunc("GET /readyz", p.probes.ReadinessHandler)
+	mux.Handle("/", p.useCors.Handler(p.router.CreateHandler()))
+
+	var handler http.Handler = mux
 	handler = p.useRecover.Middleware(handler)
 
 	return handler
@@ -0,0 +1,130 @@
+package pipeline
+
+import (
+	"context"
+	"cplatform/pkg/slogext"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// checkTimeout keeps readiness answered before probe of orchestrator times out even if dependency hangs
+const checkTimeout = 2 * time.Second
+
+const (
+	statusAlive        = "alive"
+	statusReady        = "ready"
+	statusNotReady     = "not_ready"
+	statusShuttingDown = "shutting_down"
+	statusUp           = "up"
+	statusDown         = "down"
+)
+
+// Check is dependency readiness depends on, like database
+type Check struct {
+	Name string
+	Ping func(ctx context.Context) error
+}
+
+// Probes serves liveness and readiness; they bypass authentication and scopes, so probing costs no transaction
+type Probes struct {
+	checks       []Check
+	shuttingDown atomic.Bool
+	logger       *slog.Logger
+}
+
+type livenessResponse struct {
+	Status string `json:"status"`
+}
+
+type readinessResponse struct {
+	Status string `json:"status"`
+	// Checks maps dependency name to up or down; failure details are only logged as they may reveal internal addresses
+	Checks map[string]string `json:"checks"`
+}
+
+func NewProbes(checks []Check, logger *slog.Logger) *Probes {
+	return &Probes{
+		checks: checks,
+		logger: logger,
+	}
+}
+
+// SetShuttingDown makes readiness fail, so traffic is drained away before server stops accepting connections
+func (p *Probes) SetShuttingDown() {
+	p.shuttingDown.Store(true)
+}
+
+// LivenessHandler reports that process serves requests; dependencies are not checked, as restarting server does not fix them
+func (p *Probes) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	p.writeJson(w, http.StatusOK, &livenessResponse{Status: statusAlive})
+}
+
+func (p *Probes) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	response := &readinessResponse{
+		Status: statusReady,
+		Checks: make(map[string]string, len(p.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range p.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+			defer cancel()
+
+			status := statusUp
+			if err := check.Ping(ctx); err != nil {
+				p.logger.Warn("readiness check failed", "dependency", check.Name, slogext.Cause(err))
+				status = statusDown
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			response.Checks[check.Name] = status
+			if status == statusDown {
+				response.Status = statusNotReady
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// checked after dependencies, so flag set while they were pinged is not missed
+	if p.shuttingDown.Load() {
+		response.Status = statusShuttingDown
+	}
+
+	status := http.StatusOK
+	if response.Status != statusReady {
+		status = http.StatusServiceUnavailable
+	}
+
+	p.writeJson(w, status, response)
+}
+
+func (p *Probes) writeJson(w http.ResponseWriter, status int, body any) {
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		p.logger.Error("fail marshal probe response", slogext.Cause(err))
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	// stale probe answer would hide shutdown or outage
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	if _, err := w.Write(jsonBytes); err != nil {
+		p.logger.Error("fail write probe response", slogext.Cause(err))
+	}
+}
@@ -0,0 +1,48 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy doubles delay after every failed attempt starting from Initial up to Max
+type Policy struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// Retry calls fn until it succeeds or ctx is done; onRetry, when not nil, is told about every failure before waiting
+func Retry(ctx context.Context, policy Policy, fn func(ctx context.Context) error, onRetry func(attempt int, delay time.Duration, err error)) error {
+	delay := policy.Initial
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return errors.Join(fmt.Errorf("give up after %d attempts: %w", attempt, ctx.Err()), err)
+		}
+
+		// jitter keeps replicas started together from retrying in lockstep
+		wait := delay/2 + rand.N(delay/2+1)
+
+		if onRetry != nil {
+			onRetry(attempt, wait, err)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(fmt.Errorf("give up after %d attempts: %w", attempt, ctx.Err()), err)
+		case <-timer.C:
+		}
+
+		delay = min(delay*2, policy.Max)
+	}
+}
@@ -0,0 +1,107 @@
+package certreload
+
+import (
+	"cplatform/pkg/slogext"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkInterval limits how often files are checked for changes, checks happen during handshakes
+const checkInterval = 10 * time.Second
+
+// Reloader serves certificate from files and rereads them once they change, so renewed certificates apply without restart
+type Reloader struct {
+	certFile string
+	keyFile  string
+	logger   *slog.Logger
+
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+	checkedAt   time.Time
+}
+
+// New fails if files cannot be loaded, later failures keep previous certificate
+func New(certFile string, keyFile string, logger *slog.Logger) (*Reloader, error) {
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		logger:   logger,
+	}
+
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.load(certModTime, keyModTime); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetCertificate is meant for tls.Config.GetCertificate
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.checkedAt) < checkInterval {
+		return r.cert, nil
+	}
+
+	r.checkedAt = now
+
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		r.logger.Error("certreload: fail check certificate files, keep previous certificate", slogext.Cause(err))
+		return r.cert, nil
+	}
+
+	if certModTime.Equal(r.certModTime) && keyModTime.Equal(r.keyModTime) {
+		return r.cert, nil
+	}
+
+	// files may be caught between writes of cert and key, then next check retries
+	if err := r.load(certModTime, keyModTime); err != nil {
+		r.logger.Error("certreload: fail reload certificate, keep previous certificate", slogext.Cause(err))
+		return r.cert, nil
+	}
+
+	r.logger.Info("certreload: certificate reloaded", "cert_file", r.certFile)
+
+	return r.cert, nil
+}
+
+func (r *Reloader) load(certModTime time.Time, keyModTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("fail load key pair: %w", err)
+	}
+
+	r.cert = &cert
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+
+	return nil
+}
+
+func (r *Reloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("fail stat certificate: %w", err)
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("fail stat key: %w", err)
+	}
+
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
@@ -0,0 +1,46 @@
+package poolstats
+
+import (
+	"expvar"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// PublishPgxPool exposes pool statistics as expvar under name; stats are read on every request to /debug/vars
+func PublishPgxPool(name string, pool *pgxpool.Pool) {
+	expvar.Publish(name, expvar.Func(func() any {
+		stat := pool.Stat()
+
+		return map[string]any{
+			"acquired_conns":             stat.AcquiredConns(),
+			"idle_conns":                 stat.IdleConns(),
+			"constructing_conns":         stat.ConstructingConns(),
+			"total_conns":                stat.TotalConns(),
+			"max_conns":                  stat.MaxConns(),
+			"acquire_count":              stat.AcquireCount(),
+			"acquire_duration_ms":        stat.AcquireDuration().Milliseconds(),
+			"empty_acquire_count":        stat.EmptyAcquireCount(),
+			"canceled_acquire_count":     stat.CanceledAcquireCount(),
+			"new_conns_count":            stat.NewConnsCount(),
+			"max_lifetime_destroy_count": stat.MaxLifetimeDestroyCount(),
+			"max_idle_destroy_count":     stat.MaxIdleDestroyCount(),
+		}
+	}))
+}
+
+// PublishRedisPool exposes connection pool statistics of client as expvar under name
+func PublishRedisPool(name string, client *redis.Client) {
+	expvar.Publish(name, expvar.Func(func() any {
+		stats := client.PoolStats()
+
+		return map[string]any{
+			"hits":        stats.Hits,
+			"misses":      stats.Misses,
+			"timeouts":    stats.Timeouts,
+			"total_conns": stats.TotalConns,
+			"idle_conns":  stats.IdleConns,
+			"stale_conns": stats.StaleConns,
+		}
+	}))
+}
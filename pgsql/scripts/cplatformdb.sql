@@ -1,5 +1,7 @@
 -- This script was generated by the ERD tool in pgAdmin 4.
 -- Please log an issue at https://github.com/pgadmin-org/pgadmin4/issues/new/choose if you find any bugs, including reproduction steps.
+-- Schema is changed by migrations in apiserver/internal/infrastructure/persistence/postgres/migrations/sql,
+-- this script mirrors their result for the ERD tool. Databases created by it are adopted with `migrate baseline 4`.
 BEGIN;
 
 
@@ -10,7 +12,396 @@ CREATE TABLE IF NOT EXISTS public.users
     email text NOT NULL,
     salt bytea NOT NULL,
     password_hash bytea NOT NULL,
+    country text NOT NULL DEFAULT '',
+    organization text NOT NULL DEFAULT '',
+    bio text NOT NULL DEFAULT '',
+    email_verified boolean NOT NULL DEFAULT false,
+    deleted_at timestamp with time zone,
     PRIMARY KEY (id),
     CONSTRAINT c_unique_user_email UNIQUE (email)
 );
+
+CREATE TABLE IF NOT EXISTS public.user_identities
+(
+    provider text NOT NULL,
+    subject text NOT NULL,
+    user_id bigint NOT NULL,
+    email text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, subject)
+);
+
+ALTER TABLE IF EXISTS public.user_identities
+    ADD CONSTRAINT fk_user_identity_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS i_user_identity_user
+    ON public.user_identities(user_id);
+
+CREATE TABLE IF NOT EXISTS public.access_tokens
+(
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    name text NOT NULL,
+    token_hash text NOT NULL,
+    scopes text[] NOT NULL DEFAULT '{}',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    expires_at timestamp with time zone,
+    PRIMARY KEY (id),
+    CONSTRAINT c_unique_access_token_name UNIQUE (user_id, name),
+    CONSTRAINT c_unique_access_token_hash UNIQUE (token_hash)
+);
+
+ALTER TABLE IF EXISTS public.access_tokens
+    ADD CONSTRAINT fk_access_token_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS public.user_roles
+(
+    user_id bigint NOT NULL,
+    role text NOT NULL,
+    granted_by bigint NOT NULL,
+    granted_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, role),
+    CONSTRAINT c_user_role CHECK (role IN ('admin', 'problem_setter'))
+);
+
+ALTER TABLE IF EXISTS public.user_roles
+    ADD CONSTRAINT fk_user_role_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.user_roles
+    ADD CONSTRAINT fk_user_role_granter FOREIGN KEY (granted_by)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+CREATE TABLE IF NOT EXISTS public.contests
+(
+    id bigserial NOT NULL,
+    title text NOT NULL,
+    description text NOT NULL,
+    start_time timestamp with time zone NOT NULL,
+    duration_seconds bigint NOT NULL,
+    freeze_time timestamp with time zone,
+    visibility text NOT NULL,
+    scoring_mode text NOT NULL DEFAULT 'icpc',
+    registration_deadline timestamp with time zone,
+    capacity integer NOT NULL DEFAULT 0,
+    invite_code text NOT NULL DEFAULT '',
+    require_verified_email boolean NOT NULL DEFAULT false,
+    owner_id bigint NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT c_contest_visibility CHECK (visibility IN ('public', 'private')),
+    CONSTRAINT c_contest_scoring_mode CHECK (scoring_mode IN ('icpc', 'ioi')),
+    CONSTRAINT c_contest_duration CHECK (duration_seconds > 0),
+    CONSTRAINT c_contest_capacity CHECK (capacity >= 0)
+);
+
+ALTER TABLE IF EXISTS public.contests
+    ADD CONSTRAINT fk_contest_owner FOREIGN KEY (owner_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+CREATE INDEX IF NOT EXISTS i_contest_start_time
+    ON public.contests(start_time);
+
+CREATE TABLE IF NOT EXISTS public.contest_roles
+(
+    contest_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    role text NOT NULL,
+    granted_by bigint NOT NULL,
+    granted_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (contest_id, user_id, role),
+    CONSTRAINT c_contest_role CHECK (role IN ('contest_owner', 'jury'))
+);
+
+ALTER TABLE IF EXISTS public.contest_roles
+    ADD CONSTRAINT fk_contest_role_contest FOREIGN KEY (contest_id)
+    REFERENCES public.contests (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.contest_roles
+    ADD CONSTRAINT fk_contest_role_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.contest_roles
+    ADD CONSTRAINT fk_contest_role_granter FOREIGN KEY (granted_by)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+CREATE INDEX IF NOT EXISTS i_contest_role_user
+    ON public.contest_roles(user_id);
+
+CREATE TABLE IF NOT EXISTS public.teams
+(
+    id bigserial NOT NULL,
+    name text NOT NULL,
+    captain_id bigint NOT NULL,
+    invite_token text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (id),
+    CONSTRAINT c_unique_team_name UNIQUE (name),
+    CONSTRAINT c_unique_team_invite_token UNIQUE (invite_token)
+);
+
+ALTER TABLE IF EXISTS public.teams
+    ADD CONSTRAINT fk_team_captain FOREIGN KEY (captain_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+CREATE TABLE IF NOT EXISTS public.team_members
+(
+    team_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    joined_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+ALTER TABLE IF EXISTS public.team_members
+    ADD CONSTRAINT fk_team_member_team FOREIGN KEY (team_id)
+    REFERENCES public.teams (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.team_members
+    ADD CONSTRAINT fk_team_member_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS i_team_member_user
+    ON public.team_members(user_id);
+
+CREATE TABLE IF NOT EXISTS public.contest_participants
+(
+    contest_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    team_id bigint,
+    registered_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (contest_id, user_id)
+);
+
+ALTER TABLE IF EXISTS public.contest_participants
+    ADD CONSTRAINT fk_contest_participant_contest FOREIGN KEY (contest_id)
+    REFERENCES public.contests (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.contest_participants
+    ADD CONSTRAINT fk_contest_participant_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.contest_participants
+    ADD CONSTRAINT fk_contest_participant_team FOREIGN KEY (team_id)
+    REFERENCES public.teams (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS i_contest_participant_user
+    ON public.contest_participants(user_id);
+
+CREATE TABLE IF NOT EXISTS public.problems
+(
+    id bigserial NOT NULL,
+    author_id bigint NOT NULL,
+    latest_revision integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE IF EXISTS public.problems
+    ADD CONSTRAINT fk_problem_author FOREIGN KEY (author_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+CREATE INDEX IF NOT EXISTS i_problem_author
+    ON public.problems(author_id);
+
+CREATE TABLE IF NOT EXISTS public.problem_revisions
+(
+    problem_id bigint NOT NULL,
+    revision integer NOT NULL,
+    title text NOT NULL,
+    statement text NOT NULL,
+    input_spec text NOT NULL,
+    output_spec text NOT NULL,
+    time_limit_ms integer NOT NULL,
+    memory_limit_kb bigint NOT NULL,
+    tags text[] NOT NULL DEFAULT '{}',
+    checker text NOT NULL DEFAULT 'tokens',
+    checker_abs_epsilon double precision NOT NULL DEFAULT 0,
+    checker_rel_epsilon double precision NOT NULL DEFAULT 0,
+    test_set_id bigint,
+    author_id bigint NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (problem_id, revision),
+    CONSTRAINT c_problem_revision_checker CHECK (checker IN ('exact', 'tokens', 'float', 'custom'))
+);
+
+ALTER TABLE IF EXISTS public.problem_revisions
+    ADD CONSTRAINT fk_problem_revision_problem FOREIGN KEY (problem_id)
+    REFERENCES public.problems (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.problem_revisions
+    ADD CONSTRAINT fk_problem_revision_author FOREIGN KEY (author_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+CREATE TABLE IF NOT EXISTS public.test_sets
+(
+    id bigserial NOT NULL,
+    problem_id bigint NOT NULL,
+    checker_source text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE IF EXISTS public.test_sets
+    ADD CONSTRAINT fk_test_set_problem FOREIGN KEY (problem_id)
+    REFERENCES public.problems (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.problem_revisions
+    ADD CONSTRAINT fk_problem_revision_test_set FOREIGN KEY (test_set_id)
+    REFERENCES public.test_sets (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+CREATE TABLE IF NOT EXISTS public.test_set_subtasks
+(
+    test_set_id bigint NOT NULL,
+    subtask integer NOT NULL,
+    score integer NOT NULL,
+    PRIMARY KEY (test_set_id, subtask)
+);
+
+ALTER TABLE IF EXISTS public.test_set_subtasks
+    ADD CONSTRAINT fk_subtask_test_set FOREIGN KEY (test_set_id)
+    REFERENCES public.test_sets (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS public.test_set_tests
+(
+    test_set_id bigint NOT NULL,
+    ordinal integer NOT NULL,
+    subtask integer NOT NULL DEFAULT 0,
+    is_sample boolean NOT NULL DEFAULT false,
+    input_size bigint NOT NULL,
+    answer_size bigint NOT NULL,
+    PRIMARY KEY (test_set_id, ordinal)
+);
+
+ALTER TABLE IF EXISTS public.test_set_tests
+    ADD CONSTRAINT fk_test_test_set FOREIGN KEY (test_set_id)
+    REFERENCES public.test_sets (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS public.contest_problems
+(
+    contest_id bigint NOT NULL,
+    label text NOT NULL,
+    problem_id bigint NOT NULL,
+    revision integer NOT NULL,
+    PRIMARY KEY (contest_id, label)
+);
+
+ALTER TABLE IF EXISTS public.contest_problems
+    ADD CONSTRAINT fk_contest_problem_contest FOREIGN KEY (contest_id)
+    REFERENCES public.contests (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.contest_problems
+    ADD CONSTRAINT fk_contest_problem_revision FOREIGN KEY (problem_id, revision)
+    REFERENCES public.problem_revisions (problem_id, revision) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+CREATE TABLE IF NOT EXISTS public.submissions
+(
+    id bigserial NOT NULL,
+    contest_id bigint NOT NULL,
+    problem_label text NOT NULL,
+    problem_id bigint NOT NULL,
+    problem_revision integer NOT NULL,
+    user_id bigint NOT NULL,
+    team_id bigint,
+    language_id text NOT NULL,
+    source text NOT NULL,
+    state text NOT NULL,
+    submitted_at timestamp with time zone NOT NULL DEFAULT now(),
+    verdict text,
+    score integer,
+    subtask_scores integer[],
+    failed_test integer,
+    time_ms bigint,
+    memory_kb bigint,
+    judge_log text,
+    judged_at timestamp with time zone,
+    enqueued_at timestamp with time zone,
+    claimed_at timestamp with time zone,
+    PRIMARY KEY (id),
+    CONSTRAINT c_submission_state CHECK (state IN ('queued', 'judging', 'judged', 'failed')),
+    CONSTRAINT c_submission_verdict CHECK (verdict IN ('OK', 'WA', 'TLE', 'MLE', 'RE', 'CE'))
+);
+
+ALTER TABLE IF EXISTS public.submissions
+    ADD CONSTRAINT fk_submission_contest FOREIGN KEY (contest_id)
+    REFERENCES public.contests (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.submissions
+    ADD CONSTRAINT fk_submission_problem_revision FOREIGN KEY (problem_id, problem_revision)
+    REFERENCES public.problem_revisions (problem_id, revision) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+ALTER TABLE IF EXISTS public.submissions
+    ADD CONSTRAINT fk_submission_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+ALTER TABLE IF EXISTS public.submissions
+    ADD CONSTRAINT fk_submission_team FOREIGN KEY (team_id)
+    REFERENCES public.teams (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS i_submission_contest_user
+    ON public.submissions(contest_id, user_id);
+
+CREATE INDEX IF NOT EXISTS i_submission_contest_team
+    ON public.submissions(contest_id, team_id);
+
+CREATE INDEX IF NOT EXISTS i_submission_contest_time
+    ON public.submissions(contest_id, submitted_at);
+
+CREATE INDEX IF NOT EXISTS i_submission_queued
+    ON public.submissions(submitted_at)
+    WHERE state = 'queued';
 END;
@@ -1 +1,4 @@
 -- Seed script for cplatformdb.sql
+
+-- The first admin has to be granted by hand after registration, further roles are managed through API:
+-- INSERT INTO public.user_roles (user_id, role, granted_by) SELECT id, 'admin', id FROM public.users WHERE email = 'admin@example.com';